@@ -4,11 +4,13 @@ import (
 	"ditto-backend/internal/middleware"
 	"ditto-backend/internal/routes"
 	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/caldav"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/response"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -60,6 +62,13 @@ func main() {
 		response.Success(c, gin.H{"status": "ok"})
 	})
 
+	// Uploads are only scanned when a clamd address is configured. One
+	// scanner is shared by every route that stores files.
+	fileScanner, err := routes.NewFileScanner(appState)
+	if err != nil {
+		log.Fatalf("Failed to initialize file scanner: %v", err)
+	}
+
 	apiGroup := r.Group("/api")
 	{
 		routes.RegisterAuthRoutes(apiGroup, appState)
@@ -67,23 +76,63 @@ func main() {
 		routes.RegisterCompanyRoutes(apiGroup, appState)
 		routes.RegisterJobRoutes(apiGroup, appState)
 		routes.RegisterExtractRoutes(apiGroup, appState)
-		routes.RegisterFileRoutes(apiGroup, appState)
+		routes.RegisterFileRoutes(apiGroup, appState, fileScanner)
+		routes.RegisterStorageRoutes(apiGroup, appState)
 		routes.RegisterInterviewRoutes(apiGroup, appState)
 		routes.RegisterInterviewerRoutes(apiGroup, appState)
 		routes.RegisterInterviewQuestionRoutes(apiGroup, appState)
+		routes.RegisterQuestionBankRoutes(apiGroup, appState)
+		routes.RegisterPracticeRoutes(apiGroup, appState)
+		routes.RegisterStoryRoutes(apiGroup, appState)
 		routes.RegisterInterviewNoteRoutes(apiGroup, appState)
-		routes.RegisterAssessmentRoutes(apiGroup, appState)
+		routes.RegisterNoteRenderRoutes(apiGroup, appState)
+		routes.RegisterAssessmentRoutes(apiGroup, appState, fileScanner)
 		routes.RegisterDashboardRoutes(apiGroup, appState)
 		routes.RegisterNotificationRoutes(apiGroup, appState)
 		routes.RegisterTimelineRoutes(apiGroup, appState)
 		routes.RegisterSearchRoutes(apiGroup, appState)
 		routes.RegisterExportRoutes(apiGroup, appState)
 		routes.RegisterAccountRoutes(apiGroup, appState)
+		routes.RegisterExchangeRateRoutes(apiGroup, appState)
+		routes.RegisterCalendarSyncRoutes(apiGroup, appState)
 	}
 
-	scheduler := services.NewNotificationScheduler(appState.DB)
+	if ratesFile := os.Getenv("EXCHANGE_RATES_FILE"); ratesFile != "" {
+		if _, err := services.ImportExchangeRatesFile(appState.DB, ratesFile); err != nil {
+			log.Printf("Failed to import exchange rates: %v", err)
+		}
+	}
+
+	// Hours after an interview before a missing thank-you note is reminded about
+	thankYouHours := 24
+	if hours, err := strconv.Atoi(os.Getenv("THANK_YOU_REMINDER_HOURS")); err == nil && hours > 0 {
+		thankYouHours = hours
+	}
+
+	scheduler := services.NewNotificationScheduler(appState.DB, time.Duration(thankYouHours)*time.Hour)
 	scheduler.Start(15 * time.Minute)
 
+	expiryChecker := services.NewJobExpiryChecker(appState.DB)
+	expiryChecker.Start(6 * time.Hour)
+
+	enrichmentScheduler := services.NewCompanyEnrichmentScheduler(appState.DB)
+	enrichmentScheduler.Start(24 * time.Hour)
+
+	// Calendar sync only runs when passwords can be decrypted
+	var calendarSyncScheduler *services.CalendarSyncScheduler
+	if sealer, err := caldav.NewSealer(os.Getenv("CALDAV_ENCRYPTION_KEY")); err == nil {
+		syncMinutes := 15
+		if minutes, err := strconv.Atoi(os.Getenv("CALDAV_SYNC_INTERVAL_MINUTES")); err == nil && minutes > 0 {
+			syncMinutes = minutes
+		}
+		calendarSyncScheduler = services.NewCalendarSyncScheduler(appState.DB, sealer)
+		calendarSyncScheduler.Start(time.Duration(syncMinutes) * time.Minute)
+	}
+
+	if fileScanner != nil {
+		fileScanner.Start(5 * time.Minute)
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
@@ -95,6 +144,16 @@ func main() {
 		<-sigChan
 		log.Println("Shutting down...")
 		scheduler.Stop()
+		expiryChecker.Stop()
+		enrichmentScheduler.Stop()
+		if calendarSyncScheduler != nil {
+			calendarSyncScheduler.Stop()
+		}
+		if fileScanner != nil {
+			fileScanner.Stop()
+			// Let scans of just-confirmed uploads record their verdict
+			fileScanner.Wait()
+		}
 		os.Exit(0)
 	}()
 
@@ -34,8 +34,11 @@ type QuickCreateApplicationReq struct {
 	SourceURL           string     `json:"source_url" binding:"omitempty,url,max=2048"`
 	Platform            string     `json:"platform" binding:"omitempty,max=50"`
 	Notes               string     `json:"notes" binding:"max=10000"`
+	NotesFormat         string     `json:"notes_format" binding:"omitempty,oneof=html markdown"`
 	MinSalary           *float64   `json:"min_salary,omitempty"`
 	MaxSalary           *float64   `json:"max_salary,omitempty"`
+	Currency            string     `json:"currency" binding:"omitempty,max=10"`
+	PayPeriod           string     `json:"pay_period" binding:"omitempty,oneof=hourly daily weekly monthly annual"`
 	ApplicationStatusID *uuid.UUID `json:"application_status_id,omitempty"`
 }
 
@@ -160,6 +163,11 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		return
 	}
 
+	if !models.IsValidNoteFormat(application.NotesFormat) {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "notes_format must be html or markdown"))
+		return
+	}
+
 	if application.AppliedAt.IsZero() {
 		application.AppliedAt = time.Now()
 	}
@@ -214,6 +222,12 @@ func (h *ApplicationHandler) QuickCreateApplication(c *gin.Context) {
 	if req.MaxSalary != nil {
 		job.MaxSalary = req.MaxSalary
 	}
+	if req.Currency != "" {
+		job.Currency = &req.Currency
+	}
+	if req.PayPeriod != "" {
+		job.PayPeriod = &req.PayPeriod
+	}
 
 	createdJob, err := h.jobRepo.CreateJob(userID, job)
 	if err != nil {
@@ -242,6 +256,7 @@ func (h *ApplicationHandler) QuickCreateApplication(c *gin.Context) {
 
 	if req.Notes != "" {
 		application.Notes = &req.Notes
+		application.NotesFormat = req.NotesFormat
 	}
 
 	createdApplication, err := h.applicationRepo.CreateApplication(userID, application)
@@ -308,6 +323,12 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 	if req.MaxSalary != nil {
 		jobUpdates["max_salary"] = *req.MaxSalary
 	}
+	if req.Currency != "" {
+		jobUpdates["currency"] = req.Currency
+	}
+	if req.PayPeriod != "" {
+		jobUpdates["pay_period"] = req.PayPeriod
+	}
 
 	_, err = h.jobRepo.UpdateJob(existingApp.JobID, userID, jobUpdates)
 	if err != nil {
@@ -319,6 +340,12 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 	appUpdates := map[string]any{}
 	if req.Notes != "" {
 		appUpdates["notes"] = req.Notes
+		// The editor sends HTML, so notes saved without a format must not
+		// keep an earlier Markdown format.
+		appUpdates["notes_format"] = models.NoteFormatHTML
+	}
+	if req.NotesFormat != "" {
+		appUpdates["notes_format"] = req.NotesFormat
 	}
 
 	if len(appUpdates) > 0 {
@@ -361,8 +388,8 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 
 	h.dashboardRepo.InvalidateCache(userID)
 
-	// Return updated application
-	application, err := h.applicationRepo.GetApplicationByID(applicationID, userID)
+	// Return updated application with the statuses it can move to next
+	application, err := h.applicationRepo.GetApplicationByIDWithDetails(applicationID, userID)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -533,12 +560,25 @@ func parseApplicationFilters(c *gin.Context) *repository.ApplicationFilters {
 		}
 	}
 
+	if minSalaryStr := c.Query("min_salary"); minSalaryStr != "" {
+		if minSalary, err := strconv.ParseFloat(minSalaryStr, 64); err == nil && minSalary >= 0 {
+			filters.MinSalary = &minSalary
+		}
+	}
+
+	if maxSalaryStr := c.Query("max_salary"); maxSalaryStr != "" {
+		if maxSalary, err := strconv.ParseFloat(maxSalaryStr, 64); err == nil && maxSalary >= 0 {
+			filters.MaxSalary = &maxSalary
+		}
+	}
+
 	// Parse sort params
 	if sortBy := c.Query("sort_by"); sortBy != "" {
 		// Validate sort column
 		validSortColumns := map[string]bool{
 			"company": true, "position": true, "status": true,
 			"applied_at": true, "location": true, "updated_at": true, "job_type": true,
+			"salary": true,
 		}
 		if validSortColumns[sortBy] {
 			filters.SortBy = sortBy
@@ -10,6 +10,10 @@ import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
 	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/github"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/internal/services/schedule"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
@@ -20,6 +24,7 @@ type CreateAssessmentRequest struct {
 	AssessmentType string    `json:"assessment_type" binding:"required,oneof=take_home_project live_coding system_design data_structures case_study other"`
 	Title          string    `json:"title" binding:"required,max=255"`
 	DueDate        string    `json:"due_date" binding:"required"`
+	DueTimezone    string    `json:"due_timezone" binding:"omitempty,max=64"`
 	Instructions   *string   `json:"instructions"`
 	Requirements   *string   `json:"requirements"`
 }
@@ -28,6 +33,7 @@ type UpdateAssessmentRequest struct {
 	Title          *string `json:"title" binding:"omitempty,max=255"`
 	AssessmentType *string `json:"assessment_type" binding:"omitempty,oneof=take_home_project live_coding system_design data_structures case_study other"`
 	DueDate        *string `json:"due_date"`
+	DueTimezone    *string `json:"due_timezone" binding:"omitempty,max=64"`
 	Status         *string `json:"status" binding:"omitempty,oneof=not_started in_progress submitted passed failed"`
 	Instructions   *string `json:"instructions"`
 	Requirements   *string `json:"requirements"`
@@ -35,6 +41,8 @@ type UpdateAssessmentRequest struct {
 
 type UpdateStatusRequest struct {
 	Status string `json:"status" binding:"required,oneof=not_started in_progress submitted passed failed"`
+	// Submission is recorded along with a move to submitted
+	Submission *CreateSubmissionRequest `json:"submission"`
 }
 
 type CreateSubmissionRequest struct {
@@ -42,6 +50,9 @@ type CreateSubmissionRequest struct {
 	GithubURL      *string    `json:"github_url"`
 	FileID         *uuid.UUID `json:"file_id"`
 	Notes          *string    `json:"notes"`
+	NotesFormat    string     `json:"notes_format" binding:"omitempty,oneof=html markdown"`
+	// Archive stores a tarball of the submitted commit in file storage
+	Archive bool `json:"archive"`
 }
 
 type AssessmentHandler struct {
@@ -50,26 +61,34 @@ type AssessmentHandler struct {
 	applicationRepo *repository.ApplicationRepository
 	fileRepo        *repository.FileRepository
 	dashboardRepo   *repository.DashboardRepository
+	timeSessionRepo *repository.AssessmentTimeSessionRepository
 	sanitizer       *services.SanitizerService
+	github          github.Client
+	s3Service       s3service.S3ServiceInterface
+	fileScanner     *services.FileScanner
 }
 
-func NewAssessmentHandler(appState *utils.AppState) *AssessmentHandler {
+// NewAssessmentHandler creates an AssessmentHandler. fileScanner may be nil,
+// in which case submission archives aren't scanned for malware.
+func NewAssessmentHandler(appState *utils.AppState, githubClient github.Client, s3Service s3service.S3ServiceInterface, fileScanner *services.FileScanner) *AssessmentHandler {
 	return &AssessmentHandler{
 		assessmentRepo:  repository.NewAssessmentRepository(appState.DB),
 		submissionRepo:  repository.NewAssessmentSubmissionRepository(appState.DB),
 		applicationRepo: repository.NewApplicationRepository(appState.DB),
 		fileRepo:        repository.NewFileRepository(appState.DB),
 		dashboardRepo:   repository.NewDashboardRepository(appState.DB),
+		timeSessionRepo: repository.NewAssessmentTimeSessionRepository(appState.DB),
 		sanitizer:       appState.Sanitizer,
+		github:          githubClient,
+		s3Service:       s3Service,
+		fileScanner:     fileScanner,
 	}
 }
 
-// formatDueDate formats a DueDate string from the database (e.g. "2026-05-01T00:00:00Z")
-// to YYYY-MM-DD format for API responses.
+// formatDueDate shows the due date in the time zone it was set in, so API
+// responses read "2026-05-01T17:00:00-07:00" rather than the UTC instant.
 func formatDueDate(assessment *models.Assessment) {
-	if len(assessment.DueDate) > 10 {
-		assessment.DueDate = assessment.DueDate[:10]
-	}
+	assessment.DueDate = assessment.DueDate.In(assessment.DueLocation())
 }
 
 func formatDueDates(assessments []*models.Assessment) {
@@ -78,6 +97,46 @@ func formatDueDates(assessments []*models.Assessment) {
 	}
 }
 
+// parseDueDate reads a due date given as a date, a wall-clock time in
+// timezone or a timestamp with an offset. timezone is an IANA name and
+// defaults to UTC; it is returned for storing with the deadline.
+func parseDueDate(value, timezone string) (time.Time, string, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return time.Time{}, "", errors.New(errors.ErrorBadRequest, "invalid due_timezone, use an IANA name such as America/Los_Angeles")
+	}
+
+	dueDate, ok := schedule.ParseDeadline(value, loc)
+	if !ok {
+		return time.Time{}, "", errors.New(errors.ErrorBadRequest, "invalid due date format, use YYYY-MM-DD, YYYY-MM-DDTHH:MM or RFC 3339")
+	}
+	return dueDate, timezone, nil
+}
+
+// updatedDueDate works out the deadline after an update. Changing only the
+// time zone keeps the wall-clock time, so "5pm" set in the wrong zone becomes
+// 5pm in the right one.
+func (h *AssessmentHandler) updatedDueDate(assessmentID, userID uuid.UUID, req *UpdateAssessmentRequest) (time.Time, string, error) {
+	if req.DueDate != nil && req.DueTimezone != nil {
+		return parseDueDate(*req.DueDate, *req.DueTimezone)
+	}
+
+	current, err := h.assessmentRepo.GetAssessmentByID(assessmentID, userID)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if req.DueDate != nil {
+		return parseDueDate(*req.DueDate, current.DueTimezone)
+	}
+
+	wallClock := current.DueDate.In(current.DueLocation()).Format("2006-01-02T15:04:05")
+	return parseDueDate(wallClock, *req.DueTimezone)
+}
+
 func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 	userID := c.MustGet("user_id").(uuid.UUID)
 
@@ -87,10 +146,9 @@ func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 		return
 	}
 
-	// Validate due_date format
-	_, err := time.Parse("2006-01-02", req.DueDate)
+	dueDate, dueTimezone, err := parseDueDate(req.DueDate, req.DueTimezone)
 	if err != nil {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid due date format, use YYYY-MM-DD"))
+		HandleError(c, err)
 		return
 	}
 
@@ -115,7 +173,8 @@ func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 		ApplicationID:  req.ApplicationID,
 		AssessmentType: req.AssessmentType,
 		Title:          req.Title,
-		DueDate:        req.DueDate,
+		DueDate:        dueDate,
+		DueTimezone:    dueTimezone,
 		Instructions:   req.Instructions,
 		Requirements:   req.Requirements,
 	}
@@ -130,7 +189,8 @@ func (h *AssessmentHandler) CreateAssessment(c *gin.Context) {
 	formatDueDate(createdAssessment)
 
 	response.Success(c, gin.H{
-		"assessment": createdAssessment,
+		"assessment":          createdAssessment,
+		"allowed_transitions": workflow.Assessment.Allowed(createdAssessment.Status),
 	})
 }
 
@@ -152,7 +212,8 @@ func (h *AssessmentHandler) GetAssessment(c *gin.Context) {
 	formatDueDate(assessment)
 
 	response.Success(c, gin.H{
-		"assessment": assessment,
+		"assessment":          assessment,
+		"allowed_transitions": workflow.Assessment.Allowed(assessment.Status),
 	})
 }
 
@@ -198,11 +259,8 @@ func (h *AssessmentHandler) ListAssessments(c *gin.Context) {
 		assessments = []*repository.AssessmentWithContext{}
 	}
 
-	// Format due dates
 	for _, a := range assessments {
-		if len(a.DueDate) > 10 {
-			a.DueDate = a.DueDate[:10]
-		}
+		formatDueDate(&a.Assessment)
 	}
 
 	response.Success(c, gin.H{
@@ -236,13 +294,14 @@ func (h *AssessmentHandler) UpdateAssessment(c *gin.Context) {
 		updates["assessment_type"] = *req.AssessmentType
 	}
 
-	if req.DueDate != nil {
-		// Validate date format
-		if _, err := time.Parse("2006-01-02", *req.DueDate); err != nil {
-			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid due date format, use YYYY-MM-DD"))
+	if req.DueDate != nil || req.DueTimezone != nil {
+		dueDate, dueTimezone, err := h.updatedDueDate(assessmentID, userID, &req)
+		if err != nil {
+			HandleError(c, err)
 			return
 		}
-		updates["due_date"] = *req.DueDate
+		updates["due_date"] = dueDate
+		updates["due_timezone"] = dueTimezone
 	}
 
 	if req.Status != nil {
@@ -275,7 +334,8 @@ func (h *AssessmentHandler) UpdateAssessment(c *gin.Context) {
 	formatDueDate(updatedAssessment)
 
 	response.Success(c, gin.H{
-		"assessment": updatedAssessment,
+		"assessment":          updatedAssessment,
+		"allowed_transitions": workflow.Assessment.Allowed(updatedAssessment.Status),
 	})
 }
 
@@ -316,12 +376,41 @@ func (h *AssessmentHandler) UpdateStatus(c *gin.Context) {
 	// Normalize status value
 	status := strings.TrimSpace(req.Status)
 
-	updates := map[string]any{
-		"status": status,
+	var submission *models.AssessmentSubmission
+	var warnings []string
+	if req.Submission != nil {
+		if status != models.AssessmentStatusSubmitted {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "a submission can only be recorded when moving to submitted"))
+			return
+		}
+		submission, err = h.newSubmission(req.Submission, userID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+
+		// Check the transition before calling GitHub so a rejected status
+		// change doesn't leave an archive behind. TransitionStatus checks again.
+		assessment, err := h.assessmentRepo.GetAssessmentByID(assessmentID, userID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		if err := workflow.Assessment.Check(assessment.Status, status); err != nil {
+			HandleError(c, err)
+			return
+		}
+
+		warnings, err = h.snapshotSubmission(c.Request.Context(), req.Submission, submission, assessment)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
 	}
 
-	updatedAssessment, err := h.assessmentRepo.UpdateAssessment(assessmentID, userID, updates)
+	updatedAssessment, createdSubmission, err := h.assessmentRepo.TransitionStatus(assessmentID, userID, status, submission)
 	if err != nil {
+		h.discardArchive(c.Request.Context(), userID, submission)
 		HandleError(c, err)
 		return
 	}
@@ -329,9 +418,14 @@ func (h *AssessmentHandler) UpdateStatus(c *gin.Context) {
 	h.dashboardRepo.InvalidateCache(userID)
 	formatDueDate(updatedAssessment)
 
-	response.Success(c, gin.H{
-		"assessment": updatedAssessment,
-	})
+	resp := gin.H{
+		"assessment":          updatedAssessment,
+		"allowed_transitions": workflow.Assessment.Allowed(updatedAssessment.Status),
+	}
+	if createdSubmission != nil {
+		resp["submission"] = createdSubmission
+	}
+	response.SuccessWithWarnings(c, resp, warnings)
 }
 
 func (h *AssessmentHandler) CreateSubmission(c *gin.Context) {
@@ -349,65 +443,77 @@ func (h *AssessmentHandler) CreateSubmission(c *gin.Context) {
 		return
 	}
 
-	if req.SubmissionType == "github" && (req.GithubURL == nil || *req.GithubURL == "") {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "github_url is required for github submissions"))
+	submission, err := h.newSubmission(&req, userID)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
+	submission.AssessmentID = assessmentID
 
-	if req.SubmissionType == "file_upload" && req.FileID == nil {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "file_id is required for file_upload submissions"))
+	assessment, err := h.assessmentRepo.GetAssessmentByID(assessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
-	if req.SubmissionType == "notes" && (req.Notes == nil || *req.Notes == "") {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "notes is required for notes submissions"))
+	warnings, err := h.snapshotSubmission(c.Request.Context(), &req, submission, assessment)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
+	createdSubmission, err := h.submissionRepo.CreateSubmission(submission)
+	if err != nil {
+		h.discardArchive(c.Request.Context(), userID, submission)
+		HandleError(c, err)
+		return
+	}
+
+	response.CreatedWithWarnings(c, gin.H{
+		"submission": createdSubmission,
+	}, warnings)
+}
+
+// newSubmission checks a submission request and builds the submission it
+// describes. The caller sets the assessment.
+func (h *AssessmentHandler) newSubmission(req *CreateSubmissionRequest, userID uuid.UUID) (*models.AssessmentSubmission, error) {
+	if req.SubmissionType == "github" && (req.GithubURL == nil || *req.GithubURL == "") {
+		return nil, errors.New(errors.ErrorBadRequest, "github_url is required for github submissions")
+	}
+
+	if req.SubmissionType == "file_upload" && req.FileID == nil {
+		return nil, errors.New(errors.ErrorBadRequest, "file_id is required for file_upload submissions")
+	}
+
+	if req.SubmissionType == "notes" && (req.Notes == nil || *req.Notes == "") {
+		return nil, errors.New(errors.ErrorBadRequest, "notes is required for notes submissions")
+	}
+
+	if req.Archive && req.SubmissionType != "github" {
+		return nil, errors.New(errors.ErrorBadRequest, "archive is only available for github submissions")
+	}
+
 	if req.GithubURL != nil && *req.GithubURL != "" {
 		url := *req.GithubURL
 		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-			HandleError(c, errors.New(errors.ErrorBadRequest, "github_url must start with http:// or https://"))
-			return
+			return nil, errors.New(errors.ErrorBadRequest, "github_url must start with http:// or https://")
 		}
 	}
 
-	_, err = h.assessmentRepo.GetAssessmentByID(assessmentID, userID)
-	if err != nil {
-		HandleError(c, err)
-		return
-	}
-
 	if req.SubmissionType == "file_upload" && req.FileID != nil {
 		_, err := h.fileRepo.GetFileByID(*req.FileID, userID)
 		if err != nil {
-			HandleError(c, errors.New(errors.ErrorBadRequest, "file not found or does not belong to user"))
-			return
+			return nil, errors.New(errors.ErrorBadRequest, "file not found or does not belong to user")
 		}
 	}
 
-	if req.Notes != nil && *req.Notes != "" {
-		sanitized := h.sanitizer.SanitizeHTML(*req.Notes)
-		req.Notes = &sanitized
-	}
-
-	submission := &models.AssessmentSubmission{
-		AssessmentID:   assessmentID,
+	return &models.AssessmentSubmission{
 		SubmissionType: req.SubmissionType,
 		GithubURL:      req.GithubURL,
 		FileID:         req.FileID,
-		Notes:          req.Notes,
-	}
-
-	createdSubmission, err := h.submissionRepo.CreateSubmission(submission)
-	if err != nil {
-		HandleError(c, err)
-		return
-	}
-
-	response.Created(c, gin.H{
-		"submission": createdSubmission,
-	})
+		Notes:          prepareNote(h.sanitizer, req.Notes, req.NotesFormat),
+		NotesFormat:    req.NotesFormat,
+	}, nil
 }
 
 func (h *AssessmentHandler) DeleteSubmission(c *gin.Context) {
@@ -465,10 +571,18 @@ func (h *AssessmentHandler) GetAssessmentDetails(c *gin.Context) {
 		submissions = []*models.AssessmentSubmission{}
 	}
 
+	sessions, err := h.timeSessionRepo.ListByAssessmentID(assessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
 	formatDueDate(assessment)
 
 	response.Success(c, gin.H{
-		"assessment":  assessment,
-		"submissions": submissions,
+		"assessment":          assessment,
+		"allowed_transitions": workflow.Assessment.Allowed(assessment.Status),
+		"submissions":         submissions,
+		"time_spent":          summarizeTimeSessions(sessions),
 	})
 }
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/github"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+const archiveContentType = "application/gzip"
+
+// GithubStatusResponse compares a GitHub submission with the repository as
+// it is now, so pushes after the deadline are visible.
+type GithubStatusResponse struct {
+	SubmissionID uuid.UUID `json:"submission_id"`
+	GithubURL    string    `json:"github_url"`
+	Ref          string    `json:"ref"`
+	SubmittedSHA string    `json:"submitted_sha"`
+	CurrentSHA   string    `json:"current_sha"`
+	// ChangedSinceSubmission is true when the ref has moved to another commit.
+	ChangedSinceSubmission bool      `json:"changed_since_submission"`
+	CheckedAt              time.Time `json:"checked_at"`
+}
+
+// snapshotSubmission verifies a GitHub submission against GitHub and, when
+// asked, archives the submitted commit. Other submission types pass through.
+func (h *AssessmentHandler) snapshotSubmission(ctx context.Context, req *CreateSubmissionRequest, submission *models.AssessmentSubmission, assessment *models.Assessment) ([]string, error) {
+	if submission.SubmissionType != models.SubmissionTypeGithub {
+		return nil, nil
+	}
+
+	snapshot, warnings, err := h.verifyGithubSubmission(ctx, submission)
+	if err != nil || !req.Archive {
+		return warnings, err
+	}
+	if snapshot == nil {
+		return append(warnings, "The repository wasn't archived because it couldn't be verified"), nil
+	}
+
+	file, warning := h.archiveSubmission(ctx, assessment.UserID, assessment.ApplicationID, snapshot)
+	if warning != "" {
+		return append(warnings, warning), nil
+	}
+	submission.ArchiveFileID = &file.ID
+	return warnings, nil
+}
+
+// verifyGithubSubmission checks the submitted repository with GitHub and
+// records the commit it is at. When GitHub can't see the repository or can't
+// be reached, the submission is kept unverified and a warning says so. The
+// snapshot is nil in that case.
+//
+// Private repositories are treated as not found. GitHub is read with the
+// server's token, not the user's, so recording or archiving what it can see
+// of a private repository would hand one user's access to every other user.
+func (h *AssessmentHandler) verifyGithubSubmission(ctx context.Context, submission *models.AssessmentSubmission) (*github.Snapshot, []string, error) {
+	snapshot, err := github.Verify(ctx, h.github, *submission.GithubURL)
+	switch {
+	case stderrors.Is(err, github.ErrInvalidURL):
+		return nil, nil, errors.New(errors.ErrorBadRequest, "github_url must be a GitHub repository URL, e.g. https://github.com/owner/repo")
+	case stderrors.Is(err, github.ErrNotFound), err == nil && snapshot.Repository.Private:
+		return nil, []string{"GitHub couldn't find a public repository or branch at that URL, so the submitted commit wasn't recorded. Private repositories can't be verified"}, nil
+	case err != nil:
+		slog.Warn("github submission not verified", slog.String("github_url", *submission.GithubURL), slog.String("cause", err.Error()))
+		return nil, []string{"GitHub couldn't be reached, so the submitted commit wasn't recorded"}, nil
+	}
+
+	submission.GithubCommitSHA = &snapshot.CommitSHA
+	submission.GithubRef = &snapshot.Ref
+	submission.GithubPrivate = &snapshot.Repository.Private
+	submission.GithubVerifiedAt = &snapshot.VerifiedAt
+
+	return snapshot, nil, nil
+}
+
+// archiveSubmission copies a tarball of the submitted commit into the user's
+// file storage. The submission is recorded either way, so failures come back
+// as a warning rather than an error.
+func (h *AssessmentHandler) archiveSubmission(ctx context.Context, userID, applicationID uuid.UUID, snapshot *github.Snapshot) (*models.File, string) {
+	repo := snapshot.Repository
+
+	body, err := h.github.Tarball(ctx, repo.Owner, repo.Name, snapshot.CommitSHA)
+	if err != nil {
+		slog.Warn("github archive download failed", slog.String("repository", repo.Owner+"/"+repo.Name), slog.String("cause", err.Error()))
+		return nil, "The repository archive couldn't be downloaded from GitHub"
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxAssessmentFileSize+1))
+	if err != nil {
+		return nil, "The repository archive couldn't be downloaded from GitHub"
+	}
+	if len(data) > MaxAssessmentFileSize {
+		return nil, fmt.Sprintf("The repository archive is larger than %dMB and wasn't stored", MaxAssessmentFileSize/(1024*1024))
+	}
+
+	size := int64(len(data))
+	usedBytes, err := h.fileRepo.GetUserStorageUsage(userID)
+	if err != nil {
+		return nil, "The repository archive couldn't be stored"
+	}
+	if usedBytes+size > MaxStoragePerUser {
+		return nil, "Storage limit reached, so the repository archive wasn't stored"
+	}
+
+	fileName := archiveFileName(repo, snapshot.CommitSHA)
+	s3Key := s3service.GenerateS3Key(userID, fileName)
+	if err := h.s3Service.PutObject(ctx, s3Key, archiveContentType, bytes.NewReader(data), size); err != nil {
+		slog.Warn("github archive upload failed", slog.String("s3_key", s3Key), slog.String("cause", err.Error()))
+		return nil, "The repository archive couldn't be stored"
+	}
+
+	file, err := h.fileRepo.CreateFile(&models.File{
+		UserID:        userID,
+		ApplicationID: applicationID,
+		FileName:      fileName,
+		FileType:      archiveContentType,
+		FileSize:      size,
+		S3Key:         s3Key,
+		ScanStatus:    initialScanStatus(h.fileScanner),
+	})
+	if err != nil {
+		_ = h.s3Service.DeleteObject(ctx, s3Key)
+		return nil, "The repository archive couldn't be stored"
+	}
+
+	submitScan(h.fileScanner, file)
+
+	return file, ""
+}
+
+// discardArchive removes an archive stored for a submission that ended up not
+// being saved.
+func (h *AssessmentHandler) discardArchive(ctx context.Context, userID uuid.UUID, submission *models.AssessmentSubmission) {
+	if submission == nil || submission.ArchiveFileID == nil {
+		return
+	}
+
+	file, err := h.fileRepo.GetFileByID(*submission.ArchiveFileID, userID)
+	if err != nil {
+		slog.Warn("unused github archive not found", slog.String("file_id", submission.ArchiveFileID.String()), slog.String("cause", err.Error()))
+		return
+	}
+	if err := h.s3Service.DeleteObject(ctx, file.S3Key); err != nil {
+		slog.Warn("unused github archive not deleted", slog.String("s3_key", file.S3Key), slog.String("cause", err.Error()))
+	}
+	if err := h.fileRepo.SoftDeleteFile(file.ID, userID); err != nil {
+		slog.Warn("unused github archive record not deleted", slog.String("file_id", file.ID.String()), slog.String("cause", err.Error()))
+	}
+}
+
+// archiveFileName names an archive after the repository and the short SHA,
+// the way GitHub names its own tarballs.
+func archiveFileName(repo *github.Repository, sha string) string {
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return fmt.Sprintf("%s-%s-%s.tar.gz", repo.Owner, repo.Name, sha)
+}
+
+// GET /api/assessment-submissions/:submissionId/github-status
+func (h *AssessmentHandler) GetSubmissionGithubStatus(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	submissionID, err := uuid.Parse(c.Param("submissionId"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid submission ID"))
+		return
+	}
+
+	submission, err := h.submissionRepo.GetSubmissionByID(submissionID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	_, err = h.assessmentRepo.GetAssessmentByID(submission.AssessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if submission.GithubURL == nil || submission.GithubCommitSHA == nil || submission.GithubRef == nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "submission has no verified GitHub commit to compare with"))
+		return
+	}
+
+	ref, err := github.ParseURL(*submission.GithubURL)
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "submission has no verified GitHub commit to compare with"))
+		return
+	}
+
+	// A repository made private since it was submitted is no longer the
+	// user's to look at through the server's token.
+	ctx := c.Request.Context()
+	repo, err := h.github.GetRepository(ctx, ref.Owner, ref.Name)
+	if err == nil && repo.Private {
+		err = github.ErrNotFound
+	}
+	currentSHA := ""
+	if err == nil {
+		currentSHA, err = h.github.CommitSHA(ctx, ref.Owner, ref.Name, *submission.GithubRef)
+	}
+	if stderrors.Is(err, github.ErrNotFound) {
+		HandleError(c, errors.New(errors.ErrorNotFound, "GitHub repository or branch no longer exists"))
+		return
+	}
+	if err != nil {
+		HandleError(c, errors.NewNetworkError("failed to reach GitHub", err))
+		return
+	}
+
+	response.Success(c, GithubStatusResponse{
+		SubmissionID:           submission.ID,
+		GithubURL:              *submission.GithubURL,
+		Ref:                    *submission.GithubRef,
+		SubmittedSHA:           *submission.GithubCommitSHA,
+		CurrentSHA:             currentSHA,
+		ChangedSinceSubmission: currentSHA != *submission.GithubCommitSHA,
+		CheckedAt:              time.Now(),
+	})
+}
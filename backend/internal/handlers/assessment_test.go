@@ -9,6 +9,7 @@ import (
 
 	"ditto-backend/internal/repository"
 	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/github"
 	"ditto-backend/internal/testutil"
 	"ditto-backend/internal/utils"
 
@@ -19,10 +20,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	testRepoSHA     = "3f786850e387550fdab836ed7e6dc881de23001b"
+	testSolutionSHA = "89e6c98d92887913cadf06b2adb97f26cde4849b"
+)
+
 type assessmentTestEnv struct {
 	router *gin.Engine
 	userID uuid.UUID
 	appID  uuid.UUID
+	github *github.Fake
 }
 
 func setupAssessmentTestEnv(t *testing.T) *assessmentTestEnv {
@@ -39,7 +46,13 @@ func setupAssessmentTestEnv(t *testing.T) *assessmentTestEnv {
 		Sanitizer: services.NewSanitizerService(),
 	}
 
-	handler := NewAssessmentHandler(appState)
+	githubFake := github.NewFake()
+	githubFake.AddRepository(&github.Repository{Owner: "user", Name: "repo", DefaultBranch: "main"}, testRepoSHA)
+	githubFake.AddRepository(&github.Repository{Owner: "user", Name: "solution", DefaultBranch: "main"}, testSolutionSHA)
+	githubFake.AddRepository(&github.Repository{Owner: "user", Name: "secret", DefaultBranch: "main", Private: true}, testRepoSHA)
+	githubFake.Tarballs[testSolutionSHA] = []byte("tarball of the solution")
+
+	handler := NewAssessmentHandler(appState, githubFake, &mockS3Service{}, nil)
 
 	userRepo := repository.NewUserRepository(db.Database)
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -80,12 +93,14 @@ func setupAssessmentTestEnv(t *testing.T) *assessmentTestEnv {
 	router.PATCH("/api/assessments/:id/status", handler.UpdateStatus)
 	router.DELETE("/api/assessments/:id", handler.DeleteAssessment)
 	router.POST("/api/assessments/:id/submissions", handler.CreateSubmission)
+	router.GET("/api/assessment-submissions/:submissionId/github-status", handler.GetSubmissionGithubStatus)
 	router.DELETE("/api/assessment-submissions/:submissionId", handler.DeleteSubmission)
 
 	return &assessmentTestEnv{
 		router: router,
 		userID: testUser.ID,
 		appID:  createdApp.ID,
+		github: githubFake,
 	}
 }
 
@@ -165,7 +180,8 @@ func TestAssessmentHandler(t *testing.T) {
 			assessment := data["assessment"].(map[string]interface{})
 			assert.Equal(t, "Backend API Project", assessment["title"])
 			assert.Equal(t, "take_home_project", assessment["assessment_type"])
-			assert.Equal(t, "2026-03-15", assessment["due_date"])
+			assert.Equal(t, "2026-03-15T23:59:00Z", assessment["due_date"])
+			assert.Equal(t, "UTC", assessment["due_timezone"])
 			assert.Equal(t, "not_started", assessment["status"])
 			assert.NotEmpty(t, assessment["id"])
 		})
@@ -208,6 +224,50 @@ func TestAssessmentHandler(t *testing.T) {
 			assert.Equal(t, http.StatusBadRequest, w.Code)
 		})
 
+		t.Run("DueTimeInTimezone", func(t *testing.T) {
+			payload := map[string]interface{}{
+				"application_id":  env.appID.String(),
+				"assessment_type": "take_home_project",
+				"title":           "Due at 5pm PT",
+				"due_date":        "2026-03-15T17:00",
+				"due_timezone":    "America/Los_Angeles",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			assessment := resp["data"].(map[string]interface{})["assessment"].(map[string]interface{})
+			assert.Equal(t, "2026-03-15T17:00:00-07:00", assessment["due_date"])
+			assert.Equal(t, "America/Los_Angeles", assessment["due_timezone"])
+		})
+
+		t.Run("InvalidTimezone", func(t *testing.T) {
+			payload := map[string]interface{}{
+				"application_id":  env.appID.String(),
+				"assessment_type": "take_home_project",
+				"title":           "Some Assessment",
+				"due_date":        "2026-03-15",
+				"due_timezone":    "Mars/Olympus_Mons",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+
 		t.Run("InvalidDueDate", func(t *testing.T) {
 			payload := map[string]interface{}{
 				"application_id":  env.appID.String(),
@@ -340,7 +400,7 @@ func TestAssessmentHandler(t *testing.T) {
 			data := resp["data"].(map[string]interface{})
 			assessment := data["assessment"].(map[string]interface{})
 			assert.Equal(t, "Updated Title", assessment["title"])
-			assert.Equal(t, "2026-04-01", assessment["due_date"])
+			assert.Equal(t, "2026-04-01T23:59:00Z", assessment["due_date"])
 		})
 
 		t.Run("PartialUpdate", func(t *testing.T) {
@@ -366,10 +426,35 @@ func TestAssessmentHandler(t *testing.T) {
 			data := resp["data"].(map[string]interface{})
 			assessment := data["assessment"].(map[string]interface{})
 			assert.Equal(t, "Only Title Changed", assessment["title"])
-			assert.Equal(t, "2026-03-15", assessment["due_date"])
+			assert.Equal(t, "2026-03-15T23:59:00Z", assessment["due_date"])
 			assert.Equal(t, "take_home_project", assessment["assessment_type"])
 		})
 
+		t.Run("TimezoneOnlyKeepsWallClock", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"due_timezone": "Europe/Berlin",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/assessments/"+assessmentID, bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			assessment := resp["data"].(map[string]interface{})["assessment"].(map[string]interface{})
+			assert.Equal(t, "2026-03-15T23:59:00+01:00", assessment["due_date"])
+			assert.Equal(t, "Europe/Berlin", assessment["due_timezone"])
+		})
+
 		t.Run("NotFound", func(t *testing.T) {
 			payload := map[string]interface{}{
 				"title": "Nope",
@@ -428,6 +513,85 @@ func TestAssessmentHandler(t *testing.T) {
 
 			assert.Equal(t, http.StatusBadRequest, w.Code)
 		})
+
+		t.Run("IllegalTransition", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"status": "passed",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/api/assessments/"+assessmentID+"/status", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusConflict, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			errBody := resp["error"].(map[string]interface{})
+			assert.Equal(t, "INVALID_TRANSITION", errBody["code"])
+			assert.Equal(t, []interface{}{"allowed: in_progress, submitted"}, errBody["details"])
+		})
+
+		t.Run("SubmittedWithSubmission", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"status": "submitted",
+				"submission": map[string]interface{}{
+					"submission_type": "github",
+					"github_url":      "https://github.com/user/solution",
+				},
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/api/assessments/"+assessmentID+"/status", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			data := resp["data"].(map[string]interface{})
+			assessment := data["assessment"].(map[string]interface{})
+			assert.Equal(t, "submitted", assessment["status"])
+			assert.Equal(t, []interface{}{"in_progress", "passed", "failed"}, data["allowed_transitions"])
+
+			submission := data["submission"].(map[string]interface{})
+			assert.Equal(t, "https://github.com/user/solution", submission["github_url"])
+		})
+
+		t.Run("SubmissionWithoutSubmitting", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"status": "in_progress",
+				"submission": map[string]interface{}{
+					"submission_type": "notes",
+					"notes":           "Not done yet",
+				},
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/api/assessments/"+assessmentID+"/status", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
 	})
 
 	t.Run("DeleteAssessment", func(t *testing.T) {
@@ -484,6 +648,9 @@ func TestAssessmentHandler(t *testing.T) {
 			submission := data["submission"].(map[string]interface{})
 			assert.Equal(t, "github", submission["submission_type"])
 			assert.Equal(t, "https://github.com/user/repo", submission["github_url"])
+			assert.Equal(t, testRepoSHA, submission["github_commit_sha"])
+			assert.Equal(t, "main", submission["github_ref"])
+			assert.Nil(t, resp["warnings"])
 		})
 
 		t.Run("NotesSubmission", func(t *testing.T) {
@@ -564,6 +731,138 @@ func TestAssessmentHandler(t *testing.T) {
 
 			assert.Equal(t, http.StatusBadRequest, w.Code)
 		})
+
+		t.Run("UnknownRepositoryWarns", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"submission_type": "github",
+				"github_url":      "https://github.com/user/missing",
+				"archive":         true,
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			submission := resp["data"].(map[string]interface{})["submission"].(map[string]interface{})
+			assert.Nil(t, submission["github_commit_sha"], "saved unverified")
+			assert.Nil(t, submission["archive_file_id"], "unverified repositories aren't archived")
+			assert.Len(t, resp["warnings"], 2)
+		})
+
+		t.Run("PrivateRepositoryNotRecorded", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"submission_type": "github",
+				"github_url":      "https://github.com/user/secret",
+				"archive":         true,
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			submission := resp["data"].(map[string]interface{})["submission"].(map[string]interface{})
+			assert.Nil(t, submission["github_commit_sha"], "nothing read with the server's token is recorded")
+			assert.Nil(t, submission["github_private"])
+			assert.Nil(t, submission["archive_file_id"], "private repositories aren't archived")
+			assert.Len(t, resp["warnings"], 2)
+		})
+
+		t.Run("GitHubUnavailable", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			env.github.Err = github.ErrRateLimited
+			defer func() { env.github.Err = nil }()
+
+			payload := map[string]interface{}{
+				"submission_type": "github",
+				"github_url":      "https://github.com/user/repo",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			submission := resp["data"].(map[string]interface{})["submission"].(map[string]interface{})
+			assert.Nil(t, submission["github_commit_sha"], "saved unverified")
+			assert.Len(t, resp["warnings"], 1)
+		})
+
+		t.Run("ArchiveCommit", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"submission_type": "github",
+				"github_url":      "https://github.com/user/solution",
+				"archive":         true,
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			submission := resp["data"].(map[string]interface{})["submission"].(map[string]interface{})
+			assert.NotEmpty(t, submission["archive_file_id"])
+			assert.Nil(t, resp["warnings"])
+		})
+
+		t.Run("ArchiveOnlyForGithub", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"submission_type": "notes",
+				"notes":           "Done",
+				"archive":         true,
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
 	})
 
 	t.Run("DeleteSubmission", func(t *testing.T) {
@@ -589,6 +888,58 @@ func TestAssessmentHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("GetSubmissionGithubStatus", func(t *testing.T) {
+		t.Run("PushedAfterSubmission", func(t *testing.T) {
+			created := env.createAssessment(t)
+			submission := env.createSubmission(t, created["id"].(string))
+
+			env.github.SetRef("user", "repo", "main", "0000000000000000000000000000000000000001")
+			defer env.github.SetRef("user", "repo", "main", testRepoSHA)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/assessment-submissions/"+submission["id"].(string)+"/github-status", nil)
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			data := resp["data"].(map[string]interface{})
+			assert.Equal(t, testRepoSHA, data["submitted_sha"])
+			assert.Equal(t, "0000000000000000000000000000000000000001", data["current_sha"])
+			assert.Equal(t, true, data["changed_since_submission"])
+		})
+
+		t.Run("NotAGithubSubmission", func(t *testing.T) {
+			created := env.createAssessment(t)
+			assessmentID := created["id"].(string)
+
+			payload := map[string]interface{}{
+				"submission_type": "notes",
+				"notes":           "Done",
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/assessments/"+assessmentID+"/submissions", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			env.router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			var resp map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			submissionID := resp["data"].(map[string]interface{})["submission"].(map[string]interface{})["id"].(string)
+
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/api/assessment-submissions/"+submissionID+"/github-status", nil)
+			env.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	})
+
 	t.Run("GetAssessmentDetails", func(t *testing.T) {
 		t.Run("WithSubmissions", func(t *testing.T) {
 			created := env.createAssessment(t)
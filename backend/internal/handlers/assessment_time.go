@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// maxManualSession is the longest stretch that can be logged in one entry.
+const maxManualSession = 24 * time.Hour
+
+// CreateTimeSessionRequest logs time spent without the timer. Give either
+// ended_at or duration_minutes.
+type CreateTimeSessionRequest struct {
+	StartedAt       time.Time  `json:"started_at" binding:"required"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationMinutes *int       `json:"duration_minutes" binding:"omitempty,min=1,max=1440"`
+	Note            *string    `json:"note" binding:"omitempty,max=500"`
+}
+
+// AssessmentTimeSummary is the time logged against an assessment so far.
+type AssessmentTimeSummary struct {
+	TotalSeconds int64                           `json:"total_seconds"`
+	TimerRunning bool                            `json:"timer_running"`
+	Sessions     []*models.AssessmentTimeSession `json:"sessions"`
+}
+
+func summarizeTimeSessions(sessions []*models.AssessmentTimeSession) *AssessmentTimeSummary {
+	summary := &AssessmentTimeSummary{Sessions: sessions}
+	if summary.Sessions == nil {
+		summary.Sessions = []*models.AssessmentTimeSession{}
+	}
+	for _, session := range sessions {
+		summary.TotalSeconds += session.DurationSeconds
+		if session.IsRunning() {
+			summary.TimerRunning = true
+		}
+	}
+	return summary
+}
+
+// GET /api/assessments/:id/time-sessions
+func (h *AssessmentHandler) ListTimeSessions(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid assessment ID"))
+		return
+	}
+
+	if _, err := h.assessmentRepo.GetAssessmentByID(assessmentID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	sessions, err := h.timeSessionRepo.ListByAssessmentID(assessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, summarizeTimeSessions(sessions))
+}
+
+// POST /api/assessments/:id/time-sessions/start
+// Starts the timer. The first session on a not_started assessment moves it
+// to in_progress.
+func (h *AssessmentHandler) StartTimeSession(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid assessment ID"))
+		return
+	}
+
+	session, started, err := h.timeSessionRepo.StartSession(assessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if started {
+		h.dashboardRepo.InvalidateCache(userID)
+	}
+
+	response.Created(c, gin.H{
+		"session":       session,
+		"status_change": statusChange(started),
+	})
+}
+
+// POST /api/assessments/:id/time-sessions/stop
+func (h *AssessmentHandler) StopTimeSession(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid assessment ID"))
+		return
+	}
+
+	session, err := h.timeSessionRepo.StopSession(assessmentID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"session": session,
+	})
+}
+
+// POST /api/assessments/:id/time-sessions
+// Logs time worked away from the timer.
+func (h *AssessmentHandler) CreateTimeSession(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid assessment ID"))
+		return
+	}
+
+	var req CreateTimeSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	endedAt, err := manualSessionEnd(&req, time.Now())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	session, started, err := h.timeSessionRepo.CreateManualSession(&models.AssessmentTimeSession{
+		AssessmentID: assessmentID,
+		UserID:       userID,
+		StartedAt:    req.StartedAt,
+		EndedAt:      &endedAt,
+		Note:         req.Note,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if started {
+		h.dashboardRepo.InvalidateCache(userID)
+	}
+
+	response.Created(c, gin.H{
+		"session":       session,
+		"status_change": statusChange(started),
+	})
+}
+
+// DELETE /api/assessments/:id/time-sessions/:sessionId
+func (h *AssessmentHandler) DeleteTimeSession(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	assessmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid assessment ID"))
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid session ID"))
+		return
+	}
+
+	if err := h.timeSessionRepo.DeleteSession(sessionID, assessmentID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// manualSessionEnd works out when a manually logged session ended and checks
+// it is a sensible stretch of time that has already happened.
+func manualSessionEnd(req *CreateTimeSessionRequest, now time.Time) (time.Time, error) {
+	if (req.EndedAt == nil) == (req.DurationMinutes == nil) {
+		return time.Time{}, errors.New(errors.ErrorBadRequest, "give either ended_at or duration_minutes")
+	}
+
+	var endedAt time.Time
+	if req.EndedAt != nil {
+		endedAt = *req.EndedAt
+	} else {
+		endedAt = req.StartedAt.Add(time.Duration(*req.DurationMinutes) * time.Minute)
+	}
+
+	switch {
+	case !endedAt.After(req.StartedAt):
+		return time.Time{}, errors.New(errors.ErrorBadRequest, "ended_at must be after started_at")
+	case endedAt.Sub(req.StartedAt) > maxManualSession:
+		return time.Time{}, errors.New(errors.ErrorBadRequest, "a session can't be longer than 24 hours")
+	case endedAt.After(now):
+		return time.Time{}, errors.New(errors.ErrorBadRequest, "sessions can't end in the future, use the timer instead")
+	}
+
+	return endedAt, nil
+}
+
+// statusChange describes the automatic move to in_progress, if it happened.
+func statusChange(started bool) *string {
+	if !started {
+		return nil
+	}
+	status := models.AssessmentStatusInProgress
+	return &status
+}
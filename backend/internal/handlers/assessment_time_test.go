@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ditto-backend/internal/models"
+)
+
+func TestManualSessionEnd(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	start := now.Add(-4 * time.Hour)
+	minutes := func(m int) *int { return &m }
+	at := func(t time.Time) *time.Time { return &t }
+
+	t.Run("from duration", func(t *testing.T) {
+		end, err := manualSessionEnd(&CreateTimeSessionRequest{StartedAt: start, DurationMinutes: minutes(90)}, now)
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(90*time.Minute), end)
+	})
+
+	t.Run("from end time", func(t *testing.T) {
+		end, err := manualSessionEnd(&CreateTimeSessionRequest{StartedAt: start, EndedAt: at(now)}, now)
+		require.NoError(t, err)
+		assert.Equal(t, now, end)
+	})
+
+	tests := []struct {
+		name string
+		req  CreateTimeSessionRequest
+	}{
+		{"neither end nor duration", CreateTimeSessionRequest{StartedAt: start}},
+		{"both end and duration", CreateTimeSessionRequest{StartedAt: start, EndedAt: at(now), DurationMinutes: minutes(30)}},
+		{"ends before it starts", CreateTimeSessionRequest{StartedAt: start, EndedAt: at(start.Add(-time.Minute))}},
+		{"longer than a day", CreateTimeSessionRequest{StartedAt: now.Add(-30 * time.Hour), EndedAt: at(now)}},
+		{"ends in the future", CreateTimeSessionRequest{StartedAt: start, DurationMinutes: minutes(300)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manualSessionEnd(&tt.req, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSummarizeTimeSessions(t *testing.T) {
+	ended := time.Now()
+
+	summary := summarizeTimeSessions([]*models.AssessmentTimeSession{
+		{DurationSeconds: 3600, EndedAt: &ended},
+		{DurationSeconds: 600},
+	})
+	assert.Equal(t, int64(4200), summary.TotalSeconds)
+	assert.True(t, summary.TimerRunning)
+
+	empty := summarizeTimeSessions(nil)
+	assert.NotNil(t, empty.Sessions)
+	assert.False(t, empty.TimerRunning)
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/caldav"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// calendarCheckTimeout bounds how long saving settings waits on the
+// calendar server.
+const calendarCheckTimeout = 15 * time.Second
+
+type UpdateCalendarSyncRequest struct {
+	CollectionURL string `json:"collection_url" binding:"required,url,max=2000"`
+	Username      string `json:"username" binding:"max=255"`
+	// Password may be left out to keep the stored one.
+	Password string `json:"password" binding:"max=1000"`
+	Timezone string `json:"timezone" binding:"omitempty,max=64"`
+	Enabled  *bool  `json:"enabled"`
+}
+
+type ResolveCalendarSyncItemRequest struct {
+	Keep string `json:"keep" binding:"required,oneof=local remote"`
+}
+
+// CalendarSyncSettings is the user's calendar sync setup and anything
+// waiting on them to resolve. Available is false when the server has no
+// encryption key configured and sync can't be set up.
+type CalendarSyncSettings struct {
+	Available  bool                        `json:"available"`
+	Account    *models.CalendarSyncAccount `json:"account"`
+	Unresolved []*models.CalendarSyncItem  `json:"unresolved"`
+}
+
+type CalendarSyncHandler struct {
+	syncRepo    *repository.CalendarSyncRepository
+	syncService *services.CalendarSyncService
+	sealer      *caldav.Sealer
+}
+
+// NewCalendarSyncHandler takes a nil sealer when CALDAV_ENCRYPTION_KEY is
+// unset; settings can then be read and deleted but not saved or synced.
+func NewCalendarSyncHandler(appState *utils.AppState, sealer *caldav.Sealer) *CalendarSyncHandler {
+	return &CalendarSyncHandler{
+		syncRepo:    repository.NewCalendarSyncRepository(appState.DB),
+		syncService: services.NewCalendarSyncService(appState.DB, sealer),
+		sealer:      sealer,
+	}
+}
+
+// GET /api/calendar-sync
+func (h *CalendarSyncHandler) GetCalendarSync(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	settings := &CalendarSyncSettings{
+		Available:  h.sealer != nil,
+		Unresolved: []*models.CalendarSyncItem{},
+	}
+
+	account, err := h.syncRepo.GetAccount(userID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		HandleError(c, err)
+		return
+	}
+
+	if account != nil {
+		settings.Account = account
+		unresolved, err := h.syncRepo.ListUnresolvedItems(userID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		if unresolved != nil {
+			settings.Unresolved = unresolved
+		}
+	}
+
+	response.Success(c, settings)
+}
+
+// PUT /api/calendar-sync
+// Saves the collection to sync with after checking it can be reached with
+// the given credentials and is a calendar.
+func (h *CalendarSyncHandler) UpdateCalendarSync(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	if h.sealer == nil {
+		HandleError(c, errors.New(errors.ErrorInternalServer, "calendar sync is not configured on this server"))
+		return
+	}
+
+	var req UpdateCalendarSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "unknown timezone"))
+		return
+	}
+
+	password := req.Password
+	sealed := ""
+	if password == "" {
+		existing, err := h.syncRepo.GetAccount(userID)
+		if err != nil && !errors.IsNotFoundError(err) {
+			HandleError(c, err)
+			return
+		}
+		if existing == nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "password is required"))
+			return
+		}
+		sealed = existing.PasswordEncrypted
+		if password, err = h.sealer.Open(sealed); err != nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "stored password can't be read, please enter it again"))
+			return
+		}
+	}
+
+	client, err := caldav.NewClient(req.CollectionURL, req.Username, password)
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), calendarCheckTimeout)
+	defer cancel()
+	if err := client.Check(ctx); err != nil {
+		HandleError(c, calendarSyncError(err))
+		return
+	}
+
+	if sealed == "" {
+		if sealed, err = h.sealer.Seal(password); err != nil {
+			HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to store calendar password", err))
+			return
+		}
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	account, err := h.syncRepo.UpsertAccount(&models.CalendarSyncAccount{
+		UserID:            userID,
+		CollectionURL:     req.CollectionURL,
+		Username:          req.Username,
+		PasswordEncrypted: sealed,
+		Timezone:          timezone,
+		Enabled:           enabled,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, account)
+}
+
+// DELETE /api/calendar-sync
+// Stops syncing. Events already in the calendar are left as they are.
+func (h *CalendarSyncHandler) DeleteCalendarSync(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	if err := h.syncRepo.DeleteAccount(userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Calendar sync removed successfully"})
+}
+
+// POST /api/calendar-sync/run
+// Syncs now instead of waiting for the scheduler. Changes that failed are
+// reported as warnings alongside the counts of those that went through.
+func (h *CalendarSyncHandler) RunCalendarSync(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	if h.sealer == nil {
+		HandleError(c, errors.New(errors.ErrorInternalServer, "calendar sync is not configured on this server"))
+		return
+	}
+
+	account, err := h.syncRepo.GetAccount(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	result, err := h.syncService.Sync(c.Request.Context(), account)
+	if err != nil {
+		if result == nil || stderrors.Is(err, caldav.ErrUnauthorized) {
+			HandleError(c, calendarSyncError(err))
+			return
+		}
+		response.SuccessWithWarnings(c, result, []string{err.Error()})
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// POST /api/calendar-sync/items/:id/resolve
+// Settles a conflict or calendar-side deletion by keeping Ditto's copy
+// (keep=local) or the calendar's (keep=remote).
+func (h *CalendarSyncHandler) ResolveCalendarSyncItem(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	if h.sealer == nil {
+		HandleError(c, errors.New(errors.ErrorInternalServer, "calendar sync is not configured on this server"))
+		return
+	}
+
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid sync item ID"))
+		return
+	}
+
+	var req ResolveCalendarSyncItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	account, err := h.syncRepo.GetAccount(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	item, err := h.syncRepo.GetItem(itemID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if item.Status == models.CalendarSyncStatusSynced {
+		HandleError(c, errors.New(errors.ErrorConflict, "sync item has nothing to resolve"))
+		return
+	}
+	if item.Status == models.CalendarSyncStatusRemoteDeleted && item.ItemType == models.CalendarItemAssessment && req.Keep == "remote" {
+		HandleError(c, errors.New(errors.ErrorConflict, "delete the assessment to accept its deletion from the calendar"))
+		return
+	}
+
+	if err := h.syncService.Resolve(c.Request.Context(), account, item, req.Keep == "local"); err != nil {
+		HandleError(c, calendarSyncError(err))
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Sync item resolved successfully"})
+}
+
+// calendarSyncError explains calendar server failures to the user. Errors
+// that already carry a code, such as not found, pass through.
+func calendarSyncError(err error) error {
+	var appErr *errors.AppError
+	switch {
+	case stderrors.As(err, &appErr):
+		return err
+	case stderrors.Is(err, caldav.ErrUnauthorized):
+		return errors.New(errors.ErrorBadRequest, "calendar server rejected the username or password")
+	case stderrors.Is(err, caldav.ErrNotCalendarCollection):
+		return errors.New(errors.ErrorBadRequest, "URL is not a CalDAV calendar collection")
+	case stderrors.Is(err, caldav.ErrPreconditionFailed):
+		return errors.New(errors.ErrorConflict, "calendar event changed while syncing, please try again")
+	default:
+		return errors.NewNetworkError("could not reach the calendar server", err)
+	}
+}
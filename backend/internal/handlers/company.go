@@ -3,6 +3,7 @@ package handlers
 import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
@@ -14,12 +15,26 @@ import (
 )
 
 type CompanyHandler struct {
-	companyRepo *repository.CompanyRepository
+	companyRepo     *repository.CompanyRepository
+	applicationRepo *repository.ApplicationRepository
+	interviewRepo   *repository.InterviewRepository
+	assessmentRepo  *repository.AssessmentRepository
+	fileRepo        *repository.FileRepository
+	profileRepo     *repository.UserCompanyProfileRepository
+	eventRepo       *repository.InterviewEventRepository
+	sanitizer       *services.SanitizerService
 }
 
 func NewCompanyHandler(appState *utils.AppState) *CompanyHandler {
 	return &CompanyHandler{
-		companyRepo: repository.NewCompanyRepository(appState.DB),
+		companyRepo:     repository.NewCompanyRepository(appState.DB),
+		applicationRepo: repository.NewApplicationRepository(appState.DB),
+		interviewRepo:   repository.NewInterviewRepository(appState.DB),
+		assessmentRepo:  repository.NewAssessmentRepository(appState.DB),
+		fileRepo:        repository.NewFileRepository(appState.DB),
+		profileRepo:     repository.NewUserCompanyProfileRepository(appState.DB),
+		eventRepo:       repository.NewInterviewEventRepository(appState.DB),
+		sanitizer:       appState.Sanitizer,
 	}
 }
 
@@ -66,7 +81,8 @@ func (h *CompanyHandler) AutocompleteCompanies(c *gin.Context) {
 
     limit := 10
 
-    localSuggestions, err := h.companyRepo.AutocompleteCompanies(input, limit)
+    userID, _ := optionalUserID(c)
+    localSuggestions, err := h.companyRepo.AutocompleteCompaniesForUser(input, limit, userID)
     if err != nil {
         HandleError(c, err)
         return
@@ -186,9 +202,15 @@ func (h *CompanyHandler) SearchCompanies(c *gin.Context) {
         HandleError(c, err)
         return
     }
+
+    results, err := h.withProfiles(c, companies)
+    if err != nil {
+        HandleError(c, err)
+        return
+    }
     
     response.Success(c, gin.H{
-        "companies": companies,
+        "companies": results,
         "query":     name,
         "limit":     limit,
         "offset":    offset,
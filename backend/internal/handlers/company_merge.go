@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"ditto-backend/internal/services/companymatch"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MergeCompaniesRequest struct {
+	DuplicateIDs []uuid.UUID `json:"duplicate_ids" binding:"required,min=1,max=50"`
+}
+
+// GET /api/companies/duplicates
+func (h *CompanyHandler) GetDuplicateCompanies(c *gin.Context) {
+	threshold := companymatch.DefaultThreshold
+	if thresholdStr := c.Query("threshold"); thresholdStr != "" {
+		parsed, err := strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "threshold must be a number between 0 and 1"))
+			return
+		}
+		threshold = parsed
+	}
+
+	candidates, err := h.companyRepo.GetDedupCandidates()
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	clusters := companymatch.FindClusters(candidates, threshold)
+
+	response.Success(c, gin.H{
+		"clusters":  clusters,
+		"threshold": threshold,
+	})
+}
+
+// POST /api/companies/:id/merge
+// Merges the duplicates in the request body into the company in the URL.
+func (h *CompanyHandler) MergeCompanies(c *gin.Context) {
+	survivorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+		return
+	}
+
+	var req MergeCompaniesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	result, err := h.companyRepo.MergeCompanies(survivorID, req.DuplicateIDs)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// companyOverviewApplicationLimit caps how many applications to one company
+// are loaded; anything beyond this is not a realistic history.
+const companyOverviewApplicationLimit = 500
+
+// stageRank orders application statuses by how far through the process they
+// are. Rejected counts as Applied: the application was at least submitted, and
+// interviews/offers on a rejected application are picked up separately.
+var stageRank = map[string]int{
+	"Saved":     1,
+	"Applied":   2,
+	"Rejected":  2,
+	"Interview": 3,
+	"Offer":     4,
+}
+
+var stageByRank = map[int]string{
+	1: "Saved",
+	2: "Applied",
+	3: "Interview",
+	4: "Offer",
+}
+
+type CompanyOverview struct {
+	Company         *models.Company                      `json:"company"`
+	Applications    []*repository.ApplicationWithDetails `json:"applications"`
+	InterviewRounds []*repository.CompanyRoundSummary    `json:"interview_rounds"`
+	Assessments     []*models.Assessment                 `json:"assessments"`
+	Files           []*models.File                       `json:"files"`
+	Stats           CompanyOverviewStats                 `json:"stats"`
+}
+
+type CompanyOverviewStats struct {
+	TotalApplications int `json:"total_applications"`
+	// TimesApplied excludes applications that were only saved
+	TimesApplied       int            `json:"times_applied"`
+	FurthestStage      *string        `json:"furthest_stage"`
+	InterviewRounds    int            `json:"interview_rounds"`
+	AssessmentOutcomes map[string]int `json:"assessment_outcomes"`
+	OfferReceived      bool           `json:"offer_received"`
+	// AverageResponseDays is the mean time from applying to the first
+	// interview or assessment, over applications that got a response
+	AverageResponseDays *float64 `json:"average_response_days"`
+	// Reschedules, Cancellations and NoShows come from the interview event
+	// log and show how smoothly the company runs its process
+	Reschedules   repository.InterviewEventCounts `json:"reschedules"`
+	Cancellations repository.InterviewEventCounts `json:"cancellations"`
+	NoShows       repository.InterviewEventCounts `json:"no_shows"`
+}
+
+// GET /api/companies/:id/overview
+func (h *CompanyHandler) GetCompanyOverview(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+		return
+	}
+
+	company, err := h.companyRepo.GetCompanyByID(companyID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	applications, err := h.applicationRepo.GetApplicationsWithDetails(userID, &repository.ApplicationFilters{
+		CompanyID: &companyID,
+		SortBy:    "applied_at",
+		SortOrder: "asc",
+		Limit:     companyOverviewApplicationLimit,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if applications == nil {
+		applications = []*repository.ApplicationWithDetails{}
+	}
+
+	rounds, err := h.interviewRepo.GetAllRoundsSummaryByCompany(companyID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	assessments, err := h.assessmentRepo.ListByCompanyID(companyID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if assessments == nil {
+		assessments = []*models.Assessment{}
+	}
+
+	files, err := h.fileRepo.GetFilesByCompany(companyID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if files == nil {
+		files = []*models.File{}
+	}
+
+	eventCounts, err := h.eventRepo.CountByCompany(companyID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	stats := buildCompanyOverviewStats(applications, rounds, assessments)
+	stats.Reschedules = eventCounts[models.InterviewEventRescheduled]
+	stats.Cancellations = eventCounts[models.InterviewEventCancelled]
+	stats.NoShows = eventCounts[models.InterviewEventNoShow]
+
+	response.Success(c, CompanyOverview{
+		Company:         company,
+		Applications:    applications,
+		InterviewRounds: rounds,
+		Assessments:     assessments,
+		Files:           files,
+		Stats:           stats,
+	})
+}
+
+func buildCompanyOverviewStats(
+	applications []*repository.ApplicationWithDetails,
+	rounds []*repository.CompanyRoundSummary,
+	assessments []*models.Assessment,
+) CompanyOverviewStats {
+	stats := CompanyOverviewStats{
+		TotalApplications:  len(applications),
+		InterviewRounds:    len(rounds),
+		AssessmentOutcomes: map[string]int{},
+	}
+
+	firstResponse := make(map[uuid.UUID]time.Time)
+	recordResponse := func(applicationID uuid.UUID, at time.Time) {
+		if existing, ok := firstResponse[applicationID]; !ok || at.Before(existing) {
+			firstResponse[applicationID] = at
+		}
+	}
+	interviewed := make(map[uuid.UUID]bool)
+	for _, round := range rounds {
+		respondedAt := round.CreatedAt
+		if scheduled, err := time.Parse("2006-01-02", round.ScheduledDate); err == nil {
+			respondedAt = earliest(respondedAt, scheduled)
+		}
+		recordResponse(round.ApplicationID, respondedAt)
+		interviewed[round.ApplicationID] = true
+	}
+	for _, assessment := range assessments {
+		recordResponse(assessment.ApplicationID, earliest(assessment.CreatedAt, assessment.DueDate))
+		stats.AssessmentOutcomes[assessment.Status]++
+	}
+
+	furthest := 0
+	var totalResponseDays float64
+	responded := 0
+
+	for _, app := range applications {
+		statusName := ""
+		if app.Status != nil {
+			statusName = app.Status.Name
+		}
+
+		rank := stageRank[statusName]
+		if interviewed[app.ID] && rank < stageRank["Interview"] {
+			rank = stageRank["Interview"]
+		}
+		if app.OfferReceived {
+			rank = stageRank["Offer"]
+			stats.OfferReceived = true
+		}
+		if rank > furthest {
+			furthest = rank
+		}
+
+		if statusName == "Saved" {
+			continue
+		}
+		stats.TimesApplied++
+
+		if respondedAt, ok := firstResponse[app.ID]; ok {
+			days := respondedAt.Sub(app.AppliedAt).Hours() / 24
+			if days < 0 {
+				days = 0
+			}
+			totalResponseDays += days
+			responded++
+		}
+	}
+
+	if stage, ok := stageByRank[furthest]; ok {
+		stats.FurthestStage = &stage
+	}
+
+	if responded > 0 {
+		avg := math.Round(totalResponseDays/float64(responded)*10) / 10
+		stats.AverageResponseDays = &avg
+	}
+
+	return stats
+}
+
+// earliest bounds when the company responded: rounds and assessments are often
+// logged after the fact, but the invite can't have come after the interview
+// date or the deadline.
+func earliest(loggedAt, date time.Time) time.Time {
+	if !date.IsZero() && date.Before(loggedAt) {
+		return date
+	}
+	return loggedAt
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCompanyOverviewStats(t *testing.T) {
+	appliedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	newApp := func(status string, offer bool) *repository.ApplicationWithDetails {
+		return &repository.ApplicationWithDetails{
+			Application: models.Application{
+				ID:            uuid.New(),
+				AppliedAt:     appliedAt,
+				OfferReceived: offer,
+			},
+			Status: &models.ApplicationStatus{Name: status},
+		}
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		stats := buildCompanyOverviewStats(nil, nil, nil)
+
+		assert.Equal(t, 0, stats.TotalApplications)
+		assert.Equal(t, 0, stats.TimesApplied)
+		assert.Nil(t, stats.FurthestStage)
+		assert.Nil(t, stats.AverageResponseDays)
+		assert.NotNil(t, stats.AssessmentOutcomes)
+	})
+
+	t.Run("derives stage and response time across attempts", func(t *testing.T) {
+		saved := newApp("Saved", false)
+		rejectedAfterInterview := newApp("Rejected", false)
+		assessed := newApp("Applied", false)
+		ghosted := newApp("Applied", false)
+
+		rounds := []*repository.CompanyRoundSummary{
+			{ApplicationID: rejectedAfterInterview.ID, CreatedAt: appliedAt.Add(6 * 24 * time.Hour)},
+			{ApplicationID: rejectedAfterInterview.ID, CreatedAt: appliedAt.Add(4 * 24 * time.Hour)},
+		}
+		assessments := []*models.Assessment{
+			{ApplicationID: assessed.ID, Status: models.AssessmentStatusPassed, CreatedAt: appliedAt.Add(2 * 24 * time.Hour)},
+		}
+
+		stats := buildCompanyOverviewStats(
+			[]*repository.ApplicationWithDetails{saved, rejectedAfterInterview, assessed, ghosted},
+			rounds,
+			assessments,
+		)
+
+		assert.Equal(t, 4, stats.TotalApplications)
+		assert.Equal(t, 3, stats.TimesApplied)
+		assert.Equal(t, 2, stats.InterviewRounds)
+		assert.Equal(t, 1, stats.AssessmentOutcomes[models.AssessmentStatusPassed])
+		assert.False(t, stats.OfferReceived)
+
+		require.NotNil(t, stats.FurthestStage)
+		assert.Equal(t, "Interview", *stats.FurthestStage)
+
+		// First response after 4 days and 2 days; the ghosted application is excluded
+		require.NotNil(t, stats.AverageResponseDays)
+		assert.Equal(t, 3.0, *stats.AverageResponseDays)
+	})
+
+	t.Run("backfilled rounds and assessments count from their dates", func(t *testing.T) {
+		interviewed := newApp("Interview", false)
+		assessed := newApp("Applied", false)
+		loggedLater := appliedAt.Add(30 * 24 * time.Hour)
+
+		rounds := []*repository.CompanyRoundSummary{
+			{ApplicationID: interviewed.ID, ScheduledDate: "2025-03-06", CreatedAt: loggedLater},
+		}
+		assessments := []*models.Assessment{
+			{ApplicationID: assessed.ID, DueDate: appliedAt.Add(3 * 24 * time.Hour), CreatedAt: loggedLater},
+		}
+
+		stats := buildCompanyOverviewStats(
+			[]*repository.ApplicationWithDetails{interviewed, assessed}, rounds, assessments,
+		)
+
+		// Interview on day 4.6 (midnight on the 6th) and deadline on day 3
+		require.NotNil(t, stats.AverageResponseDays)
+		assert.Equal(t, 3.8, *stats.AverageResponseDays)
+	})
+
+	t.Run("offer wins regardless of status", func(t *testing.T) {
+		stats := buildCompanyOverviewStats(
+			[]*repository.ApplicationWithDetails{newApp("Rejected", true)}, nil, nil,
+		)
+
+		require.NotNil(t, stats.FurthestStage)
+		assert.Equal(t, "Offer", *stats.FurthestStage)
+		assert.True(t, stats.OfferReceived)
+	})
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UpdateCompanyProfileRequest struct {
+	Notes         *string  `json:"notes" binding:"omitempty,max=20000"`
+	IsTarget      bool     `json:"is_target"`
+	Priority      *int     `json:"priority" binding:"omitempty,min=1,max=5"`
+	CultureRating *int     `json:"culture_rating" binding:"omitempty,min=1,max=5"`
+	TechStack     []string `json:"tech_stack" binding:"omitempty,max=50,dive,max=50"`
+	Headcount     *int     `json:"headcount" binding:"omitempty,min=0"`
+	FundingStage  *string  `json:"funding_stage" binding:"omitempty,oneof=bootstrapped pre_seed seed series_a series_b series_c series_d_plus public acquired"`
+	DoNotApply    bool     `json:"do_not_apply"`
+}
+
+// CompanySearchResult is a search hit plus the requesting user's private
+// profile for it, if they are signed in and have one.
+type CompanySearchResult struct {
+	*models.Company
+	Profile *models.UserCompanyProfile `json:"profile,omitempty"`
+}
+
+// GET /api/companies/:id/profile
+func (h *CompanyHandler) GetCompanyProfile(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+		return
+	}
+
+	if _, err := h.companyRepo.GetCompanyByID(companyID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	profile, err := h.profileRepo.GetByCompanyID(userID, companyID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, profile)
+}
+
+// PUT /api/companies/:id/profile
+// Replaces the whole profile, like notification preferences.
+func (h *CompanyHandler) UpdateCompanyProfile(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+		return
+	}
+
+	var req UpdateCompanyProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if _, err := h.companyRepo.GetCompanyByID(companyID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	profile := &models.UserCompanyProfile{
+		UserID:        userID,
+		CompanyID:     companyID,
+		IsTarget:      req.IsTarget,
+		Priority:      req.Priority,
+		CultureRating: req.CultureRating,
+		TechStack:     normalizeTechStack(req.TechStack),
+		Headcount:     req.Headcount,
+		FundingStage:  req.FundingStage,
+		DoNotApply:    req.DoNotApply,
+	}
+
+	if req.Notes != nil {
+		notes := h.sanitizer.SanitizeHTML(*req.Notes)
+		profile.Notes = &notes
+	}
+
+	result, err := h.profileRepo.Upsert(profile)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DELETE /api/companies/:id/profile
+func (h *CompanyHandler) DeleteCompanyProfile(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+		return
+	}
+
+	if err := h.profileRepo.Delete(userID, companyID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Company profile deleted successfully"})
+}
+
+// withProfiles attaches the user's profiles to search results. Anonymous
+// requests get the results back without profiles.
+func (h *CompanyHandler) withProfiles(c *gin.Context, companies []*models.Company) ([]*CompanySearchResult, error) {
+	results := make([]*CompanySearchResult, 0, len(companies))
+	for _, company := range companies {
+		results = append(results, &CompanySearchResult{Company: company})
+	}
+
+	userID, ok := optionalUserID(c)
+	if !ok || len(companies) == 0 {
+		return results, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(companies))
+	for _, company := range companies {
+		ids = append(ids, company.ID)
+	}
+
+	profiles, err := h.profileRepo.GetByCompanyIDs(userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		result.Profile = profiles[result.ID]
+	}
+
+	return results, nil
+}
+
+// optionalUserID returns the user on routes behind OptionalAuthMiddleware
+func optionalUserID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, false
+	}
+	userID, ok := value.(uuid.UUID)
+	return userID, ok
+}
+
+// normalizeTechStack trims entries and drops blanks and case-insensitive
+// duplicates, keeping the first spelling.
+func normalizeTechStack(stack []string) []string {
+	seen := make(map[string]bool, len(stack))
+	result := make([]string, 0, len(stack))
+	for _, item := range stack {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, item)
+	}
+	return result
+}
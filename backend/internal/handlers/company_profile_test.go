@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTechStack(t *testing.T) {
+	assert.Equal(t, []string{"Go", "React"}, normalizeTechStack([]string{" Go ", "", "go", "React"}))
+	assert.Equal(t, []string{}, normalizeTechStack(nil))
+}
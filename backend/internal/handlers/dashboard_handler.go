@@ -11,12 +11,14 @@ import (
 )
 
 type DashboardHandler struct {
-	dashboardRepo *repository.DashboardRepository
+	dashboardRepo   *repository.DashboardRepository
+	timeSessionRepo *repository.AssessmentTimeSessionRepository
 }
 
 func NewDashboardHandler(appState *utils.AppState) *DashboardHandler {
 	return &DashboardHandler{
-		dashboardRepo: repository.NewDashboardRepository(appState.DB),
+		dashboardRepo:   repository.NewDashboardRepository(appState.DB),
+		timeSessionRepo: repository.NewAssessmentTimeSessionRepository(appState.DB),
 	}
 }
 
@@ -45,7 +47,7 @@ func (h *DashboardHandler) GetUpcomingItems(c *gin.Context) {
 	}
 
 	itemType := c.DefaultQuery("type", "all")
-	if itemType != "all" && itemType != "interviews" && itemType != "assessments" {
+	if itemType != "all" && itemType != "interviews" && itemType != "assessments" && itemType != "practice" {
 		itemType = "all"
 	}
 
@@ -57,3 +59,23 @@ func (h *DashboardHandler) GetUpcomingItems(c *gin.Context) {
 
 	response.Success(c, items)
 }
+
+// GET /api/dashboard/assessment-time
+// Time spent on assessments against how they turned out, per assessment type.
+func (h *DashboardHandler) GetAssessmentTimeStats(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	stats, err := h.timeSessionRepo.GetTimeStats(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if stats == nil {
+		stats = []*repository.AssessmentTimeStats{}
+	}
+
+	response.Success(c, gin.H{
+		"stats": stats,
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateRepo *repository.ExchangeRateRepository
+}
+
+func NewExchangeRateHandler(appState *utils.AppState) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateRepo: repository.NewExchangeRateRepository(appState.DB),
+	}
+}
+
+// GET /api/exchange-rates
+func (h *ExchangeRateHandler) ListExchangeRates(c *gin.Context) {
+	rates, err := h.exchangeRateRepo.ListLatest(time.Now())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"rates": rates})
+}
+
+// GET /api/users/currency-preference
+func (h *ExchangeRateHandler) GetCurrencyPreference(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	currency, err := h.exchangeRateRepo.GetPreferredCurrency(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"preferred_currency": currency})
+}
+
+type UpdateCurrencyPreferenceRequest struct {
+	PreferredCurrency string `json:"preferred_currency" binding:"required,len=3,alpha"`
+}
+
+// PUT /api/users/currency-preference
+func (h *ExchangeRateHandler) UpdateCurrencyPreference(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req UpdateCurrencyPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "preferred_currency must be a 3-letter currency code"))
+		return
+	}
+
+	currency := strings.ToUpper(req.PreferredCurrency)
+
+	// Salaries can only be normalized into currencies we have a rate for
+	if _, err := h.exchangeRateRepo.GetRate(currency, time.Now()); err != nil {
+		if errors.IsNotFoundError(err) {
+			HandleError(c, errors.New(errors.ErrorValidationFailed, "no exchange rate available for "+currency))
+			return
+		}
+		HandleError(c, err)
+		return
+	}
+
+	if err := h.exchangeRateRepo.SetPreferredCurrency(userID, currency); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"preferred_currency": currency})
+}
@@ -26,10 +26,10 @@ type ExportHandler struct {
 	assessmentSubmissionRepo   *repository.AssessmentSubmissionRepository
 	fileRepo                   *repository.FileRepository
 	userRepo                   *repository.UserRepository
-	s3Service                  *s3service.S3Service
+	s3Service                  s3service.S3ServiceInterface
 }
 
-func NewExportHandler(appState *utils.AppState, s3Service *s3service.S3Service) *ExportHandler {
+func NewExportHandler(appState *utils.AppState, s3Service s3service.S3ServiceInterface) *ExportHandler {
 	return &ExportHandler{
 		applicationRepo:          repository.NewApplicationRepository(appState.DB),
 		interviewRepo:            repository.NewInterviewRepository(appState.DB),
@@ -63,7 +63,7 @@ func (h *ExportHandler) ExportApplications(c *gin.Context) {
 	writer := csv.NewWriter(c.Writer)
 	defer writer.Flush()
 
-	header := []string{"Company", "Job Title", "Status", "Application Date", "Description", "Notes"}
+	header := []string{"Company", "Job Title", "Status", "Application Date", "Description", "Notes", "Min Salary", "Max Salary", "Salary Currency"}
 	if err := writer.Write(header); err != nil {
 		HandleError(c, errors.New(errors.ErrorInternalServer, "failed to write CSV header"))
 		return
@@ -92,6 +92,14 @@ func (h *ExportHandler) ExportApplications(c *gin.Context) {
 			notes = *app.Notes
 		}
 
+		// Salaries are exported in the user's preferred currency so rows are comparable
+		minSalary := formatSalary(app.NormalizedMinSalary)
+		maxSalary := formatSalary(app.NormalizedMaxSalary)
+		salaryCurrency := ""
+		if app.NormalizedCurrency != nil && (minSalary != "" || maxSalary != "") {
+			salaryCurrency = *app.NormalizedCurrency
+		}
+
 		row := []string{
 			companyName,
 			jobTitle,
@@ -99,6 +107,9 @@ func (h *ExportHandler) ExportApplications(c *gin.Context) {
 			app.AppliedAt.Format("2006-01-02"),
 			description,
 			notes,
+			minSalary,
+			maxSalary,
+			salaryCurrency,
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -108,6 +119,13 @@ func (h *ExportHandler) ExportApplications(c *gin.Context) {
 	}
 }
 
+func formatSalary(amount *float64) string {
+	if amount == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*amount, 'f', 2, 64)
+}
+
 // GET /api/export/interviews
 func (h *ExportHandler) ExportInterviews(c *gin.Context) {
 	userID := c.MustGet("user_id").(uuid.UUID)
@@ -247,10 +265,23 @@ func parseExportFilters(c *gin.Context) *repository.ApplicationFilters {
 		}
 	}
 
+	if minSalaryStr := c.Query("min_salary"); minSalaryStr != "" {
+		if minSalary, err := strconv.ParseFloat(minSalaryStr, 64); err == nil && minSalary >= 0 {
+			filters.MinSalary = &minSalary
+		}
+	}
+
+	if maxSalaryStr := c.Query("max_salary"); maxSalaryStr != "" {
+		if maxSalary, err := strconv.ParseFloat(maxSalaryStr, 64); err == nil && maxSalary >= 0 {
+			filters.MaxSalary = &maxSalary
+		}
+	}
+
 	if sortBy := c.Query("sort_by"); sortBy != "" {
 		validSortColumns := map[string]bool{
 			"company": true, "position": true, "status": true,
 			"applied_at": true, "location": true, "updated_at": true, "job_type": true,
+			"salary": true,
 		}
 		if validSortColumns[sortBy] {
 			filters.SortBy = sortBy
@@ -287,6 +318,7 @@ type FullBackupApplication struct {
 	ApplicationDate string            `json:"application_date"`
 	Description     string            `json:"description"`
 	Notes           string            `json:"notes"`
+	NotesFormat     string            `json:"notes_format,omitempty"`
 	Location        string            `json:"location,omitempty"`
 	JobType         string            `json:"job_type,omitempty"`
 	SourceURL       string            `json:"source_url,omitempty"`
@@ -326,8 +358,9 @@ type FullBackupQuestion struct {
 }
 
 type FullBackupNote struct {
-	NoteType string `json:"note_type"`
-	Content  string `json:"content"`
+	NoteType      string `json:"note_type"`
+	Content       string `json:"content"`
+	ContentFormat string `json:"content_format,omitempty"`
 }
 
 type FullBackupAssessment struct {
@@ -338,6 +371,7 @@ type FullBackupAssessment struct {
 	Title          string                     `json:"title"`
 	AssessmentType string                     `json:"assessment_type"`
 	DueDate        string                     `json:"due_date"`
+	DueTimezone    string                     `json:"due_timezone"`
 	Status         string                     `json:"status"`
 	Instructions   string                     `json:"instructions,omitempty"`
 	Requirements   string                     `json:"requirements,omitempty"`
@@ -347,7 +381,9 @@ type FullBackupAssessment struct {
 type FullBackupSubmission struct {
 	SubmissionType string `json:"submission_type"`
 	GithubURL      string `json:"github_url,omitempty"`
+	GithubCommitSHA string `json:"github_commit_sha,omitempty"`
 	Notes          string `json:"notes,omitempty"`
+	NotesFormat    string `json:"notes_format,omitempty"`
 	SubmittedAt    string `json:"submitted_at"`
 	File           *FullBackupFile `json:"file,omitempty"`
 }
@@ -357,6 +393,8 @@ type FullBackupFile struct {
 	FileName    string `json:"file_name"`
 	FileType    string `json:"file_type"`
 	FileSize    int64  `json:"file_size"`
+	ScanStatus  string `json:"scan_status"`
+	// DownloadURL is empty until the file has passed its malware scan
 	DownloadURL string `json:"download_url"`
 }
 
@@ -393,12 +431,16 @@ func (h *ExportHandler) ExportFull(c *gin.Context) {
 	filesByID := make(map[uuid.UUID]*FullBackupFile)
 
 	for _, f := range allFiles {
-		downloadURL, _ := h.s3Service.GeneratePresignedGetURL(ctx, f.S3Key)
+		var downloadURL string
+		if f.IsClean() {
+			downloadURL, _ = h.s3Service.GeneratePresignedGetURL(ctx, f.S3Key)
+		}
 		backupFile := &FullBackupFile{
 			ID:          f.ID.String(),
 			FileName:    f.FileName,
 			FileType:    f.FileType,
 			FileSize:    f.FileSize,
+			ScanStatus:  f.ScanStatus,
 			DownloadURL: downloadURL,
 		}
 		filesByID[f.ID] = backupFile
@@ -447,6 +489,7 @@ func (h *ExportHandler) ExportFull(c *gin.Context) {
 			ApplicationDate: app.AppliedAt.Format("2006-01-02"),
 			Description:     description,
 			Notes:           notes,
+			NotesFormat:     app.NotesFormat,
 			Location:        location,
 			JobType:         jobType,
 			SourceURL:       sourceURL,
@@ -550,8 +593,9 @@ func (h *ExportHandler) ExportFull(c *gin.Context) {
 				content = *n.Content
 			}
 			exportInterview.Notes = append(exportInterview.Notes, FullBackupNote{
-				NoteType: n.NoteType,
-				Content:  content,
+				NoteType:      n.NoteType,
+				Content:       content,
+				ContentFormat: n.ContentFormat,
 			})
 		}
 
@@ -579,7 +623,8 @@ func (h *ExportHandler) ExportFull(c *gin.Context) {
 			JobTitle:       assessment.JobTitle,
 			Title:          assessment.Title,
 			AssessmentType: assessment.AssessmentType,
-			DueDate:        assessment.DueDate,
+			DueDate:        assessment.DueDate.In(assessment.DueLocation()).Format(time.RFC3339),
+			DueTimezone:    assessment.DueTimezone,
 			Status:         assessment.Status,
 			Submissions:    make([]FullBackupSubmission, 0),
 		}
@@ -599,8 +644,12 @@ func (h *ExportHandler) ExportFull(c *gin.Context) {
 			if s.GithubURL != nil {
 				exportSub.GithubURL = *s.GithubURL
 			}
+			if s.GithubCommitSHA != nil {
+				exportSub.GithubCommitSHA = *s.GithubCommitSHA
+			}
 			if s.Notes != nil {
 				exportSub.Notes = *s.Notes
+				exportSub.NotesFormat = s.NotesFormat
 			}
 			if s.FileID != nil {
 				if file, ok := filesByID[*s.FileID]; ok {
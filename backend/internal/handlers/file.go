@@ -4,6 +4,7 @@ import (
 	"context"
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
 	"time"
@@ -40,14 +41,18 @@ var assessmentAllowedFileTypes = map[string]bool{
 }
 
 type FileHandler struct {
-	fileRepo  *repository.FileRepository
-	s3Service s3service.S3ServiceInterface
+	fileRepo    *repository.FileRepository
+	s3Service   s3service.S3ServiceInterface
+	fileScanner *services.FileScanner
 }
 
-func NewFileHandler(fileRepo *repository.FileRepository, s3Service s3service.S3ServiceInterface) *FileHandler {
+// NewFileHandler creates a FileHandler. fileScanner may be nil, in which case
+// uploads aren't scanned for malware.
+func NewFileHandler(fileRepo *repository.FileRepository, s3Service s3service.S3ServiceInterface, fileScanner *services.FileScanner) *FileHandler {
 	return &FileHandler{
-		fileRepo:  fileRepo,
-		s3Service: s3Service,
+		fileRepo:    fileRepo,
+		s3Service:   s3Service,
+		fileScanner: fileScanner,
 	}
 }
 
@@ -81,6 +86,7 @@ type FileResponse struct {
 	FileType   string    `json:"file_type"`
 	FileSize   int64     `json:"file_size"`
 	S3Key      string    `json:"s3_key"`
+	ScanStatus string    `json:"scan_status"`
 	UploadedAt time.Time `json:"uploaded_at"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
@@ -100,6 +106,7 @@ type FileWithDetailsResponse struct {
 	FileName           string     `json:"file_name"`
 	FileType           string     `json:"file_type"`
 	FileSize           int64      `json:"file_size"`
+	ScanStatus         string     `json:"scan_status"`
 	ApplicationID      uuid.UUID  `json:"application_id"`
 	UploadedAt         time.Time  `json:"uploaded_at"`
 	ApplicationCompany *string    `json:"application_company,omitempty"`
@@ -151,6 +158,9 @@ func (h *FileHandler) GetPresignedUploadURL(c *gin.Context) {
 	}
 
 	s3Key := s3service.GenerateS3Key(userID, req.FileName)
+	if isAssessmentUpload {
+		s3Key = s3service.GenerateAssessmentS3Key(userID, req.FileName)
+	}
 
 	ctx := c.Request.Context()
 	presignedURL, err := h.s3Service.GeneratePresignedPutURL(ctx, s3Key, req.FileType)
@@ -176,14 +186,9 @@ func (h *FileHandler) ConfirmUpload(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	exists, err := h.s3Service.HeadObject(ctx, req.S3Key)
+	content, err := h.verifyUpload(ctx, userID, &req, 0)
 	if err != nil {
-		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to verify file upload", err))
-		return
-	}
-
-	if !exists {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "file not found in storage. Upload may have failed"))
+		HandleError(c, err)
 		return
 	}
 
@@ -193,8 +198,9 @@ func (h *FileHandler) ConfirmUpload(c *gin.Context) {
 		InterviewID:   req.InterviewID,
 		FileName:      req.FileName,
 		FileType:      req.FileType,
-		FileSize:      req.FileSize,
+		FileSize:      content.Size,
 		S3Key:         req.S3Key,
+		ScanStatus:    initialScanStatus(h.fileScanner),
 	}
 
 	createdFile, err := h.fileRepo.CreateFile(file)
@@ -203,12 +209,15 @@ func (h *FileHandler) ConfirmUpload(c *gin.Context) {
 		return
 	}
 
+	submitScan(h.fileScanner, createdFile)
+
 	response.Success(c, FileResponse{
 		ID:         createdFile.ID,
 		FileName:   createdFile.FileName,
 		FileType:   createdFile.FileType,
 		FileSize:   createdFile.FileSize,
 		S3Key:      createdFile.S3Key,
+		ScanStatus: createdFile.ScanStatus,
 		UploadedAt: createdFile.UploadedAt,
 		CreatedAt:  createdFile.CreatedAt,
 		UpdatedAt:  createdFile.UpdatedAt,
@@ -231,6 +240,11 @@ func (h *FileHandler) GetFile(c *gin.Context) {
 		return
 	}
 
+	if err := checkDownloadable(file); err != nil {
+		HandleError(c, err)
+		return
+	}
+
 	ctx := c.Request.Context()
 	downloadURL, err := h.s3Service.GeneratePresignedGetURL(ctx, file.S3Key)
 	if err != nil {
@@ -327,6 +341,7 @@ func (h *FileHandler) ListUserFilesWithDetails(c *gin.Context) {
 			FileName:           f.FileName,
 			FileType:           f.FileType,
 			FileSize:           f.FileSize,
+			ScanStatus:         f.ScanStatus,
 			ApplicationID:      f.ApplicationID,
 			UploadedAt:         f.UploadedAt,
 			ApplicationCompany: f.ApplicationCompany,
@@ -375,6 +390,7 @@ func (h *FileHandler) ListFiles(c *gin.Context) {
 			FileType:   f.FileType,
 			FileSize:   f.FileSize,
 			S3Key:      f.S3Key,
+			ScanStatus: f.ScanStatus,
 			UploadedAt: f.UploadedAt,
 			CreatedAt:  f.CreatedAt,
 			UpdatedAt:  f.UpdatedAt,
@@ -440,6 +456,9 @@ func (h *FileHandler) ReplaceFile(c *gin.Context) {
 	}
 
 	s3Key := s3service.GenerateS3Key(userID, req.FileName)
+	if isAssessmentUpload {
+		s3Key = s3service.GenerateAssessmentS3Key(userID, req.FileName)
+	}
 	ctx := c.Request.Context()
 	presignedURL, err := h.s3Service.GeneratePresignedPutURL(ctx, s3Key, req.FileType)
 	if err != nil {
@@ -477,14 +496,9 @@ func (h *FileHandler) ConfirmReplace(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
-	exists, err := h.s3Service.HeadObject(ctx, req.S3Key)
+	content, err := h.verifyUpload(ctx, userID, &req, existingFile.FileSize)
 	if err != nil {
-		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to verify file upload", err))
-		return
-	}
-
-	if !exists {
-		HandleError(c, errors.New(errors.ErrorBadRequest, "file not found in storage. Upload may have failed"))
+		HandleError(c, err)
 		return
 	}
 
@@ -507,8 +521,9 @@ func (h *FileHandler) ConfirmReplace(c *gin.Context) {
 		InterviewID:   req.InterviewID,
 		FileName:      req.FileName,
 		FileType:      req.FileType,
-		FileSize:      req.FileSize,
+		FileSize:      content.Size,
 		S3Key:         req.S3Key,
+		ScanStatus:    initialScanStatus(h.fileScanner),
 	}
 
 	createdFile, err := h.fileRepo.CreateFileTx(tx, newFile)
@@ -526,12 +541,15 @@ func (h *FileHandler) ConfirmReplace(c *gin.Context) {
 		_ = h.s3Service.DeleteObject(context.Background(), existingFile.S3Key)
 	}()
 
+	submitScan(h.fileScanner, createdFile)
+
 	response.Success(c, FileResponse{
 		ID:         createdFile.ID,
 		FileName:   createdFile.FileName,
 		FileType:   createdFile.FileType,
 		FileSize:   createdFile.FileSize,
 		S3Key:      createdFile.S3Key,
+		ScanStatus: createdFile.ScanStatus,
 		UploadedAt: createdFile.UploadedAt,
 		CreatedAt:  createdFile.CreatedAt,
 		UpdatedAt:  createdFile.UpdatedAt,
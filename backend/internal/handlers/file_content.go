@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/pkg/errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const docxMIMEType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// uploadedContent is what actually landed in storage for an upload, as
+// opposed to what the client said it was sending.
+type uploadedContent struct {
+	Size        int64
+	ContentType string
+}
+
+// detectFileType works out a file's MIME type from its magic numbers. DOCX
+// files are ZIP archives, so a ZIP is only reported as DOCX when it holds a
+// Word document.
+func detectFileType(data []byte) string {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	if contentType == "application/zip" && isDocx(data) {
+		return docxMIMEType
+	}
+	return contentType
+}
+
+func isDocx(data []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return false
+	}
+	for _, f := range archive.File {
+		if f.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDeclaredType reports whether sniffed content is what the client
+// declared. ZIP has two common MIME types, and a DOCX is also a valid ZIP.
+func matchesDeclaredType(declared, detected string) bool {
+	if declared == detected {
+		return true
+	}
+	switch declared {
+	case "application/zip", "application/x-zip-compressed":
+		return detected == "application/zip" || detected == docxMIMEType
+	}
+	return false
+}
+
+// inspectUpload reads an uploaded object back from storage to learn its real
+// size and content type. Objects larger than maxSize are only read as far as
+// maxSize+1 bytes.
+func (h *FileHandler) inspectUpload(ctx context.Context, s3Key string, maxSize int64) (*uploadedContent, error) {
+	body, err := h.s3Service.GetObject(ctx, s3Key)
+	if err != nil {
+		return nil, fmt.Errorf("fetching uploaded file: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading uploaded file: %w", err)
+	}
+
+	return &uploadedContent{
+		Size:        int64(len(data)),
+		ContentType: detectFileType(data),
+	}, nil
+}
+
+// verifyUpload checks an uploaded object against the same type, size and quota
+// rules as the presigned URL request, using the object's real content instead
+// of the sizes and types the client declared. replacedSize is the size of the
+// file being replaced, if any, which no longer counts towards the quota.
+// The key must be one the presign step issued to this user and must not be
+// held by any file yet; only then are rejected objects deleted from storage.
+// Whether assessment limits apply follows from the key, not the request.
+func (h *FileHandler) verifyUpload(ctx context.Context, userID uuid.UUID, req *ConfirmUploadRequest, replacedSize int64) (*uploadedContent, error) {
+	if !s3service.IsUserKey(req.S3Key, userID) {
+		return nil, errors.New(errors.ErrorForbidden, "upload key does not belong to this user")
+	}
+
+	inUse, err := h.fileRepo.S3KeyInUse(req.S3Key)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, errors.New(errors.ErrorConflict, "upload has already been confirmed")
+	}
+
+	exists, err := h.s3Service.HeadObject(ctx, req.S3Key)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrorInternalServer, "failed to verify file upload", err)
+	}
+	if !exists {
+		return nil, errors.New(errors.ErrorBadRequest, "file not found in storage. Upload may have failed")
+	}
+
+	isAssessmentUpload := s3service.IsAssessmentKey(req.S3Key, userID)
+
+	allowed, maxSize, sizeMessage := allowedFileTypes, int64(MaxFileSize), "file exceeds 5MB limit"
+	if isAssessmentUpload {
+		allowed, maxSize, sizeMessage = assessmentAllowedFileTypes, MaxAssessmentFileSize, "file exceeds 10MB limit"
+	}
+
+	content, err := h.inspectUpload(ctx, req.S3Key, maxSize)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrorInternalServer, "failed to verify file upload", err)
+	}
+
+	var rejection error
+	switch {
+	case content.Size > maxSize:
+		rejection = errors.New(errors.ErrorValidationFailed, sizeMessage)
+	case !allowed[content.ContentType] || !matchesDeclaredType(req.FileType, content.ContentType):
+		rejection = errors.New(errors.ErrorValidationFailed, "file content does not match its declared type")
+	default:
+		usedBytes, err := h.fileRepo.GetUserStorageUsage(userID)
+		if err != nil {
+			return nil, err
+		}
+		if usedBytes-replacedSize+content.Size > MaxStoragePerUser {
+			rejection = errors.New(errors.ErrorQuotaExceeded, "storage limit reached. Please delete old files")
+		}
+	}
+
+	if rejection != nil {
+		slog.Warn("upload rejected", slog.String("s3_key", req.S3Key), slog.String("declared_type", req.FileType), slog.String("detected_type", content.ContentType), slog.Int64("size", content.Size))
+		if err := h.s3Service.DeleteObject(ctx, req.S3Key); err != nil {
+			slog.Warn("rejected upload not deleted", slog.String("s3_key", req.S3Key), slog.String("cause", err.Error()))
+		}
+		return nil, rejection
+	}
+
+	return content, nil
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func zipWith(t *testing.T, names ...string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("content"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func TestDetectFileType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"pdf", []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3"), "application/pdf"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "image/png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), "image/jpeg"},
+		{"text", []byte("Thanks for the interview today."), "text/plain"},
+		{"zip", zipWith(t, "main.go", "README.md"), "application/zip"},
+		{"docx", zipWith(t, "[Content_Types].xml", "word/document.xml"), docxMIMEType},
+		{"windows executable", []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff"), "application/octet-stream"},
+		{"elf executable", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00"), "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectFileType(tt.data))
+		})
+	}
+}
+
+func TestMatchesDeclaredType(t *testing.T) {
+	assert.True(t, matchesDeclaredType("application/pdf", "application/pdf"))
+	assert.True(t, matchesDeclaredType("application/x-zip-compressed", "application/zip"))
+	assert.True(t, matchesDeclaredType("application/zip", docxMIMEType))
+
+	assert.False(t, matchesDeclaredType("application/pdf", "application/octet-stream"))
+	assert.False(t, matchesDeclaredType(docxMIMEType, "application/zip"))
+	assert.False(t, matchesDeclaredType("image/png", "image/jpeg"))
+}
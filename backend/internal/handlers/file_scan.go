@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services"
+	"ditto-backend/pkg/errors"
+)
+
+// initialScanStatus is the scan status a new file starts with: pending until
+// the scanner has seen it, or clean straight away when scanning is off.
+func initialScanStatus(scanner *services.FileScanner) string {
+	if scanner == nil {
+		return models.FileScanClean
+	}
+	return models.FileScanPending
+}
+
+// submitScan queues a newly stored file for a malware scan.
+func submitScan(scanner *services.FileScanner, file *models.File) {
+	if scanner != nil {
+		scanner.Submit(file)
+	}
+}
+
+// checkDownloadable stops download URLs being handed out for files that
+// haven't passed their malware scan.
+func checkDownloadable(file *models.File) error {
+	switch file.ScanStatus {
+	case models.FileScanPending:
+		return errors.New(errors.ErrorConflict, "file is still being scanned for malware. Try again shortly")
+	case models.FileScanInfected:
+		return errors.New(errors.ErrorForbidden, "file was removed because malware was detected")
+	case models.FileScanFailed:
+		return errors.New(errors.ErrorForbidden, "file couldn't be scanned for malware. Delete it and upload it again")
+	}
+	return nil
+}
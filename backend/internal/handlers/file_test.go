@@ -5,13 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/clamav"
+	"ditto-backend/internal/services/localstore"
 	"ditto-backend/internal/testutil"
 	s3service "ditto-backend/internal/services/s3"
 
@@ -23,7 +28,9 @@ import (
 )
 
 type mockS3Service struct {
-	headObjectFn func(ctx context.Context, s3Key string) (bool, error)
+	headObjectFn   func(ctx context.Context, s3Key string) (bool, error)
+	getObjectFn    func(ctx context.Context, s3Key string) (io.ReadCloser, error)
+	deleteObjectFn func(ctx context.Context, s3Key string) error
 }
 
 func (m *mockS3Service) GeneratePresignedPutURL(ctx context.Context, s3Key, contentType string) (string, error) {
@@ -42,9 +49,23 @@ func (m *mockS3Service) HeadObject(ctx context.Context, s3Key string) (bool, err
 }
 
 func (m *mockS3Service) DeleteObject(ctx context.Context, s3Key string) error {
+	if m.deleteObjectFn != nil {
+		return m.deleteObjectFn(ctx, s3Key)
+	}
+	return nil
+}
+
+func (m *mockS3Service) PutObject(ctx context.Context, s3Key, contentType string, body io.Reader, size int64) error {
 	return nil
 }
 
+func (m *mockS3Service) GetObject(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+	if m.getObjectFn != nil {
+		return m.getObjectFn(ctx, s3Key)
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
 func setupFileHandlerTest(t *testing.T) (*gin.Engine, *repository.FileRepository, uuid.UUID, uuid.UUID, *s3service.S3Service) {
 	gin.SetMode(gin.TestMode)
 
@@ -99,7 +120,7 @@ func setupFileHandlerTest(t *testing.T) (*gin.Engine, *repository.FileRepository
 	s3Svc, err := s3service.NewS3Service(s3Cfg)
 	require.NoError(t, err)
 
-	handler := NewFileHandler(fileRepo, s3Svc)
+	handler := NewFileHandler(fileRepo, s3Svc, nil)
 
 	// Setup router
 	router := gin.New()
@@ -247,7 +268,7 @@ func setupFileHandlerTestWithMockS3(t *testing.T, mock s3service.S3ServiceInterf
 	require.NoError(t, err)
 
 	fileRepo := repository.NewFileRepository(db.Database)
-	handler := NewFileHandler(fileRepo, mock)
+	handler := NewFileHandler(fileRepo, mock, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -289,10 +310,14 @@ func TestFileHandler_ConfirmUpload(t *testing.T) {
 	})
 
 	t.Run("FileExistsInS3", func(t *testing.T) {
+		content := "%PDF-1.7 resume"
 		mock := &mockS3Service{
 			headObjectFn: func(ctx context.Context, s3Key string) (bool, error) {
 				return true, nil
 			},
+			getObjectFn: func(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(content)), nil
+			},
 		}
 		router, _, userID, appID := setupFileHandlerTestWithMockS3(t, mock)
 
@@ -313,6 +338,164 @@ func TestFileHandler_ConfirmUpload(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, float64(len(content)), data["file_size"], "stored size is the object's real size")
+	})
+
+	t.Run("ContentDoesNotMatchType", func(t *testing.T) {
+		var deleted []string
+		mock := &mockS3Service{
+			headObjectFn: func(ctx context.Context, s3Key string) (bool, error) {
+				return true, nil
+			},
+			getObjectFn: func(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("MZ\x90\x00\x03\x00\x00\x00\x04\x00")), nil
+			},
+			deleteObjectFn: func(ctx context.Context, s3Key string) error {
+				deleted = append(deleted, s3Key)
+				return nil
+			},
+		}
+		router, _, userID, appID := setupFileHandlerTestWithMockS3(t, mock)
+
+		s3Key := s3service.GenerateS3Key(userID, "resume.pdf")
+
+		payload := map[string]interface{}{
+			"s3_key":         s3Key,
+			"file_name":      "resume.pdf",
+			"file_type":      "application/pdf",
+			"file_size":      1024,
+			"application_id": appID.String(),
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/files/confirm-upload", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{s3Key}, deleted)
+	})
+
+	t.Run("RealSizeOverLimit", func(t *testing.T) {
+		var deleted []string
+		mock := &mockS3Service{
+			headObjectFn: func(ctx context.Context, s3Key string) (bool, error) {
+				return true, nil
+			},
+			getObjectFn: func(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("%PDF-1.7" + strings.Repeat(" ", MaxFileSize))), nil
+			},
+			deleteObjectFn: func(ctx context.Context, s3Key string) error {
+				deleted = append(deleted, s3Key)
+				return nil
+			},
+		}
+		router, _, userID, appID := setupFileHandlerTestWithMockS3(t, mock)
+
+		s3Key := s3service.GenerateS3Key(userID, "big.pdf")
+
+		payload := map[string]interface{}{
+			"s3_key":         s3Key,
+			"file_name":      "big.pdf",
+			"file_type":      "application/pdf",
+			"file_size":      1024,
+			"application_id": appID.String(),
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/files/confirm-upload", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []string{s3Key}, deleted)
+	})
+
+	t.Run("OtherUsersKey", func(t *testing.T) {
+		var deleted []string
+		mock := &mockS3Service{
+			headObjectFn: func(ctx context.Context, s3Key string) (bool, error) {
+				return true, nil
+			},
+			getObjectFn: func(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("MZ\x90\x00")), nil
+			},
+			deleteObjectFn: func(ctx context.Context, s3Key string) error {
+				deleted = append(deleted, s3Key)
+				return nil
+			},
+		}
+		router, _, _, appID := setupFileHandlerTestWithMockS3(t, mock)
+
+		payload := map[string]interface{}{
+			"s3_key":         s3service.GenerateS3Key(uuid.New(), "resume.pdf"),
+			"file_name":      "resume.pdf",
+			"file_type":      "application/pdf",
+			"file_size":      1024,
+			"application_id": appID.String(),
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/files/confirm-upload", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, deleted, "another user's object is never deleted")
+	})
+
+	t.Run("KeyAlreadyConfirmed", func(t *testing.T) {
+		var deleted []string
+		mock := &mockS3Service{
+			headObjectFn: func(ctx context.Context, s3Key string) (bool, error) {
+				return true, nil
+			},
+			getObjectFn: func(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("MZ\x90\x00")), nil
+			},
+			deleteObjectFn: func(ctx context.Context, s3Key string) error {
+				deleted = append(deleted, s3Key)
+				return nil
+			},
+		}
+		router, fileRepo, userID, appID := setupFileHandlerTestWithMockS3(t, mock)
+
+		s3Key := s3service.GenerateS3Key(userID, "resume.pdf")
+		_, err := fileRepo.CreateFile(&models.File{
+			UserID:        userID,
+			ApplicationID: appID,
+			FileName:      "resume.pdf",
+			FileType:      "application/pdf",
+			FileSize:      1024,
+			S3Key:         s3Key,
+			ScanStatus:    models.FileScanClean,
+		})
+		require.NoError(t, err)
+
+		payload := map[string]interface{}{
+			"s3_key":         s3Key,
+			"file_name":      "resume.pdf",
+			"file_type":      "application/pdf",
+			"file_size":      1024,
+			"application_id": appID.String(),
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/files/confirm-upload", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Empty(t, deleted, "a stored file's object is never deleted")
 	})
 }
 
@@ -328,6 +511,7 @@ func TestFileHandler_GetFile(t *testing.T) {
 			FileType:      "application/pdf",
 			FileSize:      1024,
 			S3Key:         s3service.GenerateS3Key(userID, "test.pdf"),
+			ScanStatus:    models.FileScanClean,
 		}
 		createdFile, err := fileRepo.CreateFile(file)
 		require.NoError(t, err)
@@ -348,6 +532,33 @@ func TestFileHandler_GetFile(t *testing.T) {
 		assert.Equal(t, "test.pdf", data["file_name"])
 	})
 
+	t.Run("NotScannedYet", func(t *testing.T) {
+		router, fileRepo, userID, appID, _ := setupFileHandlerTest(t)
+
+		for status, code := range map[string]int{
+			models.FileScanPending:  http.StatusConflict,
+			models.FileScanInfected: http.StatusForbidden,
+			models.FileScanFailed:   http.StatusForbidden,
+		} {
+			createdFile, err := fileRepo.CreateFile(&models.File{
+				UserID:        userID,
+				ApplicationID: appID,
+				FileName:      status + ".pdf",
+				FileType:      "application/pdf",
+				FileSize:      1024,
+				S3Key:         s3service.GenerateS3Key(userID, status+".pdf"),
+				ScanStatus:    status,
+			})
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/files/"+createdFile.ID.String(), nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Code, status)
+		}
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		router, _, _, _, _ := setupFileHandlerTest(t)
 
@@ -595,3 +806,173 @@ func TestFileHandler_ReplaceFile(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
+
+func TestFileHandler_MalwareScan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := testutil.NewTestDatabase(t)
+	t.Cleanup(func() {
+		db.Close(t)
+	})
+	db.RunMigrations(t)
+
+	userRepo := repository.NewUserRepository(db.Database)
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	testUser, err := userRepo.CreateUser("filescantest@example.com", "File Scan Test", string(hashedPassword))
+	require.NoError(t, err)
+
+	createdCompany, err := repository.NewCompanyRepository(db.Database).CreateCompany(testutil.CreateTestCompany("Scan Co", "scan.com"))
+	require.NoError(t, err)
+	createdJob, err := repository.NewJobRepository(db.Database).CreateJob(testUser.ID, testutil.CreateTestJob(createdCompany.ID, "Scan Job", "Description"))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+	createdApp, err := repository.NewApplicationRepository(db.Database).CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	storage, err := localstore.NewService(localstore.Config{Root: t.TempDir(), Secret: "test-secret", URLExpiry: PresignedURLExpiry})
+	require.NoError(t, err)
+	fake := clamav.NewFake()
+	scanner := services.NewFileScanner(db.Database, storage, fake)
+
+	fileRepo := repository.NewFileRepository(db.Database)
+	handler := NewFileHandler(fileRepo, storage, scanner)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", testUser.ID)
+		c.Next()
+	})
+	router.POST("/api/files/confirm-upload", handler.ConfirmUpload)
+	router.GET("/api/files/:id", handler.GetFile)
+
+	upload := func(t *testing.T, fileName, content string) (uuid.UUID, string) {
+		s3Key := s3service.GenerateS3Key(testUser.ID, fileName)
+		require.NoError(t, storage.PutObject(context.Background(), s3Key, "text/plain", strings.NewReader(content), int64(len(content))))
+
+		payload := map[string]interface{}{
+			"s3_key":         s3Key,
+			"file_name":      fileName,
+			"file_type":      "text/plain",
+			"file_size":      len(content),
+			"application_id": createdApp.ID.String(),
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/files/confirm-upload", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		assert.Equal(t, models.FileScanPending, data["scan_status"])
+
+		return uuid.MustParse(data["id"].(string)), s3Key
+	}
+
+	t.Run("Clean", func(t *testing.T) {
+		fileID, _ := upload(t, "notes.txt", "interview notes")
+		scanner.Wait()
+
+		file, err := fileRepo.GetFileByID(fileID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FileScanClean, file.ScanStatus)
+		assert.NotNil(t, file.ScannedAt)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/files/"+fileID.String(), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Infected", func(t *testing.T) {
+		fileID, s3Key := upload(t, "virus.txt", clamav.EICAR)
+		scanner.Wait()
+
+		file, err := fileRepo.GetFileByID(fileID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FileScanInfected, file.ScanStatus)
+		require.NotNil(t, file.ScanSignature)
+		assert.Equal(t, clamav.EICARSignature, *file.ScanSignature)
+
+		exists, err := storage.HeadObject(context.Background(), s3Key)
+		require.NoError(t, err)
+		assert.False(t, exists, "infected object is deleted")
+
+		var notifications int
+		err = db.Get(&notifications, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2", testUser.ID, models.NotificationTypeFileInfected)
+		require.NoError(t, err)
+		assert.Equal(t, 1, notifications)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/files/"+fileID.String(), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	pendingFile := func(t *testing.T, fileName string) *models.File {
+		file, err := fileRepo.CreateFile(&models.File{
+			UserID:        testUser.ID,
+			ApplicationID: createdApp.ID,
+			FileName:      fileName,
+			FileType:      "text/plain",
+			FileSize:      16,
+			S3Key:         s3service.GenerateS3Key(testUser.ID, fileName),
+			ScanStatus:    models.FileScanPending,
+		})
+		require.NoError(t, err)
+		return file
+	}
+
+	t.Run("MissingObjectFails", func(t *testing.T) {
+		file := pendingFile(t, "missing.txt")
+		scanner.Submit(file)
+		scanner.Wait()
+
+		stored, err := fileRepo.GetFileByID(file.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FileScanFailed, stored.ScanStatus)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/files/"+file.ID.String(), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("TooLargeForClamdFails", func(t *testing.T) {
+		file := pendingFile(t, "huge.txt")
+		require.NoError(t, storage.PutObject(context.Background(), file.S3Key, "text/plain", strings.NewReader("interview notes"), 15))
+
+		fake.Err = clamav.ErrSizeLimit
+		defer func() { fake.Err = nil }()
+		scanner.Submit(file)
+		scanner.Wait()
+
+		stored, err := fileRepo.GetFileByID(file.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FileScanFailed, stored.ScanStatus)
+	})
+
+	t.Run("ClamdDownIsRetried", func(t *testing.T) {
+		file := pendingFile(t, "later.txt")
+		require.NoError(t, storage.PutObject(context.Background(), file.S3Key, "text/plain", strings.NewReader("interview notes"), 15))
+
+		fake.Err = fmt.Errorf("connection refused")
+		defer func() { fake.Err = nil }()
+		scanner.Submit(file)
+		scanner.Wait()
+
+		var attempts int
+		require.NoError(t, db.Get(&attempts, "SELECT scan_attempts FROM files WHERE id = $1", file.ID))
+		assert.Equal(t, 1, attempts)
+
+		stored, err := fileRepo.GetFileByID(file.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FileScanPending, stored.ScanStatus, "transient failures stay pending")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
 	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
@@ -23,19 +24,27 @@ type CreateInterviewRequest struct {
 	ScheduledDate   string    `json:"scheduled_date" binding:"required"`
 	ScheduledTime   *string   `json:"scheduled_time"`
 	DurationMinutes *int      `json:"duration_minutes"`
+	Location        *string   `json:"location" binding:"omitempty,max=500"`
+	MeetingLink     *string   `json:"meeting_link" binding:"omitempty,max=2000,url"`
 }
 
 type UpdateInterviewRequest struct {
 	ScheduledDate   *string `json:"scheduled_date"`
 	ScheduledTime   *string `json:"scheduled_time"`
 	DurationMinutes *int    `json:"duration_minutes"`
+	Location        *string `json:"location" binding:"omitempty,max=500"`
+	MeetingLink     *string `json:"meeting_link" binding:"omitempty,max=2000,url"`
 	InterviewType   *string `json:"interview_type" binding:"omitempty,oneof=phone_screen technical behavioral panel onsite other"`
 	Outcome         *string `json:"outcome"`
 	OverallFeeling  *string `json:"overall_feeling" binding:"omitempty,oneof=excellent good okay poor"`
 	WentWell        *string `json:"went_well"`
 	CouldImprove    *string `json:"could_improve"`
 	ConfidenceLevel *int    `json:"confidence_level" binding:"omitempty,min=1,max=5"`
-	Status          *string `json:"status" binding:"omitempty,oneof=scheduled completed cancelled"`
+	Status          *string `json:"status" binding:"omitempty,oneof=scheduled completed cancelled no_show"`
+	// ChangeInitiatedBy and ChangeReason describe a reschedule, cancellation
+	// or no-show made by this update, for the interview's event log
+	ChangeInitiatedBy *string `json:"change_initiated_by" binding:"omitempty,oneof=me company"`
+	ChangeReason      *string `json:"change_reason" binding:"omitempty,max=1000"`
 }
 
 type InterviewHandler struct {
@@ -44,19 +53,33 @@ type InterviewHandler struct {
 	interviewerRepo       *repository.InterviewerRepository
 	interviewQuestionRepo *repository.InterviewQuestionRepository
 	interviewNoteRepo     *repository.InterviewNoteRepository
+	interviewEventRepo    *repository.InterviewEventRepository
 	dashboardRepo         *repository.DashboardRepository
+	assessmentRepo        *repository.AssessmentRepository
+	fileRepo              *repository.FileRepository
+	profileRepo           *repository.UserCompanyProfileRepository
+	userRepo              *repository.UserRepository
+	syncRepo              *repository.CalendarSyncRepository
 	sanitizer             *services.SanitizerService
+	conflictBuffer        time.Duration
 }
 
-func NewInterviewHandler(appState *utils.AppState) *InterviewHandler {
+func NewInterviewHandler(appState *utils.AppState, conflictBuffer time.Duration) *InterviewHandler {
 	return &InterviewHandler{
 		interviewRepo:         repository.NewInterviewRepository(appState.DB),
 		applicationRepo:       repository.NewApplicationRepository(appState.DB),
 		interviewerRepo:       repository.NewInterviewerRepository(appState.DB),
 		interviewQuestionRepo: repository.NewInterviewQuestionRepository(appState.DB),
 		interviewNoteRepo:     repository.NewInterviewNoteRepository(appState.DB),
+		interviewEventRepo:    repository.NewInterviewEventRepository(appState.DB),
 		dashboardRepo:         repository.NewDashboardRepository(appState.DB),
+		assessmentRepo:        repository.NewAssessmentRepository(appState.DB),
+		fileRepo:              repository.NewFileRepository(appState.DB),
+		profileRepo:           repository.NewUserCompanyProfileRepository(appState.DB),
+		userRepo:              repository.NewUserRepository(appState.DB),
+		syncRepo:              repository.NewCalendarSyncRepository(appState.DB),
 		sanitizer:             appState.Sanitizer,
+		conflictBuffer:        conflictBuffer,
 	}
 }
 
@@ -88,6 +111,8 @@ func (h *InterviewHandler) CreateInterview(c *gin.Context) {
 		ScheduledTime:   req.ScheduledTime,
 		InterviewType:   req.InterviewType,
 		DurationMinutes: req.DurationMinutes,
+		Location:        req.Location,
+		MeetingLink:     req.MeetingLink,
 	}
 
 	createdInterview, err := h.interviewRepo.CreateInterview(interview)
@@ -96,22 +121,27 @@ func (h *InterviewHandler) CreateInterview(c *gin.Context) {
 		return
 	}
 
-	// Auto-upgrade application status to "Interview" if currently Draft/Saved/Applied
-	appWithDetails, err := h.applicationRepo.GetApplicationByIDWithDetails(req.ApplicationID, userID)
+	h.promoteApplicationToInterview(req.ApplicationID, userID)
+
+	h.dashboardRepo.InvalidateCache(userID)
+	response.SuccessWithWarnings(c, gin.H{
+		"interview": createdInterview,
+	}, h.conflictWarnings(c, userID, createdInterview))
+}
+
+// promoteApplicationToInterview auto-upgrades the application status to
+// "Interview" if it is currently Draft/Saved/Applied
+func (h *InterviewHandler) promoteApplicationToInterview(applicationID, userID uuid.UUID) {
+	appWithDetails, err := h.applicationRepo.GetApplicationByIDWithDetails(applicationID, userID)
 	if err == nil && appWithDetails.Status != nil {
 		statusName := strings.ToLower(appWithDetails.Status.Name)
 		if statusName == "draft" || statusName == "saved" || statusName == "applied" {
 			interviewStatusID, err := h.applicationRepo.GetApplicationStatusIDByName("Interview")
 			if err == nil {
-				_ = h.applicationRepo.UpdateApplicationStatus(req.ApplicationID, userID, interviewStatusID)
+				_ = h.applicationRepo.UpdateApplicationStatus(applicationID, userID, interviewStatusID)
 			}
 		}
 	}
-
-	h.dashboardRepo.InvalidateCache(userID)
-	response.Success(c, gin.H{
-		"interview": createdInterview,
-	})
 }
 
 func (h *InterviewHandler) GetInterviewByID(c *gin.Context) {
@@ -131,7 +161,8 @@ func (h *InterviewHandler) GetInterviewByID(c *gin.Context) {
 	}
 
 	response.Success(c, gin.H{
-		"interview": interviewWithInfo.Interview,
+		"interview":           interviewWithInfo.Interview,
+		"allowed_transitions": workflow.Interview.Allowed(interviewWithInfo.Status),
 		"application": gin.H{
 			"company_name": interviewWithInfo.CompanyName,
 			"job_title":    interviewWithInfo.JobTitle,
@@ -186,8 +217,19 @@ func (h *InterviewHandler) GetInterviewWithDetails(c *gin.Context) {
 		notes = []*models.InterviewNote{}
 	}
 
+	// Get reschedules, cancellations and no-shows
+	events, err := h.interviewEventRepo.ListByInterview(interviewID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if events == nil {
+		events = []*models.InterviewEvent{}
+	}
+
 	response.Success(c, gin.H{
-		"interview": interviewWithInfo.Interview,
+		"interview":           interviewWithInfo.Interview,
+		"allowed_transitions": workflow.Interview.Allowed(interviewWithInfo.Status),
 		"application": gin.H{
 			"company_name": interviewWithInfo.CompanyName,
 			"job_title":    interviewWithInfo.JobTitle,
@@ -195,6 +237,7 @@ func (h *InterviewHandler) GetInterviewWithDetails(c *gin.Context) {
 		"interviewers": interviewers,
 		"questions":    questions,
 		"notes":        notes,
+		"events":       events,
 	})
 }
 
@@ -233,6 +276,22 @@ func (h *InterviewHandler) UpdateInterview(c *gin.Context) {
 		updates["duration_minutes"] = *req.DurationMinutes
 	}
 
+	if req.Location != nil {
+		if *req.Location == "" {
+			updates["location"] = nil
+		} else {
+			updates["location"] = *req.Location
+		}
+	}
+
+	if req.MeetingLink != nil {
+		if *req.MeetingLink == "" {
+			updates["meeting_link"] = nil
+		} else {
+			updates["meeting_link"] = *req.MeetingLink
+		}
+	}
+
 	if req.InterviewType != nil {
 		updates["interview_type"] = *req.InterviewType
 	}
@@ -269,16 +328,22 @@ func (h *InterviewHandler) UpdateInterview(c *gin.Context) {
 		updates["status"] = *req.Status
 	}
 
-	updatedInterview, err := h.interviewRepo.UpdateInterview(interviewID, userID, updates)
+	change := &repository.InterviewChange{InitiatedBy: req.ChangeInitiatedBy}
+	if req.ChangeReason != nil && *req.ChangeReason != "" {
+		change.Reason = req.ChangeReason
+	}
+
+	updatedInterview, _, err := h.interviewRepo.UpdateInterviewWithChange(interviewID, userID, updates, change)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
 	h.dashboardRepo.InvalidateCache(userID)
-	response.Success(c, gin.H{
-		"interview": updatedInterview,
-	})
+	response.SuccessWithWarnings(c, gin.H{
+		"interview":           updatedInterview,
+		"allowed_transitions": workflow.Interview.Allowed(updatedInterview.Status),
+	}, h.conflictWarnings(c, userID, updatedInterview))
 }
 
 func (h *InterviewHandler) ListInterviews(c *gin.Context) {
@@ -425,7 +490,8 @@ func (h *InterviewHandler) GetInterviewWithContext(c *gin.Context) {
 
 	response.Success(c, gin.H{
 		"current_interview": gin.H{
-			"interview": interviewWithInfo.Interview,
+			"interview":           interviewWithInfo.Interview,
+			"allowed_transitions": workflow.Interview.Allowed(interviewWithInfo.Status),
 			"application": gin.H{
 				"company_name": interviewWithInfo.CompanyName,
 				"job_title":    interviewWithInfo.JobTitle,
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/schedule"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// GET /api/interviews/conflicts
+// Lists every pair of upcoming interviews and assessment deadlines that are
+// closer together than the conflict buffer.
+func (h *InterviewHandler) GetConflicts(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	loc, err := h.interviewLocation(c, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	events, err := h.upcomingEvents(userID, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	conflicts := schedule.Conflicts(events, h.conflictBuffer)
+	if conflicts == nil {
+		conflicts = []schedule.Conflict{}
+	}
+
+	response.Success(c, gin.H{
+		"conflicts":      conflicts,
+		"buffer_minutes": int(h.conflictBuffer.Minutes()),
+	})
+}
+
+// interviewLocation is the time zone interview wall-clock times are read in:
+// the request's timezone parameter or form field, else the user's calendar
+// sync time zone, else UTC.
+func (h *InterviewHandler) interviewLocation(c *gin.Context, userID uuid.UUID) (*time.Location, error) {
+	tz := c.Query("timezone")
+	if tz == "" {
+		tz = c.PostForm("timezone")
+	}
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, errors.New(errors.ErrorBadRequest, "invalid timezone")
+		}
+		return loc, nil
+	}
+
+	if account, err := h.syncRepo.GetAccount(userID); err == nil {
+		if loc, err := time.LoadLocation(account.Timezone); err == nil {
+			return loc, nil
+		}
+	}
+	return time.UTC, nil
+}
+
+// conflictWarnings describes what a newly saved interview clashes with.
+// Conflicts never block a save, so lookup failures just mean no warnings.
+func (h *InterviewHandler) conflictWarnings(c *gin.Context, userID uuid.UUID, interview *models.Interview) []string {
+	if interview.Status != models.InterviewStatusScheduled {
+		return nil
+	}
+	loc, err := h.interviewLocation(c, userID)
+	if err != nil {
+		return nil
+	}
+	start, end, ok := schedule.InterviewWindow(interview.ScheduledDate, interview.ScheduledTime, interview.DurationMinutes, loc)
+	if !ok {
+		return nil
+	}
+
+	events, err := h.upcomingEvents(userID, loc)
+	if err != nil {
+		return nil
+	}
+
+	event := schedule.Event{Kind: schedule.KindInterview, ID: interview.ID, Start: start, End: end}
+	var warnings []string
+	for _, other := range schedule.Overlapping(event, events, h.conflictBuffer) {
+		if other.Kind == schedule.KindAssessment {
+			warnings = append(warnings, fmt.Sprintf("%s is due %s, during or close to this interview",
+				other.Title, other.Start.Format("2006-01-02 15:04")))
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("Overlaps with %s from %s to %s",
+			other.Title, other.Start.Format("2006-01-02 15:04"), other.End.Format("15:04")))
+	}
+	return warnings
+}
+
+// upcomingEvents places the user's scheduled interviews and outstanding
+// assessment deadlines that have not passed yet on one calendar. Interview
+// times are read in loc, and deadlines are shown in it too.
+func (h *InterviewHandler) upcomingEvents(userID uuid.UUID, loc *time.Location) ([]schedule.Event, error) {
+	interviews, _, err := h.interviewRepo.GetInterviewsWithApplicationInfo(userID, &repository.InterviewListFilter{Filter: "upcoming"})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []schedule.Event
+	for _, interview := range interviews {
+		if interview.Status != models.InterviewStatusScheduled {
+			continue
+		}
+		start, end, ok := schedule.InterviewWindow(interview.ScheduledDate, interview.ScheduledTime, interview.DurationMinutes, loc)
+		if !ok {
+			continue
+		}
+		events = append(events, schedule.Event{
+			Kind:          schedule.KindInterview,
+			ID:            interview.ID,
+			ApplicationID: interview.ApplicationID,
+			Title: fmt.Sprintf("%s %s interview (round %d)", interview.CompanyName,
+				strings.ReplaceAll(interview.InterviewType, "_", " "), interview.RoundNumber),
+			Start: start,
+			End:   end,
+		})
+	}
+
+	now := time.Now()
+	assessments, err := h.assessmentRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assessment := range assessments {
+		if assessment.Status != models.AssessmentStatusNotStarted && assessment.Status != models.AssessmentStatusInProgress {
+			continue
+		}
+		due := assessment.DueDate.In(loc)
+		if !due.After(now) {
+			continue
+		}
+		events = append(events, schedule.Event{
+			Kind:          schedule.KindAssessment,
+			ID:            assessment.ID,
+			ApplicationID: assessment.ApplicationID,
+			Title:         fmt.Sprintf("%s assessment %q", assessment.CompanyName, assessment.Title),
+			Start:         due,
+			End:           due,
+		})
+	}
+
+	return events, nil
+}
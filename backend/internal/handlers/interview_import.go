@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/companymatch"
+	"ditto-backend/internal/services/ics"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// maxICSFileSize caps uploaded invites; real ones are a few kilobytes.
+const maxICSFileSize = 1 << 20
+
+// freeMailDomains are never taken to be a company's domain when matching an
+// invite to an application.
+var freeMailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true, "outlook.com": true, "hotmail.com": true,
+	"live.com": true, "yahoo.com": true, "icloud.com": true, "me.com": true,
+	"aol.com": true, "proton.me": true, "protonmail.com": true,
+}
+
+// interviewTypeKeywords guesses an interview type from an invite's title.
+// The first matching entry wins.
+var interviewTypeKeywords = []struct {
+	interviewType string
+	keywords      []string
+}{
+	{models.InterviewTypeOnsite, []string{"onsite", "on-site", "on site", "final round"}},
+	{models.InterviewTypePanel, []string{"panel"}},
+	{models.InterviewTypeTechnical, []string{"technical", "coding", "system design", "pair programming", "pairing", "take-home review"}},
+	{models.InterviewTypeBehavioral, []string{"behavioral", "behavioural", "culture", "values"}},
+	{models.InterviewTypePhoneScreen, []string{"phone", "screen", "intro call", "introductory call", "recruiter call"}},
+}
+
+// ICSImportEvent is one event of an uploaded invite as it would be imported.
+type ICSImportEvent struct {
+	UID             string                                 `json:"uid"`
+	Summary         string                                 `json:"summary"`
+	Start           time.Time                              `json:"start"`
+	End             time.Time                              `json:"end"`
+	TimeZone        string                                 `json:"time_zone"`
+	AllDay          bool                                   `json:"all_day"`
+	Cancelled       bool                                   `json:"cancelled"`
+	ScheduledDate   string                                 `json:"scheduled_date"`
+	ScheduledTime   *string                                `json:"scheduled_time,omitempty"`
+	DurationMinutes *int                                   `json:"duration_minutes,omitempty"`
+	InterviewType   string                                 `json:"interview_type"`
+	Location        string                                 `json:"location,omitempty"`
+	ConferenceURL   string                                 `json:"conference_url,omitempty"`
+	Organizer       *ICSImportPerson                       `json:"organizer,omitempty"`
+	Interviewers    []ICSImportPerson                      `json:"interviewers"`
+	Matches         []*repository.ApplicationCompanyDomain `json:"matches"`
+}
+
+type ICSImportPerson struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// POST /api/interviews/import-ics
+// Reads an uploaded calendar invite. With preview=true nothing is saved and
+// the parsed events are returned with the applications they probably belong
+// to; otherwise the chosen event is created as an interview.
+func (h *InterviewHandler) ImportICS(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "an .ics file is required"))
+		return
+	}
+	if fileHeader.Size > maxICSFileSize {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "calendar file is too large"))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		HandleError(c, errors.Wrap(errors.ErrorBadRequest, "failed to read calendar file", err))
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxICSFileSize))
+	if err != nil {
+		HandleError(c, errors.Wrap(errors.ErrorBadRequest, "failed to read calendar file", err))
+		return
+	}
+
+	// Times are shown in the user's zone when they give one, otherwise in
+	// the zone the invite was written in
+	var displayLoc *time.Location
+	if tz := c.PostForm("timezone"); tz != "" {
+		displayLoc, err = time.LoadLocation(tz)
+		if err != nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid timezone"))
+			return
+		}
+	}
+	floatingLoc := time.UTC
+	if displayLoc != nil {
+		floatingLoc = displayLoc
+	}
+
+	cal, err := ics.Parse(data, floatingLoc)
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, fmt.Sprintf("invalid calendar file: %v", err)))
+		return
+	}
+
+	user, err := h.userRepo.GetUserByID(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	applications, err := h.applicationRepo.GetApplicationCompanyDomains(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	ownEmails := map[string]bool{strings.ToLower(user.Email): true}
+	events := make([]ICSImportEvent, 0, len(cal.Events))
+	for i := range cal.Events {
+		events = append(events, buildICSImportEvent(&cal.Events[i], displayLoc, ownEmails, applications))
+	}
+
+	if c.PostForm("preview") == "true" || c.Query("preview") == "true" {
+		response.Success(c, gin.H{
+			"method": cal.Method,
+			"events": events,
+		})
+		return
+	}
+
+	event, err := chooseICSEvent(events, c.PostForm("event_uid"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var applicationID uuid.UUID
+	if idStr := c.PostForm("application_id"); idStr != "" {
+		applicationID, err = uuid.Parse(idStr)
+		if err != nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid application ID"))
+			return
+		}
+	} else if len(event.Matches) == 1 {
+		applicationID = event.Matches[0].ApplicationID
+	} else {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "application_id is required when the invite doesn't match exactly one application"))
+		return
+	}
+
+	if _, err := h.applicationRepo.GetApplicationByID(applicationID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	interviewType := event.InterviewType
+	if override := c.PostForm("interview_type"); override != "" {
+		if !isInterviewType(override) {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid interview type"))
+			return
+		}
+		interviewType = override
+	}
+
+	scheduledDate, _ := time.Parse("2006-01-02", event.ScheduledDate)
+	interview := &models.Interview{
+		UserID:          userID,
+		ApplicationID:   applicationID,
+		ScheduledDate:   scheduledDate,
+		ScheduledTime:   event.ScheduledTime,
+		DurationMinutes: event.DurationMinutes,
+		InterviewType:   interviewType,
+	}
+	if event.Location != "" && event.Location != event.ConferenceURL {
+		location := truncateRunes(event.Location, 500)
+		interview.Location = &location
+	}
+	if event.ConferenceURL != "" {
+		link := truncateRunes(event.ConferenceURL, 2000)
+		interview.MeetingLink = &link
+	}
+	if event.UID != "" {
+		uid := event.UID
+		interview.ICSUID = &uid
+	}
+
+	interviewers := make([]*models.Interviewer, 0, len(event.Interviewers))
+	for _, person := range event.Interviewers {
+		interviewers = append(interviewers, &models.Interviewer{Name: person.Name})
+	}
+
+	createdInterview, interviewers, err := h.interviewRepo.CreateInterviewWithInterviewers(interview, interviewers)
+	if err != nil {
+		if errors.ConvertError(err).Code == errors.ErrorConflict {
+			HandleError(c, errors.New(errors.ErrorConflict, "this invite has already been imported"))
+			return
+		}
+		HandleError(c, err)
+		return
+	}
+
+	h.promoteApplicationToInterview(applicationID, userID)
+
+	h.dashboardRepo.InvalidateCache(userID)
+	response.SuccessWithWarnings(c, gin.H{
+		"interview":    createdInterview,
+		"interviewers": interviewers,
+	}, h.conflictWarnings(c, userID, createdInterview))
+}
+
+// chooseICSEvent picks the event to import: the one named by uid, or the
+// first one that isn't a cancellation.
+func chooseICSEvent(events []ICSImportEvent, uid string) (*ICSImportEvent, error) {
+	for i := range events {
+		event := &events[i]
+		if uid != "" && event.UID != uid {
+			continue
+		}
+		if event.Cancelled {
+			if uid != "" {
+				return nil, errors.New(errors.ErrorBadRequest, "the invite cancels this event")
+			}
+			continue
+		}
+		return event, nil
+	}
+	if uid != "" {
+		return nil, errors.New(errors.ErrorNotFound, "event not found in calendar file")
+	}
+	return nil, errors.New(errors.ErrorBadRequest, "the invite only contains cancelled events")
+}
+
+func buildICSImportEvent(event *ics.Event, displayLoc *time.Location, ownEmails map[string]bool, applications []*repository.ApplicationCompanyDomain) ICSImportEvent {
+	start, end := event.Start, event.End
+	zone := event.TimeZone
+	if displayLoc != nil && !event.AllDay {
+		start, end = start.In(displayLoc), end.In(displayLoc)
+		zone = displayLoc.String()
+	}
+
+	result := ICSImportEvent{
+		UID:           event.UID,
+		Summary:       event.Summary,
+		Start:         start,
+		End:           end,
+		TimeZone:      zone,
+		AllDay:        event.AllDay,
+		Cancelled:     event.Cancelled(),
+		ScheduledDate: start.Format("2006-01-02"),
+		InterviewType: guessInterviewType(event.Summary),
+		Location:      event.Location,
+		ConferenceURL: event.ConferenceURL,
+		Interviewers:  []ICSImportPerson{},
+	}
+	if !event.AllDay {
+		scheduledTime := start.Format("15:04")
+		minutes := int(event.Duration().Minutes())
+		result.ScheduledTime = &scheduledTime
+		if minutes > 0 {
+			result.DurationMinutes = &minutes
+		}
+	}
+
+	if event.Organizer != nil {
+		result.Organizer = &ICSImportPerson{Name: event.Organizer.DisplayName(), Email: event.Organizer.Email}
+	}
+
+	seen := make(map[string]bool)
+	for _, attendee := range event.Attendees {
+		if attendee.Resource || ownEmails[attendee.Email] || seen[attendee.Email] {
+			continue
+		}
+		seen[attendee.Email] = true
+		result.Interviewers = append(result.Interviewers, ICSImportPerson{Name: attendee.DisplayName(), Email: attendee.Email})
+	}
+	if len(result.Interviewers) == 0 && result.Organizer != nil && !ownEmails[result.Organizer.Email] {
+		result.Interviewers = append(result.Interviewers, *result.Organizer)
+	}
+
+	result.Matches = matchInviteApplications(event, ownEmails, applications)
+	return result
+}
+
+// matchInviteApplications finds the user's applications to the company that
+// sent an invite, by the organizer's email domain. Invites sent from a
+// personal or scheduling-tool address fall back to the attendees' domains.
+func matchInviteApplications(event *ics.Event, ownEmails map[string]bool, applications []*repository.ApplicationCompanyDomain) []*repository.ApplicationCompanyDomain {
+	var organizerDomains, attendeeDomains []string
+	if event.Organizer != nil && !ownEmails[event.Organizer.Email] {
+		organizerDomains = append(organizerDomains, event.Organizer.Domain())
+	}
+	for _, attendee := range event.Attendees {
+		if !attendee.Resource && !ownEmails[attendee.Email] {
+			attendeeDomains = append(attendeeDomains, attendee.Domain())
+		}
+	}
+
+	matches := applicationsForDomains(organizerDomains, applications)
+	if len(matches) == 0 {
+		matches = applicationsForDomains(attendeeDomains, applications)
+	}
+	return matches
+}
+
+func applicationsForDomains(emailDomains []string, applications []*repository.ApplicationCompanyDomain) []*repository.ApplicationCompanyDomain {
+	matches := []*repository.ApplicationCompanyDomain{}
+	for _, app := range applications {
+		companyDomain := companymatch.NormalizeDomain(app.CompanyDomain)
+		if companyDomain == "" {
+			continue
+		}
+		for _, domain := range emailDomains {
+			if freeMailDomains[domain] {
+				continue
+			}
+			if domain == companyDomain || strings.HasSuffix(domain, "."+companyDomain) {
+				matches = append(matches, app)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func guessInterviewType(summary string) string {
+	lower := strings.ToLower(summary)
+	for _, entry := range interviewTypeKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(lower, keyword) {
+				return entry.interviewType
+			}
+		}
+	}
+	return models.InterviewTypeOther
+}
+
+func isInterviewType(value string) bool {
+	switch value {
+	case models.InterviewTypePhoneScreen, models.InterviewTypeTechnical, models.InterviewTypeBehavioral,
+		models.InterviewTypePanel, models.InterviewTypeOnsite, models.InterviewTypeOther:
+		return true
+	}
+	return false
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
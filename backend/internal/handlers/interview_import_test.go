@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/ics"
+)
+
+func TestBuildICSImportEvent(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	acme := &repository.ApplicationCompanyDomain{ApplicationID: uuid.New(), CompanyName: "Acme", CompanyDomain: "https://www.acme.com"}
+	other := &repository.ApplicationCompanyDomain{ApplicationID: uuid.New(), CompanyName: "Other", CompanyDomain: "other.io"}
+	applications := []*repository.ApplicationCompanyDomain{acme, other}
+	ownEmails := map[string]bool{"me@example.com": true}
+
+	event := &ics.Event{
+		UID:           "abc",
+		Summary:       "Acme - System Design Interview",
+		Start:         time.Date(2026, 3, 10, 14, 0, 0, 0, newYork),
+		End:           time.Date(2026, 3, 10, 15, 0, 0, 0, newYork),
+		TimeZone:      "America/New_York",
+		ConferenceURL: "https://meet.google.com/abc",
+		Organizer:     &ics.Person{Name: "Jane", Email: "jane@talent.acme.com"},
+		Attendees: []ics.Person{
+			{Name: "Jane", Email: "jane@talent.acme.com"},
+			{Email: "bob@acme.com"},
+			{Name: "Me", Email: "me@example.com"},
+			{Name: "Room 1", Email: "room@acme.com", Resource: true},
+			{Name: "Jane", Email: "jane@talent.acme.com"},
+		},
+	}
+
+	t.Run("in the invite's zone", func(t *testing.T) {
+		result := buildICSImportEvent(event, nil, ownEmails, applications)
+
+		assert.Equal(t, "2026-03-10", result.ScheduledDate)
+		assert.Equal(t, "14:00", *result.ScheduledTime)
+		assert.Equal(t, 60, *result.DurationMinutes)
+		assert.Equal(t, models.InterviewTypeTechnical, result.InterviewType)
+		assert.Equal(t, []ICSImportPerson{{Name: "Jane", Email: "jane@talent.acme.com"}, {Name: "bob", Email: "bob@acme.com"}}, result.Interviewers)
+		require.Len(t, result.Matches, 1)
+		assert.Equal(t, acme.ApplicationID, result.Matches[0].ApplicationID)
+	})
+
+	t.Run("in the user's zone", func(t *testing.T) {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		result := buildICSImportEvent(event, berlin, ownEmails, applications)
+
+		assert.Equal(t, "19:00", *result.ScheduledTime)
+		assert.Equal(t, "Europe/Berlin", result.TimeZone)
+	})
+
+	t.Run("personal organizer falls back to attendees", func(t *testing.T) {
+		personal := *event
+		personal.Organizer = &ics.Person{Email: "recruiter@gmail.com"}
+		personal.Attendees = []ics.Person{{Email: "hm@eng.other.io"}}
+
+		result := buildICSImportEvent(&personal, nil, ownEmails, applications)
+
+		require.Len(t, result.Matches, 1)
+		assert.Equal(t, other.ApplicationID, result.Matches[0].ApplicationID)
+	})
+}
+
+func TestChooseICSEvent(t *testing.T) {
+	events := []ICSImportEvent{
+		{UID: "a", Cancelled: true},
+		{UID: "b"},
+	}
+
+	event, err := chooseICSEvent(events, "")
+	require.NoError(t, err)
+	assert.Equal(t, "b", event.UID)
+
+	_, err = chooseICSEvent(events, "a")
+	assert.Error(t, err)
+
+	_, err = chooseICSEvent(events, "missing")
+	assert.Error(t, err)
+}
+
+func TestGuessInterviewType(t *testing.T) {
+	assert.Equal(t, models.InterviewTypePhoneScreen, guessInterviewType("Intro call with Acme"))
+	assert.Equal(t, models.InterviewTypeOnsite, guessInterviewType("Final Round (onsite)"))
+	assert.Equal(t, models.InterviewTypeOther, guessInterviewType("Chat"))
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"strconv"
+
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
 	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/notediff"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
@@ -15,24 +18,31 @@ import (
 const maxNoteContentSize = 50000 // 50KB
 
 type CreateOrUpdateNoteRequest struct {
-	NoteType string  `json:"note_type" binding:"required,oneof=preparation company_research feedback reflection general"`
-	Content  *string `json:"content"`
+	NoteType      string  `json:"note_type" binding:"required,oneof=preparation company_research feedback reflection general"`
+	Content       *string `json:"content"`
+	ContentFormat string  `json:"content_format" binding:"omitempty,oneof=html markdown"`
 }
 
 type InterviewNoteHandler struct {
 	interviewNoteRepo *repository.InterviewNoteRepository
 	interviewRepo     *repository.InterviewRepository
 	sanitizer         *services.SanitizerService
+	maxRevisions      int
 }
 
-func NewInterviewNoteHandler(appState *utils.AppState) *InterviewNoteHandler {
+// NewInterviewNoteHandler keeps at most maxRevisions revisions per note; zero
+// or less keeps every revision.
+func NewInterviewNoteHandler(appState *utils.AppState, maxRevisions int) *InterviewNoteHandler {
 	return &InterviewNoteHandler{
 		interviewNoteRepo: repository.NewInterviewNoteRepository(appState.DB),
 		interviewRepo:     repository.NewInterviewRepository(appState.DB),
 		sanitizer:         appState.Sanitizer,
+		maxRevisions:      maxRevisions,
 	}
 }
 
+// POST /api/interviews/:id/notes
+// Every save that changes the content is kept as a revision.
 func (h *InterviewNoteHandler) CreateOrUpdateNote(c *gin.Context) {
 	userID := c.MustGet("user_id").(uuid.UUID)
 
@@ -60,43 +70,168 @@ func (h *InterviewNoteHandler) CreateOrUpdateNote(c *gin.Context) {
 		return
 	}
 
-	if req.Content != nil && *req.Content != "" {
-		sanitized := h.sanitizer.SanitizeHTML(*req.Content)
-		req.Content = &sanitized
+	req.Content = prepareNote(h.sanitizer, req.Content, req.ContentFormat)
+
+	note, revision, err := h.interviewNoteRepo.SaveNote(interviewID, req.NoteType, req.Content, req.ContentFormat, userID, h.maxRevisions)
+	if err != nil {
+		HandleError(c, err)
+		return
 	}
 
-	existingNote, err := h.interviewNoteRepo.GetNoteByInterviewAndType(interviewID, req.NoteType)
+	response.Success(c, gin.H{
+		"interviewNote": note,
+		"revision":      revision,
+	})
+}
+
+// GET /api/interviews/:id/notes/:type/revisions
+func (h *InterviewNoteHandler) ListRevisions(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	note, err := h.findNote(c, userID)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
-	var newInterviewNote *models.InterviewNote
-	if existingNote == nil {
-		interviewNote := &models.InterviewNote{
-			InterviewID: interviewID,
-			NoteType:    req.NoteType,
-			Content:     req.Content,
-		}
-		createdInterviewNote, err := h.interviewNoteRepo.CreateInterviewNote(interviewNote)
+	revisions, err := h.interviewNoteRepo.ListRevisions(note.ID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if revisions == nil {
+		revisions = []*models.InterviewNoteRevision{}
+	}
+
+	response.Success(c, gin.H{
+		"revisions": revisions,
+	})
+}
+
+// GET /api/interviews/:id/notes/:type/revisions/:revision
+func (h *InterviewNoteHandler) GetRevision(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	note, err := h.findNote(c, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	revision, err := h.getRevision(note.ID, c.Param("revision"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"revision": revision,
+	})
+}
+
+// POST /api/interviews/:id/notes/:type/revisions/:revision/restore
+// Saves the revision's content as the note's current content. The restore is
+// itself a new revision, so nothing in between is lost.
+func (h *InterviewNoteHandler) RestoreRevision(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	note, err := h.findNote(c, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	revision, err := h.getRevision(note.ID, c.Param("revision"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	restored, newRevision, err := h.interviewNoteRepo.SaveNote(note.InterviewID, note.NoteType, revision.Content, revision.ContentFormat, userID, h.maxRevisions)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"interviewNote": restored,
+		"revision":      newRevision,
+	})
+}
+
+// GET /api/interviews/:id/notes/:type/diff?from=&to=
+// Compares two revisions line by line. to defaults to the latest revision.
+func (h *InterviewNoteHandler) DiffRevisions(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	note, err := h.findNote(c, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	from, err := h.getRevision(note.ID, c.Query("from"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var to *models.InterviewNoteRevision
+	if param := c.Query("to"); param != "" {
+		to, err = h.getRevision(note.ID, param)
 		if err != nil {
 			HandleError(c, err)
 			return
 		}
-		newInterviewNote = createdInterviewNote
 	} else {
-		updates := map[string]any{
-			"content": req.Content,
-		}
-		updatedInterviewNote, err := h.interviewNoteRepo.UpdateInterviewNote(existingNote.ID, updates)
+		to, err = h.interviewNoteRepo.GetLatestRevision(note.ID)
 		if err != nil {
 			HandleError(c, err)
 			return
 		}
-		newInterviewNote = updatedInterviewNote
 	}
 
 	response.Success(c, gin.H{
-		"interviewNote": newInterviewNote,
+		"from": from.RevisionNumber,
+		"to":   to.RevisionNumber,
+		"diff": notediff.Diff(noteText(from.Content), noteText(to.Content)),
 	})
 }
+
+// findNote returns the note named by the :id and :type params, if the
+// interview belongs to the user.
+func (h *InterviewNoteHandler) findNote(c *gin.Context, userID uuid.UUID) (*models.InterviewNote, error) {
+	interviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, errors.New(errors.ErrorBadRequest, "invalid interview ID")
+	}
+
+	if _, err := h.interviewRepo.GetInterviewByID(interviewID, userID); err != nil {
+		return nil, err
+	}
+
+	note, err := h.interviewNoteRepo.GetNoteByInterviewAndType(interviewID, c.Param("type"))
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, errors.New(errors.ErrorNotFound, "interview note not found")
+	}
+
+	return note, nil
+}
+
+func (h *InterviewNoteHandler) getRevision(noteID uuid.UUID, param string) (*models.InterviewNoteRevision, error) {
+	revisionNumber, err := strconv.Atoi(param)
+	if err != nil || revisionNumber < 1 {
+		return nil, errors.New(errors.ErrorBadRequest, "invalid revision number")
+	}
+	return h.interviewNoteRepo.GetRevision(noteID, revisionNumber)
+}
+
+func noteText(content *string) string {
+	if content == nil {
+		return ""
+	}
+	return *content
+}
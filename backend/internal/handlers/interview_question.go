@@ -3,6 +3,7 @@ package handlers
 import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/questionbank"
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
@@ -12,8 +13,10 @@ import (
 )
 
 type CreateQuestionRequest struct {
-	QuestionText string  `json:"question_text" binding:"required"`
-	AnswerText   *string `json:"answer_text"`
+	QuestionText string     `json:"question_text" binding:"required"`
+	AnswerText   *string    `json:"answer_text"`
+	BankEntryID  *uuid.UUID `json:"bank_entry_id"`
+	StoryID      *uuid.UUID `json:"story_id"`
 }
 
 type CreateQuestionsRequest struct {
@@ -25,6 +28,18 @@ type UpdateQuestionRequest struct {
 	AnswerText   *string `json:"answer_text"`
 }
 
+// LinkBankEntryRequest links a question to a bank entry, or unlinks it when
+// bank_entry_id is null
+type LinkBankEntryRequest struct {
+	BankEntryID *uuid.UUID `json:"bank_entry_id"`
+}
+
+// LinkStoryRequest records the story used to answer a question, or clears it
+// when story_id is null
+type LinkStoryRequest struct {
+	StoryID *uuid.UUID `json:"story_id"`
+}
+
 type ReorderQuestionsRequest struct {
 	QuestionIDs []string `json:"question_ids" binding:"required,min=1"`
 }
@@ -32,20 +47,26 @@ type ReorderQuestionsRequest struct {
 type InterviewQuestionHandler struct {
 	questionRepo  *repository.InterviewQuestionRepository
 	interviewRepo *repository.InterviewRepository
+	bankRepo      *repository.QuestionBankRepository
+	storyRepo     *repository.StoryRepository
 }
 
 func NewInterviewQuestionHandler(appState *utils.AppState) *InterviewQuestionHandler {
 	return &InterviewQuestionHandler{
 		questionRepo:  repository.NewInterviewQuestionRepository(appState.DB),
 		interviewRepo: repository.NewInterviewRepository(appState.DB),
+		bankRepo:      repository.NewQuestionBankRepository(appState.DB),
+		storyRepo:     repository.NewStoryRepository(appState.DB),
 	}
 }
 
 // CreateQuestionUnifiedRequest handles both single and bulk creation
 type CreateQuestionUnifiedRequest struct {
 	// For single creation
-	QuestionText *string `json:"question_text"`
-	AnswerText   *string `json:"answer_text"`
+	QuestionText *string    `json:"question_text"`
+	AnswerText   *string    `json:"answer_text"`
+	BankEntryID  *uuid.UUID `json:"bank_entry_id"`
+	StoryID      *uuid.UUID `json:"story_id"`
 	// For bulk creation
 	Questions []CreateQuestionRequest `json:"questions"`
 }
@@ -84,10 +105,20 @@ func (h *InterviewQuestionHandler) CreateQuestion(c *gin.Context) {
 				HandleError(c, errors.New(errors.ErrorBadRequest, "question_text is required for all questions"))
 				return
 			}
+			if err := h.checkBankEntry(item.BankEntryID, userID); err != nil {
+				HandleError(c, err)
+				return
+			}
+			if err := h.checkStory(item.StoryID, userID); err != nil {
+				HandleError(c, err)
+				return
+			}
 			question := &models.InterviewQuestion{
 				InterviewID:  interviewID,
 				QuestionText: item.QuestionText,
 				AnswerText:   item.AnswerText,
+				BankEntryID:  item.BankEntryID,
+				StoryID:      item.StoryID,
 			}
 			questions = append(questions, question)
 		}
@@ -96,8 +127,14 @@ func (h *InterviewQuestionHandler) CreateQuestion(c *gin.Context) {
 			HandleError(c, err)
 			return
 		}
+		suggestions, err := h.suggestBankEntries(userID, created)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
 		response.Success(c, gin.H{
-			"questions": created,
+			"questions":              created,
+			"suggested_bank_entries": suggestions,
 		})
 		return
 	}
@@ -108,10 +145,21 @@ func (h *InterviewQuestionHandler) CreateQuestion(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkBankEntry(req.BankEntryID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+	if err := h.checkStory(req.StoryID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
 	question := &models.InterviewQuestion{
 		InterviewID:  interviewID,
 		QuestionText: *req.QuestionText,
 		AnswerText:   req.AnswerText,
+		BankEntryID:  req.BankEntryID,
+		StoryID:      req.StoryID,
 	}
 
 	created, err := h.questionRepo.CreateInterviewQuestion(question)
@@ -120,8 +168,15 @@ func (h *InterviewQuestionHandler) CreateQuestion(c *gin.Context) {
 		return
 	}
 
+	suggestions, err := h.suggestBankEntries(userID, []*models.InterviewQuestion{created})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
 	response.Success(c, gin.H{
-		"question": created,
+		"question":               created,
+		"suggested_bank_entries": suggestions[created.ID],
 	})
 }
 
@@ -184,6 +239,100 @@ func (h *InterviewQuestionHandler) UpdateQuestion(c *gin.Context) {
 	})
 }
 
+// LinkBankEntry handles PUT /api/interview-questions/:id/bank-entry
+func (h *InterviewQuestionHandler) LinkBankEntry(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid question ID"))
+		return
+	}
+
+	// Get question and verify ownership
+	question, err := h.questionRepo.GetInterviewQuestionByID(questionID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	_, err = h.interviewRepo.GetInterviewByID(question.InterviewID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req LinkBankEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid request body"))
+		return
+	}
+
+	if err := h.checkBankEntry(req.BankEntryID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	updated, err := h.questionRepo.UpdateInterviewQuestion(questionID, map[string]any{
+		"bank_entry_id": req.BankEntryID,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"question": updated,
+	})
+}
+
+// LinkStory handles PUT /api/interview-questions/:id/story
+func (h *InterviewQuestionHandler) LinkStory(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid question ID"))
+		return
+	}
+
+	// Get question and verify ownership
+	question, err := h.questionRepo.GetInterviewQuestionByID(questionID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	_, err = h.interviewRepo.GetInterviewByID(question.InterviewID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req LinkStoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid request body"))
+		return
+	}
+
+	if err := h.checkStory(req.StoryID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	updated, err := h.questionRepo.UpdateInterviewQuestion(questionID, map[string]any{
+		"story_id": req.StoryID,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"question": updated,
+	})
+}
+
 // DeleteQuestion handles DELETE /api/interview-questions/:id
 func (h *InterviewQuestionHandler) DeleteQuestion(c *gin.Context) {
 	userID := c.MustGet("user_id").(uuid.UUID)
@@ -265,3 +414,45 @@ func (h *InterviewQuestionHandler) ReorderQuestions(c *gin.Context) {
 		"questions": questions,
 	})
 }
+
+// checkBankEntry verifies an optional bank entry belongs to the user
+func (h *InterviewQuestionHandler) checkBankEntry(bankEntryID *uuid.UUID, userID uuid.UUID) error {
+	if bankEntryID == nil {
+		return nil
+	}
+	_, err := h.bankRepo.GetByID(*bankEntryID, userID)
+	return err
+}
+
+// checkStory verifies an optional story belongs to the user
+func (h *InterviewQuestionHandler) checkStory(storyID *uuid.UUID, userID uuid.UUID) error {
+	if storyID == nil {
+		return nil
+	}
+	_, err := h.storyRepo.GetByID(*storyID, userID)
+	return err
+}
+
+// suggestBankEntries finds bank entries similar to each question that was
+// created without one, keyed by question ID. Questions with no likely match
+// get an empty list.
+func (h *InterviewQuestionHandler) suggestBankEntries(userID uuid.UUID, questions []*models.InterviewQuestion) (map[uuid.UUID][]questionbank.Suggestion, error) {
+	suggestions := make(map[uuid.UUID][]questionbank.Suggestion)
+
+	var candidates []questionbank.Candidate
+	for _, question := range questions {
+		if question.BankEntryID != nil {
+			continue
+		}
+		if candidates == nil {
+			var err error
+			candidates, err = h.bankRepo.ListCandidates(userID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		suggestions[question.ID] = questionbank.Suggest(question.QuestionText, candidates, questionBankSuggestionLimit, questionbank.DefaultThreshold)
+	}
+
+	return suggestions, nil
+}
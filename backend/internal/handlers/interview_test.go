@@ -41,7 +41,7 @@ func setupInterviewHandlerTest(t *testing.T) *interviewTestContext {
 		DB:        db.Database,
 		Sanitizer: services.NewSanitizerService(),
 	}
-	handler := NewInterviewHandler(appState)
+	handler := NewInterviewHandler(appState, 15*time.Minute)
 
 	userRepo := repository.NewUserRepository(db.Database)
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
@@ -76,6 +76,7 @@ func setupInterviewHandlerTest(t *testing.T) *interviewTestContext {
 
 	router.POST("/api/interviews", handler.CreateInterview)
 	router.GET("/api/interviews", handler.ListInterviews)
+	router.GET("/api/interviews/conflicts", handler.GetConflicts)
 	router.GET("/api/interviews/:id", handler.GetInterviewByID)
 	router.GET("/api/interviews/:id/details", handler.GetInterviewWithDetails)
 	router.PUT("/api/interviews/:id", handler.UpdateInterview)
@@ -684,5 +685,86 @@ func TestInterviewHandler(t *testing.T) {
 			assert.Equal(t, http.StatusBadRequest, w.Code)
 		})
 	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		date := time.Now().AddDate(0, 0, 30).Format("2006-01-02")
+		schedule := func(scheduledTime string) *httptest.ResponseRecorder {
+			payload := map[string]interface{}{
+				"application_id":   tc.applicationID.String(),
+				"interview_type":   "technical",
+				"scheduled_date":   date,
+				"scheduled_time":   scheduledTime,
+				"duration_minutes": 60,
+			}
+			jsonPayload, _ := json.Marshal(payload)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/interviews", bytes.NewBuffer(jsonPayload))
+			req.Header.Set("Content-Type", "application/json")
+			tc.router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+			return w
+		}
+
+		t.Run("CreateWarnsOnOverlap", func(t *testing.T) {
+			var resp map[string]interface{}
+			require.NoError(t, json.Unmarshal(schedule("10:00").Body.Bytes(), &resp))
+			assert.Nil(t, resp["warnings"])
+
+			resp = nil
+			require.NoError(t, json.Unmarshal(schedule("10:30").Body.Bytes(), &resp))
+			warnings := resp["warnings"].([]interface{})
+			assert.Len(t, warnings, 1)
+			assert.Contains(t, warnings[0], "Overlaps with Interview Test Co")
+		})
+
+		t.Run("ListsOverlaps", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/interviews/conflicts", nil)
+			tc.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			data := resp["data"].(map[string]interface{})
+			assert.Equal(t, float64(15), data["buffer_minutes"])
+
+			conflicts := data["conflicts"].([]interface{})
+			require.Len(t, conflicts, 1)
+			conflict := conflicts[0].(map[string]interface{})
+			first := conflict["first"].(map[string]interface{})
+			assert.Equal(t, "interview", first["kind"])
+		})
+
+		t.Run("ReadsInterviewTimesInTimezone", func(t *testing.T) {
+			berlin, err := time.LoadLocation("Europe/Berlin")
+			require.NoError(t, err)
+			day, err := time.ParseInLocation("2006-01-02", date, berlin)
+			require.NoError(t, err)
+
+			// Due during the 10:00 interview in Berlin, but hours away from
+			// 10:00 UTC
+			assessment := testutil.CreateTestAssessment(tc.userID, tc.applicationID, date, models.AssessmentStatusNotStarted)
+			assessment.DueDate = day.Add(10*time.Hour + 15*time.Minute)
+			assessment.DueTimezone = "Europe/Berlin"
+			_, err = repository.NewAssessmentRepository(tc.db.Database).CreateAssessment(assessment)
+			require.NoError(t, err)
+
+			countConflicts := func(url string) int {
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", url, nil)
+				tc.router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusOK, w.Code)
+
+				var resp map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				return len(resp["data"].(map[string]interface{})["conflicts"].([]interface{}))
+			}
+
+			assert.Equal(t, 1, countConflicts("/api/interviews/conflicts"))
+			assert.Equal(t, 3, countConflicts("/api/interviews/conflicts?timezone=Europe/Berlin"))
+		})
+	})
 }
 
@@ -6,6 +6,7 @@ import (
 	"ditto-backend/internal/utils"
 	"ditto-backend/pkg/errors"
 	"ditto-backend/pkg/response"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -25,6 +26,14 @@ type UpdateInterviewerRequest struct {
 	Role *string `json:"role"`
 }
 
+type UpdateThankYouRequest struct {
+	Sent bool `json:"sent"`
+	// SentAt is YYYY-MM-DD and defaults to today when Sent is true
+	SentAt   *string `json:"sent_at"`
+	Channel  *string `json:"channel" binding:"omitempty,oneof=email linkedin handwritten other"`
+	Template *string `json:"template" binding:"omitempty,max=10000"`
+}
+
 type InterviewerHandler struct {
 	interviewerRepo *repository.InterviewerRepository
 	interviewRepo   *repository.InterviewRepository
@@ -209,3 +218,74 @@ func (h *InterviewerHandler) DeleteInterviewer(c *gin.Context) {
 		"message": "interviewer deleted successfully",
 	})
 }
+
+// UpdateThankYou handles PUT /api/interviewers/:id/thank-you
+func (h *InterviewerHandler) UpdateThankYou(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	interviewerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid interviewer ID"))
+		return
+	}
+
+	// Get interviewer and verify ownership
+	interviewer, err := h.interviewerRepo.GetInterviewerByID(interviewerID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	_, err = h.interviewRepo.GetInterviewByID(interviewer.InterviewID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req UpdateThankYouRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	updates := map[string]any{
+		"thank_you_sent":    req.Sent,
+		"thank_you_sent_at": nil,
+		"updated_at":        time.Now(),
+	}
+	if req.Sent {
+		sentAt := time.Now()
+		if req.SentAt != nil && *req.SentAt != "" {
+			sentAt, err = time.Parse("2006-01-02", *req.SentAt)
+			if err != nil {
+				HandleError(c, errors.New(errors.ErrorBadRequest, "invalid sent_at format, use YYYY-MM-DD"))
+				return
+			}
+		}
+		updates["thank_you_sent_at"] = sentAt
+	}
+	if req.Channel != nil {
+		if *req.Channel == "" {
+			updates["thank_you_channel"] = nil
+		} else {
+			updates["thank_you_channel"] = *req.Channel
+		}
+	}
+	if req.Template != nil {
+		if *req.Template == "" {
+			updates["thank_you_template"] = nil
+		} else {
+			updates["thank_you_template"] = *req.Template
+		}
+	}
+
+	updated, err := h.interviewerRepo.UpdateInterviewer(interviewerID, updates)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"interviewer": updated,
+	})
+}
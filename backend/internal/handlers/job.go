@@ -37,6 +37,7 @@ type CreateJobRequest struct {
 	MinSalary      *float64   `json:"min_salary,omitempty"`
 	MaxSalary      *float64   `json:"max_salary,omitempty"`
 	Currency       *string    `json:"currency,omitempty"`
+	PayPeriod      *string    `json:"pay_period,omitempty" validate:"omitempty,oneof=hourly daily weekly monthly annual"`
 }
 
 type UpdateJobRequest struct {
@@ -47,6 +48,7 @@ type UpdateJobRequest struct {
 	MinSalary      *float64 `json:"min_salary,omitempty"`
 	MaxSalary      *float64 `json:"max_salary,omitempty"`
 	Currency       *string  `json:"currency,omitempty"`
+	PayPeriod      *string  `json:"pay_period,omitempty" validate:"omitempty,oneof=hourly daily weekly monthly annual"`
 	IsExpired      *bool    `json:"is_expired,omitempty"`
 }
 
@@ -134,6 +136,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		MinSalary:      req.MinSalary,
 		MaxSalary:      req.MaxSalary,
 		Currency:       req.Currency,
+		PayPeriod:      req.PayPeriod,
 	}
 
 	createdJob, err := h.jobRepo.CreateJob(userID, job)
@@ -193,6 +196,7 @@ func (h *JobHandler) UpdateJob(c *gin.Context) {
 		"min_salary":      req.MinSalary,
 		"max_salary":      req.MaxSalary,
 		"currency":        req.Currency,
+		"pay_period":      req.PayPeriod,
 	}
 
 	if req.CompanyID != nil {
@@ -268,6 +272,10 @@ func (h *JobHandler) PatchJob(c *gin.Context) {
 		updates["currency"] = *req.Currency
 	}
 
+	if req.PayPeriod != nil {
+		updates["pay_period"] = *req.PayPeriod
+	}
+
 	if req.IsExpired != nil {
 		updates["is_expired"] = *req.IsExpired
 	}
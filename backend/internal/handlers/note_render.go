@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// maxNoteFileReferences caps how many file links in one note are resolved to
+// download URLs.
+const maxNoteFileReferences = 50
+
+type RenderNoteRequest struct {
+	Content string `json:"content" binding:"max=50000"`
+	Format  string `json:"format" binding:"omitempty,oneof=html markdown"`
+}
+
+type NoteRenderHandler struct {
+	fileRepo  *repository.FileRepository
+	markdown  *services.MarkdownService
+	sanitizer *services.SanitizerService
+	s3Service s3service.S3ServiceInterface
+}
+
+func NewNoteRenderHandler(appState *utils.AppState, s3Service s3service.S3ServiceInterface) *NoteRenderHandler {
+	return &NoteRenderHandler{
+		fileRepo:  repository.NewFileRepository(appState.DB),
+		markdown:  appState.Markdown,
+		sanitizer: appState.Sanitizer,
+		s3Service: s3Service,
+	}
+}
+
+// POST /api/notes/render
+// Renders a note to sanitized HTML. In Markdown, links and images pointing at
+// file:<id> become presigned download URLs for the user's own files.
+func (h *NoteRenderHandler) RenderNote(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req RenderNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if req.Format != models.NoteFormatMarkdown {
+		response.Success(c, gin.H{
+			"html": h.sanitizer.SanitizeHTML(req.Content),
+		})
+		return
+	}
+
+	fileURLs, err := h.resolveFileURLs(c.Request.Context(), userID, h.markdown.FileReferences(req.Content))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	html, err := h.markdown.Render(req.Content, fileURLs)
+	if err != nil {
+		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to render note", err))
+		return
+	}
+
+	response.Success(c, gin.H{
+		"html": html,
+	})
+}
+
+// resolveFileURLs returns download URLs for the referenced files the user
+// owns. Files that do not exist or belong to someone else are left out.
+func (h *NoteRenderHandler) resolveFileURLs(ctx context.Context, userID uuid.UUID, fileIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	if len(fileIDs) > maxNoteFileReferences {
+		fileIDs = fileIDs[:maxNoteFileReferences]
+	}
+
+	urls := make(map[uuid.UUID]string, len(fileIDs))
+	for _, fileID := range fileIDs {
+		file, err := h.fileRepo.GetFileByID(fileID, userID)
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !file.IsClean() {
+			continue
+		}
+
+		url, err := h.s3Service.GeneratePresignedGetURL(ctx, file.S3Key)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrorInternalServer, "failed to generate download URL", err)
+		}
+		urls[fileID] = url
+	}
+
+	return urls, nil
+}
+
+// prepareNote readies note content for storage. HTML is sanitized now;
+// Markdown is stored as written and sanitized when it is rendered.
+func prepareNote(sanitizer *services.SanitizerService, content *string, format string) *string {
+	if content == nil || *content == "" || format == models.NoteFormatMarkdown {
+		return content
+	}
+	sanitized := sanitizer.SanitizeHTML(*content)
+	return &sanitized
+}
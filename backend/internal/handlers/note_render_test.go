@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services"
+)
+
+func TestPrepareNote(t *testing.T) {
+	sanitizer := services.NewSanitizerService()
+	content := "**Ask** about <script>alert(1)</script> on-call"
+
+	html := prepareNote(sanitizer, &content, models.NoteFormatHTML)
+	assert.NotContains(t, *html, "<script>")
+
+	html = prepareNote(sanitizer, &content, "")
+	assert.NotContains(t, *html, "<script>")
+
+	markdown := prepareNote(sanitizer, &content, models.NoteFormatMarkdown)
+	assert.Equal(t, content, *markdown)
+
+	assert.Nil(t, prepareNote(sanitizer, nil, models.NoteFormatHTML))
+}
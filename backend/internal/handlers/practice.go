@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+const (
+	defaultPracticeLimit = 20
+	maxPracticeLimit     = 100
+)
+
+type ReviewPracticeRequest struct {
+	// Grade is a self-assessment from 0 (blank) to 5 (perfect recall).
+	// A pointer so that 0 passes the required check.
+	Grade *int `json:"grade" binding:"required,min=0,max=5"`
+}
+
+type PracticeHandler struct {
+	practiceRepo *repository.PracticeRepository
+}
+
+func NewPracticeHandler(appState *utils.AppState) *PracticeHandler {
+	return &PracticeHandler{
+		practiceRepo: repository.NewPracticeRepository(appState.DB),
+	}
+}
+
+// GET /api/practice/due?limit=
+// Returns questions due for review now, creating cards for newly logged
+// questions first.
+func (h *PracticeHandler) GetDue(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	limit := defaultPracticeLimit
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsed, err := strconv.Atoi(limitParam); err == nil && parsed > 0 {
+			limit = min(parsed, maxPracticeLimit)
+		}
+	}
+
+	if err := h.practiceRepo.SyncCards(userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	now := time.Now()
+	items, err := h.practiceRepo.ListDue(userID, now, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if items == nil {
+		items = []*models.PracticeItem{}
+	}
+
+	totalDue, err := h.practiceRepo.CountDue(userID, now)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"items":     items,
+		"total_due": totalDue,
+	})
+}
+
+// GET /api/practice/:id
+func (h *PracticeHandler) GetCard(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	cardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid practice card ID"))
+		return
+	}
+
+	item, err := h.practiceRepo.GetItem(cardID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	reviews, err := h.practiceRepo.ListReviews(cardID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if reviews == nil {
+		reviews = []*models.PracticeReview{}
+	}
+
+	response.Success(c, gin.H{
+		"card":    item,
+		"reviews": reviews,
+	})
+}
+
+// POST /api/practice/:id/review
+func (h *PracticeHandler) ReviewCard(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	cardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid practice card ID"))
+		return
+	}
+
+	var req ReviewPracticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	review, err := h.practiceRepo.RecordReview(cardID, userID, *req.Grade, time.Now())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	item, err := h.practiceRepo.GetItem(cardID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"review": review,
+		"card":   item,
+	})
+}
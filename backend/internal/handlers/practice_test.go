@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewPracticeRequestGrade(t *testing.T) {
+	for _, grade := range []int{0, 3, 5} {
+		req := ReviewPracticeRequest{Grade: &grade}
+		assert.NoError(t, binding.Validator.ValidateStruct(req), grade)
+	}
+
+	invalid := 6
+	assert.Error(t, binding.Validator.ValidateStruct(ReviewPracticeRequest{Grade: &invalid}))
+	assert.Error(t, binding.Validator.ValidateStruct(ReviewPracticeRequest{}))
+}
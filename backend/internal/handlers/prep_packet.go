@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/preppacket"
+	"ditto-backend/pkg/errors"
+)
+
+var filenameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GET /api/interviews/:id/prep-packet?format=md|pdf
+// Downloads everything needed to prepare for an interview as one Markdown or
+// PDF document.
+func (h *InterviewHandler) GetPrepPacket(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	interviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid interview ID"))
+		return
+	}
+
+	format := c.DefaultQuery("format", preppacket.FormatMarkdown)
+	if format != preppacket.FormatMarkdown && format != preppacket.FormatPDF {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "format must be md or pdf"))
+		return
+	}
+
+	packet, err := h.buildPrepPacket(interviewID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", prepPacketFilename(packet, format)))
+
+	if format == preppacket.FormatMarkdown {
+		c.Data(200, "text/markdown; charset=utf-8", []byte(packet.Markdown()))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := packet.WritePDF(&buf); err != nil {
+		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to generate PDF", err))
+		return
+	}
+	c.Data(200, "application/pdf", buf.Bytes())
+}
+
+// prepPacketFilename names a packet after its company and round, falling back
+// to "company" when the name has nothing usable in a filename.
+func prepPacketFilename(packet *preppacket.Packet, format string) string {
+	company := strings.Trim(filenameUnsafe.ReplaceAllString(strings.ToLower(packet.CompanyName), "-"), "-")
+	if company == "" {
+		company = "company"
+	}
+	return fmt.Sprintf("prep-packet-%s-round-%d.%s", company, packet.Interview.Number, format)
+}
+
+func (h *InterviewHandler) buildPrepPacket(interviewID, userID uuid.UUID) (*preppacket.Packet, error) {
+	interview, err := h.interviewRepo.GetInterviewByID(interviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := h.applicationRepo.GetApplicationByIDWithDetails(interview.ApplicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	packet := &preppacket.Packet{GeneratedAt: time.Now()}
+	if app.Job != nil {
+		packet.JobTitle = app.Job.Title
+		packet.JobDescription = preppacket.NoteText(&app.Job.JobDescription, models.NoteFormatHTML)
+	}
+	if app.Company != nil {
+		packet.CompanyName = app.Company.Name
+
+		profile, err := h.profileRepo.GetByCompanyID(userID, app.Company.ID)
+		if err != nil {
+			return nil, err
+		}
+		packet.CompanyNotes = preppacket.NoteText(profile.Notes, models.NoteFormatHTML)
+	}
+
+	packet.Interview, err = h.prepPacketRound(interview)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := h.interviewNoteRepo.GetInterviewNotesByInterviewID(interviewID)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		packet.Notes = append(packet.Notes, preppacket.Note{
+			Type: note.NoteType,
+			Text: preppacket.NoteText(note.Content, note.ContentFormat),
+		})
+	}
+
+	rounds, err := h.interviewRepo.GetInterviewsByApplicationID(interview.ApplicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i].RoundNumber < rounds[j].RoundNumber })
+	for _, round := range rounds {
+		if round.RoundNumber >= interview.RoundNumber {
+			continue
+		}
+		previous, err := h.prepPacketRound(round)
+		if err != nil {
+			return nil, err
+		}
+		packet.PreviousRounds = append(packet.PreviousRounds, previous)
+	}
+
+	files, err := h.fileRepo.GetUserFiles(userID, &interview.ApplicationID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		packet.Files = append(packet.Files, preppacket.File{
+			Name: file.FileName,
+			Type: file.FileType,
+			Size: file.FileSize,
+		})
+	}
+
+	return packet, nil
+}
+
+// prepPacketRound loads an interview's panel, questions and feedback.
+func (h *InterviewHandler) prepPacketRound(interview *models.Interview) (preppacket.Round, error) {
+	round := preppacket.Round{
+		Number: interview.RoundNumber,
+		Type:   interview.InterviewType,
+		Date:   interview.ScheduledDate,
+		Status: interview.Status,
+	}
+	if interview.ScheduledTime != nil {
+		round.Time = *interview.ScheduledTime
+	}
+	if interview.DurationMinutes != nil {
+		round.DurationMinutes = *interview.DurationMinutes
+	}
+	if interview.Outcome != nil {
+		round.Outcome = *interview.Outcome
+	}
+
+	interviewers, err := h.interviewerRepo.GetInterviewerByInterview(interview.ID)
+	if err != nil {
+		return round, err
+	}
+	for _, interviewer := range interviewers {
+		person := preppacket.Person{Name: interviewer.Name}
+		if interviewer.Role != nil {
+			person.Role = *interviewer.Role
+		}
+		round.Interviewers = append(round.Interviewers, person)
+	}
+
+	questions, err := h.interviewQuestionRepo.GetInterviewQuestionByInterviewID(interview.ID)
+	if err != nil {
+		return round, err
+	}
+	for _, question := range questions {
+		round.Questions = append(round.Questions, preppacket.Question{
+			Text:   question.QuestionText,
+			Answer: preppacket.NoteText(question.AnswerText, models.NoteFormatHTML),
+		})
+	}
+
+	notes, err := h.interviewNoteRepo.GetInterviewNotesByInterviewID(interview.ID)
+	if err != nil {
+		return round, err
+	}
+	for _, note := range notes {
+		if note.NoteType == models.NoteTypeFeedback {
+			round.Feedback = preppacket.NoteText(note.Content, note.ContentFormat)
+		}
+	}
+
+	return round, nil
+}
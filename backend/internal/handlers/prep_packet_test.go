@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"testing"
+
+	"ditto-backend/internal/services/preppacket"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepPacketFilename(t *testing.T) {
+	tests := []struct {
+		company string
+		want    string
+	}{
+		{"Acme Corp.", "prep-packet-acme-corp-round-2.md"},
+		{"", "prep-packet-company-round-2.md"},
+		{"株式会社", "prep-packet-company-round-2.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.company, func(t *testing.T) {
+			packet := &preppacket.Packet{CompanyName: tt.company, Interview: preppacket.Round{Number: 2}}
+			assert.Equal(t, tt.want, prepPacketFilename(packet, preppacket.FormatMarkdown))
+		})
+	}
+}
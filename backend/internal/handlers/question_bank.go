@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/questionbank"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+// questionBankSuggestionLimit caps how many bank entries are suggested for a
+// single question.
+const questionBankSuggestionLimit = 3
+
+type CreateQuestionBankEntryRequest struct {
+	QuestionText string   `json:"question_text" binding:"required,max=2000"`
+	Topics       []string `json:"topics" binding:"omitempty,max=20,dive,max=50"`
+	Difficulty   *string  `json:"difficulty" binding:"omitempty,oneof=easy medium hard"`
+	ModelAnswer  *string  `json:"model_answer" binding:"omitempty,max=20000"`
+	// InterviewQuestionID promotes a logged question into the bank and links it
+	InterviewQuestionID *uuid.UUID `json:"interview_question_id"`
+}
+
+type UpdateQuestionBankEntryRequest struct {
+	QuestionText *string   `json:"question_text" binding:"omitempty,max=2000"`
+	Topics       *[]string `json:"topics" binding:"omitempty,max=20,dive,max=50"`
+	// An empty difficulty clears it
+	Difficulty  *string `json:"difficulty" binding:"omitempty,oneof=easy medium hard ''"`
+	ModelAnswer *string `json:"model_answer" binding:"omitempty,max=20000"`
+}
+
+type QuestionBankHandler struct {
+	bankRepo      *repository.QuestionBankRepository
+	questionRepo  *repository.InterviewQuestionRepository
+	interviewRepo *repository.InterviewRepository
+	sanitizer     *services.SanitizerService
+}
+
+func NewQuestionBankHandler(appState *utils.AppState) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		bankRepo:      repository.NewQuestionBankRepository(appState.DB),
+		questionRepo:  repository.NewInterviewQuestionRepository(appState.DB),
+		interviewRepo: repository.NewInterviewRepository(appState.DB),
+		sanitizer:     appState.Sanitizer,
+	}
+}
+
+// GET /api/question-bank?topic=&difficulty=&q=
+func (h *QuestionBankHandler) ListEntries(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	entries, err := h.bankRepo.List(userID, &repository.QuestionBankFilters{
+		Topic:      normalizeTopic(c.Query("topic")),
+		Difficulty: c.Query("difficulty"),
+		Search:     strings.TrimSpace(c.Query("q")),
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if entries == nil {
+		entries = []*models.QuestionBankEntryWithStats{}
+	}
+
+	response.Success(c, gin.H{
+		"entries": entries,
+	})
+}
+
+// GET /api/question-bank/suggest?text=
+// Returns bank entries that look like the given question text.
+func (h *QuestionBankHandler) SuggestEntries(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	text := strings.TrimSpace(c.Query("text"))
+	if text == "" {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "text is required"))
+		return
+	}
+
+	candidates, err := h.bankRepo.ListCandidates(userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"suggestions": questionbank.Suggest(text, candidates, questionBankSuggestionLimit, questionbank.DefaultThreshold),
+	})
+}
+
+// POST /api/question-bank
+func (h *QuestionBankHandler) CreateEntry(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req CreateQuestionBankEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	questionText := strings.TrimSpace(req.QuestionText)
+	if questionText == "" {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "question_text is required"))
+		return
+	}
+
+	// Check the question to promote before creating anything
+	if req.InterviewQuestionID != nil {
+		question, err := h.questionRepo.GetInterviewQuestionByID(*req.InterviewQuestionID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		if _, err := h.interviewRepo.GetInterviewByID(question.InterviewID, userID); err != nil {
+			HandleError(c, err)
+			return
+		}
+	}
+
+	entry := &models.QuestionBankEntry{
+		UserID:       userID,
+		QuestionText: questionText,
+		Topics:       normalizeTopics(req.Topics),
+		Difficulty:   req.Difficulty,
+	}
+	if req.ModelAnswer != nil && *req.ModelAnswer != "" {
+		sanitized := h.sanitizer.SanitizeHTML(*req.ModelAnswer)
+		entry.ModelAnswer = &sanitized
+	}
+
+	created, err := h.bankRepo.Create(entry)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if req.InterviewQuestionID != nil {
+		_, err := h.questionRepo.UpdateInterviewQuestion(*req.InterviewQuestionID, map[string]any{
+			"bank_entry_id": created.ID,
+		})
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+	}
+
+	entryWithStats, err := h.bankRepo.GetByID(created.ID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"entry": entryWithStats,
+	})
+}
+
+// GET /api/question-bank/:id
+// Returns the entry together with every interview it was asked in.
+func (h *QuestionBankHandler) GetEntry(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid question bank entry ID"))
+		return
+	}
+
+	entry, err := h.bankRepo.GetByID(entryID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	usages, err := h.bankRepo.GetUsages(entryID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if usages == nil {
+		usages = []*models.QuestionBankUsage{}
+	}
+
+	response.Success(c, gin.H{
+		"entry":  entry,
+		"usages": usages,
+	})
+}
+
+// PUT /api/question-bank/:id
+func (h *QuestionBankHandler) UpdateEntry(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid question bank entry ID"))
+		return
+	}
+
+	var req UpdateQuestionBankEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	updates := make(map[string]any)
+
+	if req.QuestionText != nil {
+		questionText := strings.TrimSpace(*req.QuestionText)
+		if questionText == "" {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "question_text cannot be empty"))
+			return
+		}
+		updates["question_text"] = questionText
+	}
+
+	if req.Topics != nil {
+		updates["topics"] = normalizeTopics(*req.Topics)
+	}
+
+	if req.Difficulty != nil {
+		if *req.Difficulty == "" {
+			updates["difficulty"] = nil
+		} else {
+			updates["difficulty"] = *req.Difficulty
+		}
+	}
+
+	if req.ModelAnswer != nil {
+		if *req.ModelAnswer == "" {
+			updates["model_answer"] = nil
+		} else {
+			updates["model_answer"] = h.sanitizer.SanitizeHTML(*req.ModelAnswer)
+		}
+	}
+
+	if len(updates) == 0 {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "no fields to update"))
+		return
+	}
+
+	entry, err := h.bankRepo.Update(entryID, userID, updates)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"entry": entry,
+	})
+}
+
+// DELETE /api/question-bank/:id
+// Interview questions linked to the entry are kept and unlinked.
+func (h *QuestionBankHandler) DeleteEntry(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid question bank entry ID"))
+		return
+	}
+
+	if err := h.bankRepo.SoftDelete(entryID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"message": "question bank entry deleted successfully",
+	})
+}
+
+// normalizeTopics lower-cases tags, joins words with hyphens and drops blanks
+// and duplicates, so "System Design" and "system-design" are one topic.
+func normalizeTopics(topics []string) pq.StringArray {
+	seen := make(map[string]bool, len(topics))
+	result := pq.StringArray{}
+	for _, topic := range topics {
+		topic = normalizeTopic(topic)
+		if topic == "" || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		result = append(result, topic)
+	}
+	return result
+}
+
+func normalizeTopic(topic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(topic)), "-")
+}
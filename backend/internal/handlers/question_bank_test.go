@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTopics(t *testing.T) {
+	assert.Equal(t, []string{"system-design", "go"}, []string(normalizeTopics([]string{" System  Design", "system-design", "", "Go"})))
+	assert.Empty(t, normalizeTopics(nil))
+}
+
+func TestUpdateQuestionBankEntryRequestDifficulty(t *testing.T) {
+	for _, difficulty := range []string{"", "easy", "hard"} {
+		req := UpdateQuestionBankEntryRequest{Difficulty: &difficulty}
+		assert.NoError(t, binding.Validator.ValidateStruct(req), difficulty)
+	}
+
+	invalid := "impossible"
+	req := UpdateQuestionBankEntryRequest{Difficulty: &invalid}
+	assert.Error(t, binding.Validator.ValidateStruct(req))
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/services/localstore"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/pkg/errors"
+)
+
+// StorageHandler serves the signed URLs handed out by the local storage
+// backend. It stands in for S3, so requests are authorized by the URL's
+// signature rather than a session.
+type StorageHandler struct {
+	store *localstore.Service
+}
+
+func NewStorageHandler(store *localstore.Service) *StorageHandler {
+	return &StorageHandler{store: store}
+}
+
+// PUT /api/storage/*key
+func (h *StorageHandler) Upload(c *gin.Context) {
+	key, ok := h.verify(c)
+	if !ok {
+		return
+	}
+
+	maxSize := uploadSizeLimit(key)
+	if _, err := h.store.Upload(key, c.Request.Body, maxSize); err != nil {
+		if stderrors.Is(err, localstore.ErrTooLarge) {
+			HandleError(c, errors.New(errors.ErrorPayloadTooLarge, fmt.Sprintf("file exceeds %dMB limit", maxSize/(1024*1024))))
+			return
+		}
+		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to store file", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GET /api/storage/*key
+func (h *StorageHandler) Download(c *gin.Context) {
+	key, ok := h.verify(c)
+	if !ok {
+		return
+	}
+
+	file, err := h.store.Open(key)
+	if err != nil {
+		if stderrors.Is(err, fs.ErrNotExist) {
+			HandleError(c, errors.New(errors.ErrorNotFound, "file not found"))
+			return
+		}
+		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to read file", err))
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		HandleError(c, errors.Wrap(errors.ErrorInternalServer, "failed to read file", err))
+		return
+	}
+
+	// The key's extension is chosen by the uploader, so never let it or the
+	// content decide how a browser treats the file.
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
+
+// uploadSizeLimit is the limit the presign step chose for a key: the larger
+// assessment limit only for keys issued for assessments.
+func uploadSizeLimit(key string) int64 {
+	owner, _, _ := strings.Cut(key, "/")
+	if userID, err := uuid.Parse(owner); err == nil && s3service.IsAssessmentKey(key, userID) {
+		return MaxAssessmentFileSize
+	}
+	return MaxFileSize
+}
+
+func (h *StorageHandler) verify(c *gin.Context) (string, bool) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	err := h.store.Verify(c.Request.Method, key, c.Query("expires"), c.Query("signature"))
+	switch {
+	case stderrors.Is(err, localstore.ErrURLExpired):
+		HandleError(c, errors.New(errors.ErrorExpired, "URL has expired"))
+		return "", false
+	case err != nil:
+		HandleError(c, errors.New(errors.ErrorForbidden, "invalid signature"))
+		return "", false
+	}
+
+	return key, true
+}
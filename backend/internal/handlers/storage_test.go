@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"ditto-backend/internal/services/localstore"
+	s3service "ditto-backend/internal/services/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store, err := localstore.NewService(localstore.Config{
+		Root:      t.TempDir(),
+		Secret:    "test-secret",
+		URLExpiry: PresignedURLExpiry,
+	})
+	require.NoError(t, err)
+
+	handler := NewStorageHandler(store)
+	router := gin.New()
+	router.GET(localstore.RoutePrefix+"/*key", handler.Download)
+	router.PUT(localstore.RoutePrefix+"/*key", handler.Upload)
+
+	ctx := context.Background()
+	key := "user/report.txt"
+
+	send := func(method, target, body string) *httptest.ResponseRecorder {
+		parsed, err := url.Parse(target)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, parsed.RequestURI(), strings.NewReader(body))
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Upload and download", func(t *testing.T) {
+		putURL, err := store.GeneratePresignedPutURL(ctx, key, "text/plain")
+		require.NoError(t, err)
+
+		w := send("PUT", putURL, "hello")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		getURL, err := store.GeneratePresignedGetURL(ctx, key)
+		require.NoError(t, err)
+
+		w = send("GET", getURL, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "hello", w.Body.String())
+		assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=report.txt", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	})
+
+	t.Run("HTML is downloaded, not rendered", func(t *testing.T) {
+		htmlKey := "user/page.html"
+		putURL, err := store.GeneratePresignedPutURL(ctx, htmlKey, "text/plain")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, send("PUT", putURL, "<b>hi</b>").Code)
+
+		getURL, err := store.GeneratePresignedGetURL(ctx, htmlKey)
+		require.NoError(t, err)
+
+		w := send("GET", getURL, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment"))
+	})
+
+	t.Run("GET URL can't upload", func(t *testing.T) {
+		getURL, err := store.GeneratePresignedGetURL(ctx, key)
+		require.NoError(t, err)
+
+		w := send("PUT", getURL, "overwrite")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Unsigned request", func(t *testing.T) {
+		w := send("GET", localstore.RoutePrefix+"/"+key, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		putURL, err := store.GeneratePresignedPutURL(ctx, "user/big.txt", "text/plain")
+		require.NoError(t, err)
+
+		w := send("PUT", putURL, strings.Repeat("x", MaxFileSize+1))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "5MB")
+
+		exists, err := store.HeadObject(ctx, "user/big.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Assessment keys allow the larger limit", func(t *testing.T) {
+		assessmentKey := s3service.GenerateAssessmentS3Key(uuid.New(), "solution.zip")
+		putURL, err := store.GeneratePresignedPutURL(ctx, assessmentKey, "application/zip")
+		require.NoError(t, err)
+
+		w := send("PUT", putURL, strings.Repeat("x", MaxFileSize+1))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		getURL, err := store.GeneratePresignedGetURL(ctx, "user/missing.txt")
+		require.NoError(t, err)
+
+		w := send("GET", getURL, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
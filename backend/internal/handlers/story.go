@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/utils"
+	"ditto-backend/pkg/errors"
+	"ditto-backend/pkg/response"
+)
+
+type CreateStoryRequest struct {
+	Title        string   `json:"title" binding:"required,max=255"`
+	Situation    *string  `json:"situation" binding:"omitempty,max=20000"`
+	Task         *string  `json:"task" binding:"omitempty,max=20000"`
+	Action       *string  `json:"action" binding:"omitempty,max=20000"`
+	Result       *string  `json:"result" binding:"omitempty,max=20000"`
+	Competencies []string `json:"competencies" binding:"omitempty,max=20,dive,max=50"`
+}
+
+type UpdateStoryRequest struct {
+	Title        *string   `json:"title" binding:"omitempty,max=255"`
+	Situation    *string   `json:"situation" binding:"omitempty,max=20000"`
+	Task         *string   `json:"task" binding:"omitempty,max=20000"`
+	Action       *string   `json:"action" binding:"omitempty,max=20000"`
+	Result       *string   `json:"result" binding:"omitempty,max=20000"`
+	Competencies *[]string `json:"competencies" binding:"omitempty,max=20,dive,max=50"`
+}
+
+// StoryReportEntry is a story and every time it was told
+type StoryReportEntry struct {
+	*models.StoryWithStats
+	Usages []*models.StoryUsage `json:"usages"`
+}
+
+// StoryConflict flags a story already told to the company or to one of the
+// interviewers of an upcoming interview. SharedInterviewers lists the
+// interviewers who heard it before.
+type StoryConflict struct {
+	StoryID            uuid.UUID          `json:"story_id"`
+	StoryTitle         string             `json:"story_title"`
+	SameCompany        bool               `json:"same_company"`
+	SharedInterviewers []string           `json:"shared_interviewers"`
+	PreviousUsage      *models.StoryUsage `json:"previous_usage"`
+}
+
+type StoryHandler struct {
+	storyRepo *repository.StoryRepository
+	sanitizer *services.SanitizerService
+}
+
+func NewStoryHandler(appState *utils.AppState) *StoryHandler {
+	return &StoryHandler{
+		storyRepo: repository.NewStoryRepository(appState.DB),
+		sanitizer: appState.Sanitizer,
+	}
+}
+
+// GET /api/stories?competency=
+func (h *StoryHandler) ListStories(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	stories, err := h.storyRepo.List(userID, normalizeTopic(c.Query("competency")))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if stories == nil {
+		stories = []*models.StoryWithStats{}
+	}
+
+	response.Success(c, gin.H{
+		"stories": stories,
+	})
+}
+
+// POST /api/stories
+func (h *StoryHandler) CreateStory(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var req CreateStoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "title is required"))
+		return
+	}
+
+	story := &models.Story{
+		UserID:       userID,
+		Title:        title,
+		Situation:    h.sanitizeOptional(req.Situation),
+		Task:         h.sanitizeOptional(req.Task),
+		Action:       h.sanitizeOptional(req.Action),
+		Result:       h.sanitizeOptional(req.Result),
+		Competencies: normalizeTopics(req.Competencies),
+	}
+
+	created, err := h.storyRepo.Create(story)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"story": created,
+	})
+}
+
+// GET /api/stories/:id
+// Returns the story together with every interview it was told in.
+func (h *StoryHandler) GetStory(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	storyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid story ID"))
+		return
+	}
+
+	story, err := h.storyRepo.GetByID(storyID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	usages, err := h.storyRepo.GetUsages(userID, &storyID, nil)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if usages == nil {
+		usages = []*models.StoryUsage{}
+	}
+
+	response.Success(c, gin.H{
+		"story":  story,
+		"usages": usages,
+	})
+}
+
+// PUT /api/stories/:id
+func (h *StoryHandler) UpdateStory(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	storyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid story ID"))
+		return
+	}
+
+	var req UpdateStoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	updates := make(map[string]any)
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "title cannot be empty"))
+			return
+		}
+		updates["title"] = title
+	}
+
+	// Empty STAR sections are cleared
+	for field, value := range map[string]*string{
+		"situation": req.Situation,
+		"task":      req.Task,
+		"action":    req.Action,
+		"result":    req.Result,
+	} {
+		if value != nil {
+			updates[field] = h.sanitizeOptional(value)
+		}
+	}
+
+	if req.Competencies != nil {
+		updates["competencies"] = normalizeTopics(*req.Competencies)
+	}
+
+	if len(updates) == 0 {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "no fields to update"))
+		return
+	}
+
+	story, err := h.storyRepo.Update(storyID, userID, updates)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"story": story,
+	})
+}
+
+// DELETE /api/stories/:id
+// Interview questions answered with the story are kept and unlinked.
+func (h *StoryHandler) DeleteStory(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	storyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		HandleError(c, errors.New(errors.ErrorBadRequest, "invalid story ID"))
+		return
+	}
+
+	if err := h.storyRepo.SoftDelete(storyID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"message": "story deleted successfully",
+	})
+}
+
+// GET /api/stories/report?company_id=&interview_id=
+// Lists which stories were told to which company and interviewers. With
+// interview_id, also flags stories that company or those interviewers have
+// already heard.
+func (h *StoryHandler) GetStoryReport(c *gin.Context) {
+	userID := c.MustGet("user_id").(uuid.UUID)
+
+	var companyID *uuid.UUID
+	if param := c.Query("company_id"); param != "" {
+		parsed, err := uuid.Parse(param)
+		if err != nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid company ID"))
+			return
+		}
+		companyID = &parsed
+	}
+
+	var audience *models.StoryAudience
+	if param := c.Query("interview_id"); param != "" {
+		interviewID, err := uuid.Parse(param)
+		if err != nil {
+			HandleError(c, errors.New(errors.ErrorBadRequest, "invalid interview ID"))
+			return
+		}
+		audience, err = h.storyRepo.GetAudience(interviewID, userID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+	}
+
+	stories, err := h.storyRepo.List(userID, "")
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	usages, err := h.storyRepo.GetUsages(userID, nil, companyID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	result := gin.H{
+		"stories": buildStoryReport(stories, usages),
+	}
+
+	if audience != nil {
+		// Conflicts look across every company, not just the filtered one
+		allUsages := usages
+		if companyID != nil {
+			allUsages, err = h.storyRepo.GetUsages(userID, nil, nil)
+			if err != nil {
+				HandleError(c, err)
+				return
+			}
+		}
+		result["audience"] = audience
+		result["conflicts"] = findStoryConflicts(audience, stories, allUsages)
+	}
+
+	response.Success(c, result)
+}
+
+func (h *StoryHandler) sanitizeOptional(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	sanitized := h.sanitizer.SanitizeHTML(*value)
+	return &sanitized
+}
+
+// buildStoryReport groups usages under their stories, leaving out stories
+// that were never told.
+func buildStoryReport(stories []*models.StoryWithStats, usages []*models.StoryUsage) []*StoryReportEntry {
+	byStory := make(map[uuid.UUID][]*models.StoryUsage)
+	for _, usage := range usages {
+		byStory[usage.StoryID] = append(byStory[usage.StoryID], usage)
+	}
+
+	report := []*StoryReportEntry{}
+	for _, story := range stories {
+		if storyUsages, ok := byStory[story.ID]; ok {
+			report = append(report, &StoryReportEntry{StoryWithStats: story, Usages: storyUsages})
+		}
+	}
+	return report
+}
+
+// findStoryConflicts returns earlier tellings of a story to the audience's
+// company or to any of its interviewers. Usages in the audience's own
+// interview are not conflicts.
+func findStoryConflicts(audience *models.StoryAudience, stories []*models.StoryWithStats, usages []*models.StoryUsage) []*StoryConflict {
+	titles := make(map[uuid.UUID]string, len(stories))
+	for _, story := range stories {
+		titles[story.ID] = story.Title
+	}
+
+	interviewers := make(map[string]bool, len(audience.Interviewers))
+	for _, name := range audience.Interviewers {
+		interviewers[normalizePersonName(name)] = true
+	}
+
+	conflicts := []*StoryConflict{}
+	for _, usage := range usages {
+		if usage.InterviewID == audience.InterviewID {
+			continue
+		}
+
+		shared := []string{}
+		for _, name := range usage.Interviewers {
+			if interviewers[normalizePersonName(name)] {
+				shared = append(shared, name)
+			}
+		}
+
+		sameCompany := usage.CompanyID == audience.CompanyID
+		if !sameCompany && len(shared) == 0 {
+			continue
+		}
+
+		conflicts = append(conflicts, &StoryConflict{
+			StoryID:            usage.StoryID,
+			StoryTitle:         titles[usage.StoryID],
+			SameCompany:        sameCompany,
+			SharedInterviewers: shared,
+			PreviousUsage:      usage,
+		})
+	}
+	return conflicts
+}
+
+func normalizePersonName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
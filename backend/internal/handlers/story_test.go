@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"ditto-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindStoryConflicts(t *testing.T) {
+	companyID := uuid.New()
+	otherCompanyID := uuid.New()
+	upcomingID := uuid.New()
+
+	outage := &models.StoryWithStats{Story: models.Story{ID: uuid.New(), Title: "Payments outage"}}
+	mentoring := &models.StoryWithStats{Story: models.Story{ID: uuid.New(), Title: "Mentoring a junior"}}
+	launch := &models.StoryWithStats{Story: models.Story{ID: uuid.New(), Title: "Late launch"}}
+
+	usages := []*models.StoryUsage{
+		// Told to this company in an earlier round
+		{StoryID: outage.ID, InterviewID: uuid.New(), CompanyID: companyID, Interviewers: pq.StringArray{"Sam Lee"}},
+		// Told elsewhere, but to someone on the upcoming panel
+		{StoryID: mentoring.ID, InterviewID: uuid.New(), CompanyID: otherCompanyID, Interviewers: pq.StringArray{"Priya  Patel", "Alex Kim"}},
+		// Told elsewhere to someone else
+		{StoryID: launch.ID, InterviewID: uuid.New(), CompanyID: otherCompanyID, Interviewers: pq.StringArray{"Jo Park"}},
+		// Already linked in the upcoming interview itself
+		{StoryID: launch.ID, InterviewID: upcomingID, CompanyID: companyID, ScheduledDate: time.Now()},
+	}
+
+	audience := &models.StoryAudience{
+		InterviewID:  upcomingID,
+		CompanyID:    companyID,
+		Interviewers: pq.StringArray{"priya patel", "Morgan Diaz"},
+	}
+
+	conflicts := findStoryConflicts(audience, []*models.StoryWithStats{outage, mentoring, launch}, usages)
+	require.Len(t, conflicts, 2)
+
+	assert.Equal(t, "Payments outage", conflicts[0].StoryTitle)
+	assert.True(t, conflicts[0].SameCompany)
+	assert.Empty(t, conflicts[0].SharedInterviewers)
+
+	assert.Equal(t, "Mentoring a junior", conflicts[1].StoryTitle)
+	assert.False(t, conflicts[1].SameCompany)
+	assert.Equal(t, []string{"Priya  Patel"}, conflicts[1].SharedInterviewers)
+}
+
+func TestBuildStoryReport(t *testing.T) {
+	told := &models.StoryWithStats{Story: models.Story{ID: uuid.New()}}
+	untold := &models.StoryWithStats{Story: models.Story{ID: uuid.New()}}
+
+	report := buildStoryReport(
+		[]*models.StoryWithStats{told, untold},
+		[]*models.StoryUsage{{StoryID: told.ID}, {StoryID: told.ID}},
+	)
+
+	require.Len(t, report, 1)
+	assert.Equal(t, told.ID, report[0].ID)
+	assert.Len(t, report[0].Usages, 2)
+}
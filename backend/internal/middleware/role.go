@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/repository"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequireRole only lets through users who have been granted role. It must run
+// after AuthMiddleware.
+func RequireRole(db *database.Database, role string) gin.HandlerFunc {
+	userRepo := repository.NewUserRepository(db)
+
+	return func(c *gin.Context) {
+		userID, ok := c.MustGet("user_id").(uuid.UUID)
+		if !ok {
+			handlers.HandleError(c, errors.NewUnauthorized())
+			c.Abort()
+			return
+		}
+
+		hasRole, err := userRepo.HasRole(userID, role)
+		if err != nil {
+			handlers.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !hasRole {
+			handlers.HandleError(c, errors.New(errors.ErrorForbidden, role+" access required"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/testutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := repository.NewUserRepository(db.Database)
+	admin, err := userRepo.CreateUser("admin@example.com", "Admin", "hash")
+	require.NoError(t, err)
+	member, err := userRepo.CreateUser("member@example.com", "Member", "hash")
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, id FROM roles WHERE name = $2
+	`, admin.ID, models.RoleAdmin)
+	require.NoError(t, err)
+
+	request := func(userID uuid.UUID) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.POST("/merge", RequireRole(db.Database, models.RoleAdmin), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/merge", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request(admin.ID))
+	assert.Equal(t, http.StatusForbidden, request(member.ID))
+}
@@ -22,6 +22,7 @@ type Application struct {
 	OfferReceived       bool       `json:"offer_received" db:"offer_received"`
 	AttemptNumber       int        `json:"attempt_number" db:"attempt_number" validate:"min=1"`
 	Notes               *string    `json:"notes,omitempty" db:"notes"`
+	NotesFormat         string     `json:"notes_format" db:"notes_format"`
 	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt           *time.Time `json:"-" db:"deleted_at"`
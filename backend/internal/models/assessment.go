@@ -35,7 +35,8 @@ type Assessment struct {
 	ApplicationID  uuid.UUID  `json:"application_id" db:"application_id" validate:"required"`
 	AssessmentType string     `json:"assessment_type" db:"assessment_type" validate:"required,max=50"`
 	Title          string     `json:"title" db:"title" validate:"required,max=255"`
-	DueDate        string     `json:"due_date" db:"due_date" validate:"required"`
+	DueDate        time.Time  `json:"due_date" db:"due_date" validate:"required"`
+	DueTimezone    string     `json:"due_timezone" db:"due_timezone"`
 	Status         string     `json:"status" db:"status"`
 	Instructions   *string    `json:"instructions,omitempty" db:"instructions"`
 	Requirements   *string    `json:"requirements,omitempty" db:"requirements"`
@@ -48,6 +49,19 @@ func (a *Assessment) IsDeleted() bool {
 	return a.DeletedAt != nil
 }
 
+// DueLocation is the time zone the deadline was set in, or UTC when it is
+// missing or unknown.
+func (a *Assessment) DueLocation() *time.Location {
+	if a.DueTimezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(a.DueTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 type AssessmentSubmission struct {
 	ID             uuid.UUID  `json:"id" db:"id"`
 	AssessmentID   uuid.UUID  `json:"assessment_id" db:"assessment_id" validate:"required"`
@@ -55,11 +69,38 @@ type AssessmentSubmission struct {
 	GithubURL      *string    `json:"github_url,omitempty" db:"github_url"`
 	FileID         *uuid.UUID `json:"file_id,omitempty" db:"file_id"`
 	Notes          *string    `json:"notes,omitempty" db:"notes"`
-	SubmittedAt    time.Time  `json:"submitted_at" db:"submitted_at"`
-	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
-	DeletedAt      *time.Time `json:"-" db:"deleted_at"`
+	NotesFormat    string     `json:"notes_format" db:"notes_format"`
+	// The GitHub fields record the repository as it was when submitted. They
+	// are nil when GitHub couldn't be reached to verify it.
+	GithubCommitSHA  *string    `json:"github_commit_sha,omitempty" db:"github_commit_sha"`
+	GithubRef        *string    `json:"github_ref,omitempty" db:"github_ref"`
+	GithubPrivate    *bool      `json:"github_private,omitempty" db:"github_private"`
+	GithubVerifiedAt *time.Time `json:"github_verified_at,omitempty" db:"github_verified_at"`
+	ArchiveFileID    *uuid.UUID `json:"archive_file_id,omitempty" db:"archive_file_id"`
+	SubmittedAt      time.Time  `json:"submitted_at" db:"submitted_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	DeletedAt        *time.Time `json:"-" db:"deleted_at"`
 }
 
 func (s *AssessmentSubmission) IsDeleted() bool {
 	return s.DeletedAt != nil
 }
+
+// AssessmentTimeSession is a stretch of time spent on an assessment. EndedAt
+// is nil while the timer is running; DurationSeconds then counts up to now.
+type AssessmentTimeSession struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	AssessmentID    uuid.UUID  `json:"assessment_id" db:"assessment_id"`
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	StartedAt       time.Time  `json:"started_at" db:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+	IsManual        bool       `json:"is_manual" db:"is_manual"`
+	Note            *string    `json:"note,omitempty" db:"note"`
+	DurationSeconds int64      `json:"duration_seconds" db:"duration_seconds"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsRunning reports whether the session's timer hasn't been stopped.
+func (s *AssessmentTimeSession) IsRunning() bool {
+	return s.EndedAt == nil
+}
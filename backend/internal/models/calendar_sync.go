@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CalendarItemInterview  = "interview"
+	CalendarItemAssessment = "assessment"
+)
+
+const (
+	CalendarSyncStatusSynced        = "synced"
+	CalendarSyncStatusConflict      = "conflict"
+	CalendarSyncStatusRemoteDeleted = "remote_deleted"
+)
+
+// CalendarSyncAccount is the CalDAV collection a user's interviews and
+// assessment deadlines are mirrored into. Timezone is how Ditto's wall-clock
+// interview times are read when they are turned into calendar events.
+type CalendarSyncAccount struct {
+	UserID            uuid.UUID  `json:"user_id" db:"user_id"`
+	CollectionURL     string     `json:"collection_url" db:"collection_url"`
+	Username          string     `json:"username" db:"username"`
+	PasswordEncrypted string     `json:"-" db:"password_encrypted"`
+	Timezone          string     `json:"timezone" db:"timezone"`
+	Enabled           bool       `json:"enabled" db:"enabled"`
+	LastSyncedAt      *time.Time `json:"last_synced_at,omitempty" db:"last_synced_at"`
+	LastError         *string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CalendarSyncItem records the calendar resource an interview or assessment
+// was written to, its ETag when last seen and a hash of what was pushed.
+type CalendarSyncItem struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	ItemType       string    `json:"item_type" db:"item_type"`
+	ItemID         uuid.UUID `json:"item_id" db:"item_id"`
+	Href           string    `json:"href" db:"href"`
+	ETag           *string   `json:"etag,omitempty" db:"etag"`
+	LocalHash      string    `json:"-" db:"local_hash"`
+	Status         string    `json:"status" db:"status"`
+	ConflictDetail *string   `json:"conflict_detail,omitempty" db:"conflict_detail"`
+	LastSyncedAt   time.Time `json:"last_synced_at" db:"last_synced_at"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type Company struct {
@@ -46,4 +47,50 @@ type CompanySuggestion struct {
 	LogoURL *string    `json:"logo_url,omitempty"`
 	Website *string    `json:"website,omitempty"`
 	Source  string     `json:"source"`
+
+	// From the requesting user's private company profile, when they have one
+	IsTarget   bool `json:"is_target,omitempty"`
+	Priority   *int `json:"priority,omitempty"`
+	DoNotApply bool `json:"do_not_apply,omitempty"`
+}
+
+const (
+	FundingStageBootstrapped = "bootstrapped"
+	FundingStagePreSeed      = "pre_seed"
+	FundingStageSeed         = "seed"
+	FundingStageSeriesA      = "series_a"
+	FundingStageSeriesB      = "series_b"
+	FundingStageSeriesC      = "series_c"
+	FundingStageSeriesDPlus  = "series_d_plus"
+	FundingStagePublic       = "public"
+	FundingStageAcquired     = "acquired"
+)
+
+// UserCompanyProfile is one user's private research on a company. Unlike
+// Company it is never shared with other users.
+type UserCompanyProfile struct {
+	UserID        uuid.UUID      `json:"user_id" db:"user_id"`
+	CompanyID     uuid.UUID      `json:"company_id" db:"company_id"`
+	Notes         *string        `json:"notes,omitempty" db:"notes"`
+	IsTarget      bool           `json:"is_target" db:"is_target"`
+	Priority      *int           `json:"priority,omitempty" db:"priority"`
+	CultureRating *int           `json:"culture_rating,omitempty" db:"culture_rating"`
+	TechStack     pq.StringArray `json:"tech_stack" db:"tech_stack"`
+	Headcount     *int           `json:"headcount,omitempty" db:"headcount"`
+	FundingStage  *string        `json:"funding_stage,omitempty" db:"funding_stage"`
+	DoNotApply    bool           `json:"do_not_apply" db:"do_not_apply"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultUserCompanyProfile is returned when the user has not saved anything
+// about a company yet.
+func DefaultUserCompanyProfile(userID, companyID uuid.UUID) *UserCompanyProfile {
+	return &UserCompanyProfile{
+		UserID:    userID,
+		CompanyID: companyID,
+		TechStack: pq.StringArray{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
 }
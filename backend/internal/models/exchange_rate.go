@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const BaseCurrency = "USD"
+
+// ExchangeRate is the value of one unit of Currency in USD from EffectiveDate
+// until the next rate for the same currency takes effect.
+type ExchangeRate struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	Currency      string    `json:"currency" db:"currency"`
+	RateToUSD     float64   `json:"rate_to_usd" db:"rate_to_usd"`
+	EffectiveDate time.Time `json:"effective_date" db:"effective_date"`
+	Source        *string   `json:"source,omitempty" db:"source"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -6,6 +6,15 @@ import (
 	"github.com/google/uuid"
 )
 
+// Malware scan states. Files can't be downloaded until they are clean.
+// Failed files could not be scanned at all.
+const (
+	FileScanPending  = "pending"
+	FileScanClean    = "clean"
+	FileScanInfected = "infected"
+	FileScanFailed   = "failed"
+)
+
 type File struct {
 	ID            uuid.UUID  `json:"id" db:"id"`
 	UserID        uuid.UUID  `json:"user_id" db:"user_id" validate:"required"`
@@ -15,6 +24,9 @@ type File struct {
 	FileType      string     `json:"file_type" db:"file_type" validate:"required,max=50"`
 	FileSize      int64      `json:"file_size" db:"file_size" validate:"required,min=1"`
 	S3Key         string     `json:"s3_key" db:"s3_key" validate:"required,max=500"`
+	ScanStatus    string     `json:"scan_status" db:"scan_status"`
+	ScanSignature *string    `json:"scan_signature,omitempty" db:"scan_signature"`
+	ScannedAt     *time.Time `json:"scanned_at,omitempty" db:"scanned_at"`
 	UploadedAt    time.Time  `json:"uploaded_at" db:"uploaded_at"`
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
@@ -25,6 +37,11 @@ func (f *File) IsDeleted() bool {
 	return f.DeletedAt != nil
 }
 
+// IsClean reports whether the file has passed its malware scan.
+func (f *File) IsClean() bool {
+	return f.ScanStatus == FileScanClean
+}
+
 func (f *File) BelongsToInterview() bool {
 	return f.InterviewID != nil
 }
@@ -23,6 +23,25 @@ const (
 	NoteTypeGeneral         = "general"
 )
 
+const (
+	InterviewStatusScheduled = "scheduled"
+	InterviewStatusCompleted = "completed"
+	InterviewStatusCancelled = "cancelled"
+	InterviewStatusNoShow    = "no_show"
+)
+
+const (
+	InterviewEventRescheduled = "rescheduled"
+	InterviewEventCancelled   = "cancelled"
+	InterviewEventNoShow      = "no_show"
+)
+
+// Who asked for a reschedule or cancellation, or who failed to turn up.
+const (
+	InitiatedByMe      = "me"
+	InitiatedByCompany = "company"
+)
+
 type Interview struct {
 	ID              uuid.UUID  `json:"id" db:"id"`
 	UserID          uuid.UUID  `json:"user_id" db:"user_id" validate:"required"`
@@ -38,6 +57,9 @@ type Interview struct {
 	ConfidenceLevel *int       `json:"confidence_level,omitempty" db:"confidence_level"`
 	InterviewType   string     `json:"interview_type" db:"interview_type" validate:"required,max=50"`
 	Status          string     `json:"status" db:"status"`
+	Location        *string    `json:"location,omitempty" db:"location"`
+	MeetingLink     *string    `json:"meeting_link,omitempty" db:"meeting_link"`
+	ICSUID          *string    `json:"-" db:"ics_uid"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt       *time.Time `json:"-" db:"deleted_at"`
@@ -48,14 +70,25 @@ func (i *Interview) IsDeleted() bool {
 	return i.DeletedAt != nil
 }
 
+const (
+	ThankYouChannelEmail       = "email"
+	ThankYouChannelLinkedIn    = "linkedin"
+	ThankYouChannelHandwritten = "handwritten"
+	ThankYouChannelOther       = "other"
+)
+
 type Interviewer struct {
-	ID          uuid.UUID  `json:"id" db:"id"`
-	InterviewID uuid.UUID  `json:"interview_id" db:"interview_id" validate:"required"`
-	Name        string     `json:"name" db:"name"`
-	Role        *string    `json:"role,omitempty" db:"role"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *time.Time `json:"-" db:"deleted_at"`
+	ID               uuid.UUID  `json:"id" db:"id"`
+	InterviewID      uuid.UUID  `json:"interview_id" db:"interview_id" validate:"required"`
+	Name             string     `json:"name" db:"name"`
+	Role             *string    `json:"role,omitempty" db:"role"`
+	ThankYouSent     bool       `json:"thank_you_sent" db:"thank_you_sent"`
+	ThankYouSentAt   *time.Time `json:"thank_you_sent_at,omitempty" db:"thank_you_sent_at"`
+	ThankYouChannel  *string    `json:"thank_you_channel,omitempty" db:"thank_you_channel"`
+	ThankYouTemplate *string    `json:"thank_you_template,omitempty" db:"thank_you_template"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt        *time.Time `json:"-" db:"deleted_at"`
 }
 
 type InterviewQuestion struct {
@@ -64,6 +97,8 @@ type InterviewQuestion struct {
 	QuestionText string     `json:"question_text" db:"question_text"`
 	AnswerText   *string    `json:"answer_text,omitempty" db:"answer_text"`
 	Order        int        `json:"order" db:"order"`
+	BankEntryID  *uuid.UUID `json:"bank_entry_id,omitempty" db:"bank_entry_id"`
+	StoryID      *uuid.UUID `json:"story_id,omitempty" db:"story_id"`
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt    *time.Time `json:"-" db:"deleted_at"`
@@ -74,19 +109,52 @@ func (q *InterviewQuestion) IsDeleted() bool {
 }
 
 type InterviewNote struct {
-	ID          uuid.UUID  `json:"id" db:"id"`
-	InterviewID uuid.UUID  `json:"interview_id" db:"interview_id" validate:"required"`
-	NoteType    string     `json:"note_type" db:"note_type"`
-	Content     *string    `json:"content,omitempty" db:"content"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *time.Time `json:"-" db:"deleted_at"`
+	ID            uuid.UUID  `json:"id" db:"id"`
+	InterviewID   uuid.UUID  `json:"interview_id" db:"interview_id" validate:"required"`
+	NoteType      string     `json:"note_type" db:"note_type"`
+	Content       *string    `json:"content,omitempty" db:"content"`
+	ContentFormat string     `json:"content_format" db:"content_format"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt     *time.Time `json:"-" db:"deleted_at"`
 }
 
 func (n *InterviewNote) IsDeleted() bool {
 	return n.DeletedAt != nil
 }
 
+// InterviewNoteRevision is the content of a note as it was saved at one point.
+// ContentHash is the hex SHA-256 of the content and ContentSize its length in
+// bytes. A note without content hashes as the empty string.
+type InterviewNoteRevision struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	NoteID         uuid.UUID  `json:"note_id" db:"note_id"`
+	RevisionNumber int        `json:"revision_number" db:"revision_number"`
+	AuthorID       *uuid.UUID `json:"author_id,omitempty" db:"author_id"`
+	Content        *string    `json:"content,omitempty" db:"content"`
+	ContentFormat  string     `json:"content_format" db:"content_format"`
+	ContentHash    string     `json:"content_hash" db:"content_hash"`
+	ContentSize    int        `json:"content_size" db:"content_size"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// InterviewEvent records a reschedule, cancellation or no-show. Previous and
+// new date/time are only set for reschedules. InitiatedBy is nil when the
+// user didn't say.
+type InterviewEvent struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	InterviewID  uuid.UUID  `json:"interview_id" db:"interview_id"`
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	EventType    string     `json:"event_type" db:"event_type"`
+	InitiatedBy  *string    `json:"initiated_by,omitempty" db:"initiated_by"`
+	Reason       *string    `json:"reason,omitempty" db:"reason"`
+	PreviousDate *time.Time `json:"previous_date,omitempty" db:"previous_date"`
+	PreviousTime *string    `json:"previous_time,omitempty" db:"previous_time"`
+	NewDate      *time.Time `json:"new_date,omitempty" db:"new_date"`
+	NewTime      *string    `json:"new_time,omitempty" db:"new_time"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
 type InterviewWithDetails struct {
 	Interview
 	Interviewers       []Interviewer       `json:"interviewers"`
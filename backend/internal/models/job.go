@@ -7,21 +7,23 @@ import (
 )
 
 type Job struct {
-	ID             uuid.UUID  `json:"id" db:"id"`
-	CompanyID      uuid.UUID  `json:"company_id" db:"company_id"`
-	Title          string     `json:"title" db:"title" validate:"required,min=1,max=255"`
-	JobDescription string     `json:"job_description" db:"job_description" validate:"required,min=1"`
-	Location       string     `json:"location" db:"location" validate:"required,min=1"`
-	JobType        string     `json:"job_type" db:"job_type" validate:"max=50"`
-	SourceURL      *string    `json:"source_url,omitempty" db:"source_url"`
-	Platform       *string    `json:"platform,omitempty" db:"platform"`
-	MinSalary      *float64   `json:"min_salary,omitempty" db:"min_salary"`
-	MaxSalary      *float64   `json:"max_salary,omitempty" db:"max_salary"`
-	Currency       *string    `json:"currency,omitempty" db:"currency"`
-	IsExpired      bool       `json:"is_expired" db:"is_expired"`
-	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt      *time.Time `json:"-" db:"deleted_at"`
+	ID              uuid.UUID  `json:"id" db:"id"`
+	CompanyID       uuid.UUID  `json:"company_id" db:"company_id"`
+	Title           string     `json:"title" db:"title" validate:"required,min=1,max=255"`
+	JobDescription  string     `json:"job_description" db:"job_description" validate:"required,min=1"`
+	Location        string     `json:"location" db:"location" validate:"required,min=1"`
+	JobType         string     `json:"job_type" db:"job_type" validate:"max=50"`
+	SourceURL       *string    `json:"source_url,omitempty" db:"source_url"`
+	Platform        *string    `json:"platform,omitempty" db:"platform"`
+	MinSalary       *float64   `json:"min_salary,omitempty" db:"min_salary"`
+	MaxSalary       *float64   `json:"max_salary,omitempty" db:"max_salary"`
+	Currency        *string    `json:"currency,omitempty" db:"currency"`
+	PayPeriod       *string    `json:"pay_period,omitempty" db:"pay_period"`
+	IsExpired       bool       `json:"is_expired" db:"is_expired"`
+	ExpiryCheckedAt *time.Time `json:"expiry_checked_at,omitempty" db:"expiry_checked_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt       *time.Time `json:"-" db:"deleted_at"`
 }
 
 type UserJob struct {
@@ -0,0 +1,16 @@
+package models
+
+// Formats a note can be stored in. HTML notes are sanitized when saved;
+// Markdown notes are stored as written and rendered to sanitized HTML when
+// displayed. Stored note content is therefore only safe to inject as HTML
+// when its format is HTML.
+const (
+	NoteFormatHTML     = "html"
+	NoteFormatMarkdown = "markdown"
+)
+
+// IsValidNoteFormat reports whether format is a supported note format. An
+// empty format is treated as HTML by callers.
+func IsValidNoteFormat(format string) bool {
+	return format == "" || format == NoteFormatHTML || format == NoteFormatMarkdown
+}
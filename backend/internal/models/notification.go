@@ -10,6 +10,9 @@ const (
 	NotificationTypeInterviewReminder  = "interview_reminder"
 	NotificationTypeAssessmentDeadline = "assessment_deadline"
 	NotificationTypeSystemAlert        = "system_alert"
+	NotificationTypeReflectionPrompt   = "reflection_prompt"
+	NotificationTypeThankYouReminder   = "thank_you_reminder"
+	NotificationTypeFileInfected       = "file_infected"
 )
 
 type Notification struct {
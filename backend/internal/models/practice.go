@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	PracticeSourceInterviewQuestion = "interview_question"
+	PracticeSourceBankEntry         = "bank_entry"
+)
+
+// PracticeCard is the spaced-repetition schedule for one question. Exactly one
+// of InterviewQuestionID and BankEntryID is set.
+type PracticeCard struct {
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	UserID              uuid.UUID  `json:"user_id" db:"user_id"`
+	InterviewQuestionID *uuid.UUID `json:"interview_question_id,omitempty" db:"interview_question_id"`
+	BankEntryID         *uuid.UUID `json:"bank_entry_id,omitempty" db:"bank_entry_id"`
+	EaseFactor          float64    `json:"ease_factor" db:"ease_factor"`
+	IntervalDays        int        `json:"interval_days" db:"interval_days"`
+	Repetitions         int        `json:"repetitions" db:"repetitions"`
+	DueAt               time.Time  `json:"due_at" db:"due_at"`
+	LastReviewedAt      *time.Time `json:"last_reviewed_at,omitempty" db:"last_reviewed_at"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PracticeItem is a card together with the question it schedules.
+type PracticeItem struct {
+	PracticeCard
+	Source       string         `json:"source" db:"source"`
+	QuestionText string         `json:"question_text" db:"question_text"`
+	AnswerText   *string        `json:"answer_text,omitempty" db:"answer_text"`
+	Topics       pq.StringArray `json:"topics" db:"topics"`
+	CompanyName  *string        `json:"company_name,omitempty" db:"company_name"`
+	InterviewID  *uuid.UUID     `json:"interview_id,omitempty" db:"interview_id"`
+}
+
+// PracticeReview is one self-graded attempt at a card, with the schedule it
+// produced.
+type PracticeReview struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	CardID       uuid.UUID `json:"card_id" db:"card_id"`
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Grade        int       `json:"grade" db:"grade"`
+	EaseFactor   float64   `json:"ease_factor" db:"ease_factor"`
+	IntervalDays int       `json:"interval_days" db:"interval_days"`
+	ReviewedAt   time.Time `json:"reviewed_at" db:"reviewed_at"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	QuestionDifficultyEasy   = "easy"
+	QuestionDifficultyMedium = "medium"
+	QuestionDifficultyHard   = "hard"
+)
+
+// QuestionBankEntry is a canonical interview question in a user's bank. Each
+// time it comes up in an interview, the InterviewQuestion row links back here.
+type QuestionBankEntry struct {
+	ID           uuid.UUID      `json:"id" db:"id"`
+	UserID       uuid.UUID      `json:"user_id" db:"user_id"`
+	QuestionText string         `json:"question_text" db:"question_text"`
+	Topics       pq.StringArray `json:"topics" db:"topics"`
+	Difficulty   *string        `json:"difficulty,omitempty" db:"difficulty"`
+	ModelAnswer  *string        `json:"model_answer,omitempty" db:"model_answer"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time     `json:"-" db:"deleted_at"`
+}
+
+// QuestionBankEntryWithStats adds how often and how recently an entry was
+// asked across the user's interviews.
+type QuestionBankEntryWithStats struct {
+	QuestionBankEntry
+	TimesAsked   int        `json:"times_asked" db:"times_asked"`
+	CompanyCount int        `json:"company_count" db:"company_count"`
+	LastAskedAt  *time.Time `json:"last_asked_at,omitempty" db:"last_asked_at"`
+}
+
+// QuestionBankUsage is one interview in which a bank entry was asked.
+type QuestionBankUsage struct {
+	InterviewQuestionID uuid.UUID `json:"interview_question_id" db:"interview_question_id"`
+	InterviewID         uuid.UUID `json:"interview_id" db:"interview_id"`
+	ApplicationID       uuid.UUID `json:"application_id" db:"application_id"`
+	ScheduledDate       time.Time `json:"scheduled_date" db:"scheduled_date"`
+	RoundNumber         int       `json:"round_number" db:"round_number"`
+	InterviewType       string    `json:"interview_type" db:"interview_type"`
+	CompanyID           uuid.UUID `json:"company_id" db:"company_id"`
+	CompanyName         string    `json:"company_name" db:"company_name"`
+	JobTitle            string    `json:"job_title" db:"job_title"`
+	AskedAs             string    `json:"asked_as" db:"asked_as"`
+	AnswerText          *string   `json:"answer_text,omitempty" db:"answer_text"`
+}
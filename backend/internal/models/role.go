@@ -0,0 +1,5 @@
+package models
+
+// RoleAdmin is granted to users who maintain data shared by every user,
+// such as the companies table.
+const RoleAdmin = "admin"
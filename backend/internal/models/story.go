@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Story is a behavioral interview story in STAR form. Interview questions
+// answered with it link back via InterviewQuestion.StoryID.
+type Story struct {
+	ID           uuid.UUID      `json:"id" db:"id"`
+	UserID       uuid.UUID      `json:"user_id" db:"user_id"`
+	Title        string         `json:"title" db:"title"`
+	Situation    *string        `json:"situation,omitempty" db:"situation"`
+	Task         *string        `json:"task,omitempty" db:"task"`
+	Action       *string        `json:"action,omitempty" db:"action"`
+	Result       *string        `json:"result,omitempty" db:"result"`
+	Competencies pq.StringArray `json:"competencies" db:"competencies"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time     `json:"-" db:"deleted_at"`
+}
+
+// StoryWithStats adds how often and how recently a story was told.
+type StoryWithStats struct {
+	Story
+	TimesTold    int        `json:"times_told" db:"times_told"`
+	LastToldAt   *time.Time `json:"last_told_at,omitempty" db:"last_told_at"`
+	CompanyCount int        `json:"company_count" db:"company_count"`
+}
+
+// StoryUsage is one interview question answered with a story, with who heard
+// it.
+type StoryUsage struct {
+	StoryID             uuid.UUID      `json:"story_id" db:"story_id"`
+	InterviewQuestionID uuid.UUID      `json:"interview_question_id" db:"interview_question_id"`
+	QuestionText        string         `json:"question_text" db:"question_text"`
+	InterviewID         uuid.UUID      `json:"interview_id" db:"interview_id"`
+	ScheduledDate       time.Time      `json:"scheduled_date" db:"scheduled_date"`
+	RoundNumber         int            `json:"round_number" db:"round_number"`
+	InterviewType       string         `json:"interview_type" db:"interview_type"`
+	CompanyID           uuid.UUID      `json:"company_id" db:"company_id"`
+	CompanyName         string         `json:"company_name" db:"company_name"`
+	Interviewers        pq.StringArray `json:"interviewers" db:"interviewers"`
+}
+
+// StoryAudience is who an interview is with, for checking which stories they
+// have already heard.
+type StoryAudience struct {
+	InterviewID  uuid.UUID      `json:"interview_id" db:"interview_id"`
+	CompanyID    uuid.UUID      `json:"company_id" db:"company_id"`
+	CompanyName  string         `json:"company_name" db:"company_name"`
+	Interviewers pq.StringArray `json:"interviewers" db:"interviewers"`
+}
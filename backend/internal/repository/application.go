@@ -2,6 +2,7 @@ package repository
 
 import (
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/pkg/database"
 	"ditto-backend/pkg/errors"
 	"fmt"
@@ -34,6 +35,8 @@ type ApplicationFilters struct {
 	DateTo         *time.Time
 	HasInterviews  *bool
 	HasAssessments *bool
+	MinSalary      *float64 // in the user's preferred currency
+	MaxSalary      *float64 // in the user's preferred currency
 	SortBy         string
 	SortOrder      string
 	Limit          int
@@ -45,6 +48,33 @@ type ApplicationWithDetails struct {
 	Job     *models.Job               `json:"job,omitempty"`
 	Company *models.Company           `json:"company,omitempty"`
 	Status  *models.ApplicationStatus `json:"status,omitempty"`
+
+	// Names of the statuses the application can move to next
+	AllowedTransitions []string `json:"allowed_transitions"`
+
+	// Job salary converted into the user's preferred currency. Nil when the
+	// job has no salary or there is no exchange rate for its currency.
+	NormalizedMinSalary *float64 `json:"normalized_min_salary,omitempty"`
+	NormalizedMaxSalary *float64 `json:"normalized_max_salary,omitempty"`
+	NormalizedCurrency  *string  `json:"normalized_currency,omitempty"`
+}
+
+// SQL expressions converting job salaries into the owning user's preferred
+// currency via convert_currency (migration 000022). They expect the
+// applications table aliased as a and jobs as j.
+const (
+	preferredCurrencyExpr   = `(SELECT u.preferred_currency FROM users u WHERE u.id = a.user_id)`
+	normalizedMinSalaryExpr = `convert_currency(j.min_salary, j.currency, ` + preferredCurrencyExpr + `)`
+	normalizedMaxSalaryExpr = `convert_currency(j.max_salary, j.currency, ` + preferredCurrencyExpr + `)`
+	normalizedSalaryColumns = normalizedMinSalaryExpr + ` as normalized_min_salary, ` +
+		normalizedMaxSalaryExpr + ` as normalized_max_salary, ` +
+		preferredCurrencyExpr + ` as normalized_currency`
+)
+
+type normalizedSalary struct {
+	MinSalary *float64
+	MaxSalary *float64
+	Currency  *string
 }
 
 func NewApplicationRepository(database *database.Database) *ApplicationRepository {
@@ -56,20 +86,23 @@ func NewApplicationRepository(database *database.Database) *ApplicationRepositor
 func (r *ApplicationRepository) CreateApplication(userID uuid.UUID, application *models.Application) (*models.Application, error) {
 	application.ID = uuid.New()
 	application.UserID = userID
+	if application.NotesFormat == "" {
+		application.NotesFormat = models.NoteFormatHTML
+	}
 	application.CreatedAt = time.Now()
 	application.UpdatedAt = time.Now()
 
 	query := `
         INSERT INTO applications (
             id, user_id, job_id, application_status_id, applied_at, 
-            offer_received, attempt_number, notes, created_at, updated_at
+            offer_received, attempt_number, notes, notes_format, created_at, updated_at
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
     `
 
 	_, err := r.db.Exec(query, application.ID, application.UserID, application.JobID,
 		application.ApplicationStatusID, application.AppliedAt, application.OfferReceived,
-		application.AttemptNumber, application.Notes, application.CreatedAt, application.UpdatedAt,
+		application.AttemptNumber, application.Notes, application.NotesFormat, application.CreatedAt, application.UpdatedAt,
 	)
 	if err != nil {
 		return nil, errors.ConvertError(err)
@@ -80,7 +113,7 @@ func (r *ApplicationRepository) CreateApplication(userID uuid.UUID, application
 
 func (r *ApplicationRepository) GetApplicationByID(applicationID, userID uuid.UUID) (*models.Application, error) {
 	query := `
-        SELECT id, user_id, job_id, application_status_id, applied_at, offer_received, attempt_number, notes, created_at, updated_at
+        SELECT id, user_id, job_id, application_status_id, applied_at, offer_received, attempt_number, notes, notes_format, created_at, updated_at
         FROM applications
         WHERE id = $1 
         AND user_id = $2
@@ -172,7 +205,7 @@ func (r *ApplicationRepository) SoftDeleteApplication(applicationID, userID uuid
 
 func (r *ApplicationRepository) GetApplicationsByUser(userID uuid.UUID, filters *ApplicationFilters) ([]*models.Application, error) {
 	baseQuery := `
-        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.created_at, a.updated_at
+        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.notes_format, a.created_at, a.updated_at
         FROM applications a
         LEFT JOIN jobs j ON a.job_id = j.id
         LEFT JOIN companies c ON j.company_id = c.id
@@ -194,14 +227,15 @@ func (r *ApplicationRepository) GetApplicationsByUser(userID uuid.UUID, filters
 
 func (r *ApplicationRepository) GetApplicationsWithDetails(userID uuid.UUID, filters *ApplicationFilters) ([]*ApplicationWithDetails, error) {
 	baseQuery := `
-        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.created_at, a.updated_at,
+        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.notes_format, a.created_at, a.updated_at,
             j.id as "job.id", j.company_id as "job.company_id", j.title as "job.title", j.job_description as "job.job_description", j.location as "job.location",
             j.job_type as "job.job_type", j.source_url as "job.source_url", j.platform as "job.platform",
             j.min_salary as "job.min_salary", j.max_salary as "job.max_salary",
-            j.currency as "job.currency", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
+            j.currency as "job.currency", j.pay_period as "job.pay_period", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
             c.id as "company.id", c.name as "company.name", c.description as "company.description", c.website as "company.website",
             c.logo_url as "company.logo_url", c.created_at as "company.created_at", c.updated_at as "company.updated_at",
-            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at"
+            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at",
+            ` + normalizedSalaryColumns + `
         FROM applications a
         LEFT JOIN jobs j ON a.job_id = j.id
         LEFT JOIN companies c ON j.company_id = c.id
@@ -226,15 +260,17 @@ func (r *ApplicationRepository) GetApplicationsWithDetails(userID uuid.UUID, fil
 		var job models.Job
 		var company models.Company
 		var applicationStatus models.ApplicationStatus
+		var normalized normalizedSalary
 
 		err := rows.Scan(
-			&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.CreatedAt, &application.UpdatedAt,
+			&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.NotesFormat, &application.CreatedAt, &application.UpdatedAt,
 			&job.ID, &job.CompanyID, &job.Title, &job.JobDescription,
-			&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency,
+			&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency, &job.PayPeriod,
 			&job.IsExpired, &job.CreatedAt, &job.UpdatedAt,
 			&company.ID, &company.Name, &company.Description, &company.Website,
 			&company.LogoURL, &company.CreatedAt, &company.UpdatedAt,
 			&applicationStatus.ID, &applicationStatus.Name, &applicationStatus.CreatedAt, &applicationStatus.UpdatedAt,
+			&normalized.MinSalary, &normalized.MaxSalary, &normalized.Currency,
 		)
 		if err != nil {
 			return nil, errors.ConvertError(err)
@@ -245,6 +281,12 @@ func (r *ApplicationRepository) GetApplicationsWithDetails(userID uuid.UUID, fil
 			Job:         &job,
 			Company:     &company,
 			Status:      &applicationStatus,
+
+			AllowedTransitions: workflow.Application.Allowed(applicationStatus.Name),
+
+			NormalizedMinSalary: normalized.MinSalary,
+			NormalizedMaxSalary: normalized.MaxSalary,
+			NormalizedCurrency:  normalized.Currency,
 		})
 	}
 
@@ -253,14 +295,15 @@ func (r *ApplicationRepository) GetApplicationsWithDetails(userID uuid.UUID, fil
 
 func (r *ApplicationRepository) GetApplicationByIDWithDetails(applicationID, userID uuid.UUID) (*ApplicationWithDetails, error) {
 	query := `
-        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.created_at, a.updated_at,
+        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.notes_format, a.created_at, a.updated_at,
             j.id as "job.id", j.company_id as "job.company_id", j.title as "job.title", j.job_description as "job.job_description", j.location as "job.location",
             j.job_type as "job.job_type", j.source_url as "job.source_url", j.platform as "job.platform",
             j.min_salary as "job.min_salary", j.max_salary as "job.max_salary",
-            j.currency as "job.currency", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
+            j.currency as "job.currency", j.pay_period as "job.pay_period", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
             c.id as "company.id", c.name as "company.name", c.description as "company.description", c.website as "company.website",
             c.logo_url as "company.logo_url", c.created_at as "company.created_at", c.updated_at as "company.updated_at",
-            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at"
+            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at",
+            ` + normalizedSalaryColumns + `
         FROM applications a
         LEFT JOIN jobs j ON a.job_id = j.id
         LEFT JOIN companies c ON j.company_id = c.id
@@ -274,16 +317,18 @@ func (r *ApplicationRepository) GetApplicationByIDWithDetails(applicationID, use
 	var job models.Job
 	var company models.Company
 	var applicationStatus models.ApplicationStatus
+	var normalized normalizedSalary
 
 	row := r.db.QueryRow(query, applicationID, userID)
 	err := row.Scan(
-		&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.CreatedAt, &application.UpdatedAt,
+		&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.NotesFormat, &application.CreatedAt, &application.UpdatedAt,
 		&job.ID, &job.CompanyID, &job.Title, &job.JobDescription,
-		&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency,
+		&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency, &job.PayPeriod,
 		&job.IsExpired, &job.CreatedAt, &job.UpdatedAt,
 		&company.ID, &company.Name, &company.Description, &company.Website,
 		&company.LogoURL, &company.CreatedAt, &company.UpdatedAt,
 		&applicationStatus.ID, &applicationStatus.Name, &applicationStatus.CreatedAt, &applicationStatus.UpdatedAt,
+		&normalized.MinSalary, &normalized.MaxSalary, &normalized.Currency,
 	)
 	if err != nil {
 		return nil, errors.ConvertError(err)
@@ -294,6 +339,12 @@ func (r *ApplicationRepository) GetApplicationByIDWithDetails(applicationID, use
 		Job:         &job,
 		Company:     &company,
 		Status:      &applicationStatus,
+
+		AllowedTransitions: workflow.Application.Allowed(applicationStatus.Name),
+
+		NormalizedMinSalary: normalized.MinSalary,
+		NormalizedMaxSalary: normalized.MaxSalary,
+		NormalizedCurrency:  normalized.Currency,
 	}, nil
 }
 
@@ -351,14 +402,15 @@ func (r *ApplicationRepository) GetApplicationsByStatus(userID uuid.UUID) (map[s
 
 func (r *ApplicationRepository) GetRecentApplications(userID uuid.UUID, limit int) ([]*ApplicationWithDetails, error) {
 	query := `
-        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.created_at, a.updated_at,
+        SELECT a.id, a.user_id, a.job_id, a.application_status_id, a.applied_at, a.offer_received, a.attempt_number, a.notes, a.notes_format, a.created_at, a.updated_at,
             j.id as "job.id", j.company_id as "job.company_id", j.title as "job.title", j.job_description as "job.job_description", j.location as "job.location",
             j.job_type as "job.job_type", j.source_url as "job.source_url", j.platform as "job.platform",
             j.min_salary as "job.min_salary", j.max_salary as "job.max_salary",
-            j.currency as "job.currency", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
+            j.currency as "job.currency", j.pay_period as "job.pay_period", j.is_expired as "job.is_expired", j.created_at as "job.created_at", j.updated_at as "job.updated_at",
             c.id as "company.id", c.name as "company.name", c.description as "company.description", c.website as "company.website",
             c.logo_url as "company.logo_url", c.created_at as "company.created_at", c.updated_at as "company.updated_at",
-            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at"
+            ast.id as "application_status.id", ast.name as "application_status.name", ast.created_at as "application_status.created_at", ast.updated_at as "application_status.updated_at",
+            ` + normalizedSalaryColumns + `
         FROM applications a
         LEFT JOIN jobs j ON a.job_id = j.id
         LEFT JOIN companies c ON j.company_id = c.id
@@ -382,15 +434,17 @@ func (r *ApplicationRepository) GetRecentApplications(userID uuid.UUID, limit in
 		var job models.Job
 		var company models.Company
 		var applicationStatus models.ApplicationStatus
+		var normalized normalizedSalary
 
 		err := rows.Scan(
-			&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.CreatedAt, &application.UpdatedAt,
+			&application.ID, &application.UserID, &application.JobID, &application.ApplicationStatusID, &application.AppliedAt, &application.OfferReceived, &application.AttemptNumber, &application.Notes, &application.NotesFormat, &application.CreatedAt, &application.UpdatedAt,
 			&job.ID, &job.CompanyID, &job.Title, &job.JobDescription,
-			&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency,
+			&job.Location, &job.JobType, &job.SourceURL, &job.Platform, &job.MinSalary, &job.MaxSalary, &job.Currency, &job.PayPeriod,
 			&job.IsExpired, &job.CreatedAt, &job.UpdatedAt,
 			&company.ID, &company.Name, &company.Description, &company.Website,
 			&company.LogoURL, &company.CreatedAt, &company.UpdatedAt,
 			&applicationStatus.ID, &applicationStatus.Name, &applicationStatus.CreatedAt, &applicationStatus.UpdatedAt,
+			&normalized.MinSalary, &normalized.MaxSalary, &normalized.Currency,
 		)
 		if err != nil {
 			return nil, errors.ConvertError(err)
@@ -401,13 +455,57 @@ func (r *ApplicationRepository) GetRecentApplications(userID uuid.UUID, limit in
 			Job:         &job,
 			Company:     &company,
 			Status:      &applicationStatus,
+
+			AllowedTransitions: workflow.Application.Allowed(applicationStatus.Name),
+
+			NormalizedMinSalary: normalized.MinSalary,
+			NormalizedMaxSalary: normalized.MaxSalary,
+			NormalizedCurrency:  normalized.Currency,
 		})
 	}
 
 	return applicationsWithDetails, nil
 }
 
+// UpdateApplicationStatus moves an application to another status. The move
+// must be allowed by workflow.Application.
 func (r *ApplicationRepository) UpdateApplicationStatus(applicationID, userID, application_status_id uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var current string
+	err = tx.Get(&current, `
+		SELECT ast.name
+		FROM applications a
+		JOIN application_status ast ON a.application_status_id = ast.id
+		WHERE a.id = $1 AND a.user_id = $2 AND a.deleted_at IS NULL
+		FOR UPDATE OF a
+	`, applicationID, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return errors.New(errors.ErrorNotFound, "application not found")
+		}
+		return err
+	}
+
+	var next string
+	err = tx.Get(&next, `SELECT name FROM application_status WHERE id = $1`, application_status_id)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return errors.New(errors.ErrorBadRequest, "unknown application status")
+		}
+		return err
+	}
+
+	if err := workflow.Application.Check(current, next); err != nil {
+		return err
+	}
+
 	query := `
             UPDATE applications
             SET application_status_id = $1, updated_at = $2
@@ -416,20 +514,14 @@ func (r *ApplicationRepository) UpdateApplicationStatus(applicationID, userID, a
             AND deleted_at IS NULL
         `
 
-	result, err := r.db.Exec(query, application_status_id, time.Now(), applicationID, userID)
-	if err != nil {
+	if _, err := tx.Exec(query, application_status_id, time.Now(), applicationID, userID); err != nil {
 		return errors.ConvertError(err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return errors.ConvertError(err)
 	}
 
-	if rowsAffected == 0 {
-		return errors.New(errors.ErrorNotFound, "application not found")
-	}
-
 	return nil
 }
 
@@ -528,6 +620,7 @@ func (r *ApplicationRepository) buildOrderByClause(filters *ApplicationFilters)
 		"location":   "j.location",
 		"updated_at": "a.updated_at",
 		"job_type":   "j.job_type",
+		"salary":     "COALESCE(" + normalizedMaxSalaryExpr + ", " + normalizedMinSalaryExpr + ")",
 	}
 
 	sortOrder := "DESC"
@@ -614,6 +707,19 @@ func (r *ApplicationRepository) buildFilterQuery(filters *ApplicationFilters, ba
 		argIndex++
 	}
 
+	// Salary filters match jobs whose range overlaps [MinSalary, MaxSalary]
+	if filters.MinSalary != nil {
+		query += fmt.Sprintf(" AND COALESCE(%s, %s) >= $%d", normalizedMaxSalaryExpr, normalizedMinSalaryExpr, argIndex)
+		args = append(args, *filters.MinSalary)
+		argIndex++
+	}
+
+	if filters.MaxSalary != nil {
+		query += fmt.Sprintf(" AND COALESCE(%s, %s) <= $%d", normalizedMinSalaryExpr, normalizedMaxSalaryExpr, argIndex)
+		args = append(args, *filters.MaxSalary)
+		argIndex++
+	}
+
 	if filters.HasInterviews != nil {
 		if *filters.HasInterviews {
 			query += " AND EXISTS (SELECT 1 FROM interviews i WHERE i.application_id = a.id AND i.deleted_at IS NULL)"
@@ -632,3 +738,41 @@ func (r *ApplicationRepository) buildFilterQuery(filters *ApplicationFilters, ba
 
 	return query, args, argIndex
 }
+
+// ApplicationCompanyDomain links one of the user's applications to the
+// domain of the company it is with.
+type ApplicationCompanyDomain struct {
+	ApplicationID uuid.UUID `json:"application_id" db:"application_id"`
+	CompanyID     uuid.UUID `json:"company_id" db:"company_id"`
+	CompanyName   string    `json:"company_name" db:"company_name"`
+	CompanyDomain string    `json:"company_domain" db:"company_domain"`
+	JobTitle      string    `json:"job_title" db:"job_title"`
+	StatusName    string    `json:"status" db:"status_name"`
+	AppliedAt     time.Time `json:"applied_at" db:"applied_at"`
+}
+
+// GetApplicationCompanyDomains lists the user's applications to companies
+// with a known domain, most recent first
+func (r *ApplicationRepository) GetApplicationCompanyDomains(userID uuid.UUID) ([]*ApplicationCompanyDomain, error) {
+	query := `
+		SELECT
+			a.id as application_id, c.id as company_id, c.name as company_name,
+			c.domain as company_domain, j.title as job_title,
+			COALESCE(ast.name, '') as status_name, a.applied_at
+		FROM applications a
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		LEFT JOIN application_status ast ON a.application_status_id = ast.id
+		WHERE a.user_id = $1 AND a.deleted_at IS NULL
+		AND c.domain IS NOT NULL AND c.domain <> ''
+		ORDER BY a.applied_at DESC
+	`
+
+	var applications []*ApplicationCompanyDomain
+	err := r.db.Select(&applications, query, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return applications, nil
+}
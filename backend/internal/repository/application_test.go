@@ -2,7 +2,9 @@ package repository
 
 import (
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/internal/testutil"
+	"ditto-backend/pkg/errors"
 	"testing"
 
 	"github.com/google/uuid"
@@ -437,6 +439,26 @@ func TestApplicationRepository(t *testing.T) {
 			assert.Equal(t, differentStatusID, retrieved.ApplicationStatusID)
 		})
 
+		t.Run("IllegalTransition", func(t *testing.T) {
+			savedID, err := applicationRepo.GetApplicationStatusIDByName(workflow.ApplicationSaved)
+			require.NoError(t, err)
+			offerID, err := applicationRepo.GetApplicationStatusIDByName(workflow.ApplicationOffer)
+			require.NoError(t, err)
+
+			statusApp := testutil.CreateTestApplication(testUser.ID, createdJob.ID, savedID)
+			statusApp, err = applicationRepo.CreateApplication(testUser.ID, statusApp)
+			require.NoError(t, err)
+
+			err = applicationRepo.UpdateApplicationStatus(statusApp.ID, testUser.ID, offerID)
+			require.Error(t, err)
+			assert.Equal(t, errors.ErrorInvalidTransition, errors.ConvertError(err).Code)
+
+			retrieved, err := applicationRepo.GetApplicationByIDWithDetails(statusApp.ID, testUser.ID)
+			require.NoError(t, err)
+			assert.Equal(t, savedID, retrieved.ApplicationStatusID)
+			assert.Equal(t, []string{workflow.ApplicationApplied, workflow.ApplicationInterview, workflow.ApplicationRejected}, retrieved.AllowedTransitions)
+		})
+
 		t.Run("WrongUserID", func(t *testing.T) {
 			statusApp := testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID)
 			statusApp, err := applicationRepo.CreateApplication(testUser.ID, statusApp)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/pkg/database"
 	"ditto-backend/pkg/errors"
 	"fmt"
@@ -30,18 +31,21 @@ func (r *AssessmentRepository) CreateAssessment(assessment *models.Assessment) (
 	if assessment.Status == "" {
 		assessment.Status = models.AssessmentStatusNotStarted
 	}
+	if assessment.DueTimezone == "" {
+		assessment.DueTimezone = "UTC"
+	}
 
 	query := `
 		INSERT INTO assessments (
-			id, user_id, application_id, assessment_type, title, due_date,
+			id, user_id, application_id, assessment_type, title, due_date, due_timezone,
 			status, instructions, requirements, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.db.Exec(query, assessment.ID, assessment.UserID,
 		assessment.ApplicationID, assessment.AssessmentType, assessment.Title,
-		assessment.DueDate, assessment.Status, assessment.Instructions,
+		assessment.DueDate, assessment.DueTimezone, assessment.Status, assessment.Instructions,
 		assessment.Requirements, assessment.CreatedAt, assessment.UpdatedAt)
 	if err != nil {
 		return nil, errors.ConvertError(err)
@@ -53,7 +57,7 @@ func (r *AssessmentRepository) CreateAssessment(assessment *models.Assessment) (
 func (r *AssessmentRepository) GetAssessmentByID(id, userID uuid.UUID) (*models.Assessment, error) {
 	query := `
 		SELECT
-			id, user_id, application_id, assessment_type, title, due_date,
+			id, user_id, application_id, assessment_type, title, due_date, due_timezone,
 			status, instructions, requirements, created_at, updated_at
 		FROM assessments
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
@@ -71,7 +75,7 @@ func (r *AssessmentRepository) GetAssessmentByID(id, userID uuid.UUID) (*models.
 func (r *AssessmentRepository) ListByApplicationID(applicationID, userID uuid.UUID) ([]*models.Assessment, error) {
 	query := `
 		SELECT
-			id, user_id, application_id, assessment_type, title, due_date,
+			id, user_id, application_id, assessment_type, title, due_date, due_timezone,
 			status, instructions, requirements, created_at, updated_at
 		FROM assessments
 		WHERE application_id = $1 AND user_id = $2 AND deleted_at IS NULL
@@ -87,6 +91,30 @@ func (r *AssessmentRepository) ListByApplicationID(applicationID, userID uuid.UU
 	return assessments, nil
 }
 
+// ListByCompanyID returns assessments across every application the user has
+// made to a company
+func (r *AssessmentRepository) ListByCompanyID(companyID, userID uuid.UUID) ([]*models.Assessment, error) {
+	query := `
+		SELECT
+			ass.id, ass.user_id, ass.application_id, ass.assessment_type, ass.title, ass.due_date, ass.due_timezone,
+			ass.status, ass.instructions, ass.requirements, ass.created_at, ass.updated_at
+		FROM assessments ass
+		JOIN applications a ON ass.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.company_id = $1 AND ass.user_id = $2
+		AND ass.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY ass.due_date ASC
+	`
+
+	var assessments []*models.Assessment
+	err := r.db.Select(&assessments, query, companyID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return assessments, nil
+}
+
 // AssessmentWithContext includes application context for dashboard/timeline display
 type AssessmentWithContext struct {
 	models.Assessment
@@ -98,7 +126,7 @@ type AssessmentWithContext struct {
 func (r *AssessmentRepository) ListByUserID(userID uuid.UUID) ([]*AssessmentWithContext, error) {
 	query := `
 		SELECT
-			ass.id, ass.user_id, ass.application_id, ass.assessment_type, ass.title, ass.due_date,
+			ass.id, ass.user_id, ass.application_id, ass.assessment_type, ass.title, ass.due_date, ass.due_timezone,
 			ass.status, ass.instructions, ass.requirements, ass.created_at, ass.updated_at,
 			c.name as company_name, j.title as job_title
 		FROM assessments ass
@@ -118,11 +146,94 @@ func (r *AssessmentRepository) ListByUserID(userID uuid.UUID) ([]*AssessmentWith
 	return assessments, nil
 }
 
+// UpdateAssessment applies updates to an assessment. A status change must be
+// allowed by workflow.Assessment.
 func (r *AssessmentRepository) UpdateAssessment(id, userID uuid.UUID, updates map[string]any) (*models.Assessment, error) {
 	if len(updates) == 0 {
 		return r.GetAssessmentByID(id, userID)
 	}
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if status, ok := updates["status"].(string); ok {
+		if err := checkAssessmentTransition(tx, id, userID, status); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateAssessment(tx, id, userID, updates); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return r.GetAssessmentByID(id, userID)
+}
+
+// TransitionStatus moves an assessment to a new status. When submission is
+// given it is recorded in the same transaction, so moving to submitted and
+// saving what was submitted succeed or fail together.
+func (r *AssessmentRepository) TransitionStatus(id, userID uuid.UUID, status string, submission *models.AssessmentSubmission) (*models.Assessment, *models.AssessmentSubmission, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := checkAssessmentTransition(tx, id, userID, status); err != nil {
+		return nil, nil, err
+	}
+
+	if err := updateAssessment(tx, id, userID, map[string]any{"status": status}); err != nil {
+		return nil, nil, err
+	}
+
+	if submission != nil {
+		submission.AssessmentID = id
+		if err := insertSubmission(tx, submission); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+
+	assessment, err := r.GetAssessmentByID(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return assessment, submission, nil
+}
+
+// checkAssessmentTransition locks the assessment and checks it may move to
+// status.
+func checkAssessmentTransition(tx *sqlx.Tx, id, userID uuid.UUID, status string) error {
+	var current string
+	err := tx.Get(&current, `
+		SELECT status FROM assessments
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, id, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return errors.New(errors.ErrorNotFound, "assessment not found or owned by other user")
+		}
+		return err
+	}
+
+	return workflow.Assessment.Check(current, status)
+}
+
+func updateAssessment(tx *sqlx.Tx, id, userID uuid.UUID, updates map[string]any) error {
 	setParts := []string{}
 	args := []any{}
 	argIndex := 1
@@ -145,21 +256,21 @@ func (r *AssessmentRepository) UpdateAssessment(id, userID uuid.UUID, updates ma
 		WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL
 	`, strings.Join(setParts, ", "), argIndex, argIndex+1)
 
-	result, err := r.db.Exec(query, args...)
+	result, err := tx.Exec(query, args...)
 	if err != nil {
-		return nil, errors.ConvertError(err)
+		return errors.ConvertError(err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, errors.ConvertError(err)
+		return errors.ConvertError(err)
 	}
 
 	if rowsAffected == 0 {
-		return nil, errors.New(errors.ErrorNotFound, "assessment not found or owned by other user")
+		return errors.New(errors.ErrorNotFound, "assessment not found or owned by other user")
 	}
 
-	return r.GetAssessmentByID(id, userID)
+	return nil
 }
 
 func (r *AssessmentRepository) SoftDeleteAssessment(id, userID uuid.UUID) error {
@@ -21,33 +21,48 @@ func NewAssessmentSubmissionRepository(database *database.Database) *AssessmentS
 }
 
 func (r *AssessmentSubmissionRepository) CreateSubmission(submission *models.AssessmentSubmission) (*models.AssessmentSubmission, error) {
+	if err := insertSubmission(r.db, submission); err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+func insertSubmission(db sqlx.Execer, submission *models.AssessmentSubmission) error {
 	submission.ID = uuid.New()
+	if submission.NotesFormat == "" {
+		submission.NotesFormat = models.NoteFormatHTML
+	}
 	submission.SubmittedAt = time.Now()
 	submission.CreatedAt = time.Now()
 
 	query := `
 		INSERT INTO assessment_submissions (
 			id, assessment_id, submission_type, github_url, file_id,
-			notes, submitted_at, created_at
+			notes, notes_format, github_commit_sha, github_ref, github_private,
+			github_verified_at, archive_file_id, submitted_at, created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
-	_, err := r.db.Exec(query, submission.ID, submission.AssessmentID,
+	_, err := db.Exec(query, submission.ID, submission.AssessmentID,
 		submission.SubmissionType, submission.GithubURL, submission.FileID,
-		submission.Notes, submission.SubmittedAt, submission.CreatedAt)
+		submission.Notes, submission.NotesFormat, submission.GithubCommitSHA,
+		submission.GithubRef, submission.GithubPrivate, submission.GithubVerifiedAt,
+		submission.ArchiveFileID, submission.SubmittedAt, submission.CreatedAt)
 	if err != nil {
-		return nil, errors.ConvertError(err)
+		return errors.ConvertError(err)
 	}
 
-	return submission, nil
+	return nil
 }
 
 func (r *AssessmentSubmissionRepository) ListByAssessmentID(assessmentID uuid.UUID) ([]*models.AssessmentSubmission, error) {
 	query := `
 		SELECT
 			id, assessment_id, submission_type, github_url, file_id,
-			notes, submitted_at, created_at
+			notes, notes_format, github_commit_sha, github_ref, github_private,
+			github_verified_at, archive_file_id, submitted_at, created_at
 		FROM assessment_submissions
 		WHERE assessment_id = $1 AND deleted_at IS NULL
 		ORDER BY submitted_at DESC
@@ -66,7 +81,8 @@ func (r *AssessmentSubmissionRepository) GetSubmissionByID(id uuid.UUID) (*model
 	query := `
 		SELECT
 			id, assessment_id, submission_type, github_url, file_id,
-			notes, submitted_at, created_at
+			notes, notes_format, github_commit_sha, github_ref, github_private,
+			github_verified_at, archive_file_id, submitted_at, created_at
 		FROM assessment_submissions
 		WHERE id = $1 AND deleted_at IS NULL
 	`
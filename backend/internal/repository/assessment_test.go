@@ -3,7 +3,9 @@ package repository
 import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/testutil"
+	"ditto-backend/pkg/errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -60,7 +62,7 @@ func TestAssessmentRepository(t *testing.T) {
 				ApplicationID:  createdApp.ID,
 				AssessmentType: models.AssessmentTypeTakeHomeProject,
 				Title:          "Take Home Project",
-				DueDate:        "2026-03-01",
+				DueDate:        time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC),
 				Instructions:   &instructions,
 			}
 
@@ -82,7 +84,7 @@ func TestAssessmentRepository(t *testing.T) {
 				ApplicationID:  createdApp.ID,
 				AssessmentType: models.AssessmentTypeLiveCoding,
 				Title:          "Live Coding Session",
-				DueDate:        "2026-03-05",
+				DueDate:        time.Date(2026, 3, 5, 23, 59, 0, 0, time.UTC),
 				Status:         models.AssessmentStatusInProgress,
 			}
 
@@ -99,7 +101,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeSystemDesign,
 			Title:          "System Design Exercise",
-			DueDate:        "2026-03-10",
+			DueDate:        time.Date(2026, 3, 10, 23, 59, 0, 0, time.UTC),
 		}
 		created, err := assessmentRepo.CreateAssessment(assessment)
 		require.NoError(t, err)
@@ -135,7 +137,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeDataStructures,
 			Title:          "DS Assessment (later)",
-			DueDate:        "2026-04-15",
+			DueDate:        time.Date(2026, 4, 15, 23, 59, 0, 0, time.UTC),
 		}
 		_, err := assessmentRepo.CreateAssessment(assessment1)
 		require.NoError(t, err)
@@ -145,7 +147,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeCaseStudy,
 			Title:          "Case Study (earlier)",
-			DueDate:        "2026-04-01",
+			DueDate:        time.Date(2026, 4, 1, 23, 59, 0, 0, time.UTC),
 		}
 		_, err = assessmentRepo.CreateAssessment(assessment2)
 		require.NoError(t, err)
@@ -158,7 +160,7 @@ func TestAssessmentRepository(t *testing.T) {
 
 			// Verify ascending due_date order
 			for i := 0; i < len(list)-1; i++ {
-				assert.LessOrEqual(t, list[i].DueDate, list[i+1].DueDate)
+				assert.False(t, list[i].DueDate.After(list[i+1].DueDate))
 			}
 		})
 
@@ -198,7 +200,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeOther,
 			Title:          "Original Title",
-			DueDate:        "2026-05-01",
+			DueDate:        time.Date(2026, 5, 1, 23, 59, 0, 0, time.UTC),
 		}
 		created, err := assessmentRepo.CreateAssessment(assessment)
 		require.NoError(t, err)
@@ -214,7 +216,7 @@ func TestAssessmentRepository(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, "Updated Title", updated.Title)
 			assert.Equal(t, models.AssessmentStatusSubmitted, updated.Status)
-			assert.Contains(t, updated.DueDate, "2026-05-01") // unchanged, DB returns full timestamp format
+			assert.True(t, updated.DueDate.Equal(time.Date(2026, 5, 1, 23, 59, 0, 0, time.UTC))) // unchanged
 		})
 
 		t.Run("EmptyUpdates", func(t *testing.T) {
@@ -224,6 +226,22 @@ func TestAssessmentRepository(t *testing.T) {
 			assert.Equal(t, "Updated Title", updated.Title)
 		})
 
+		t.Run("IllegalStatusChange", func(t *testing.T) {
+			updates := map[string]any{
+				"title":  "Skipped ahead",
+				"status": models.AssessmentStatusNotStarted,
+			}
+
+			_, err := assessmentRepo.UpdateAssessment(created.ID, testUser.ID, updates)
+
+			require.Error(t, err)
+			assert.Equal(t, errors.ErrorInvalidTransition, errors.ConvertError(err).Code)
+
+			unchanged, err := assessmentRepo.GetAssessmentByID(created.ID, testUser.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Updated Title", unchanged.Title)
+		})
+
 		t.Run("WrongUserID", func(t *testing.T) {
 			updates := map[string]any{"title": "Hacked"}
 
@@ -234,13 +252,67 @@ func TestAssessmentRepository(t *testing.T) {
 		})
 	})
 
+	t.Run("TransitionStatus", func(t *testing.T) {
+		assessment := &models.Assessment{
+			UserID:         testUser.ID,
+			ApplicationID:  createdApp.ID,
+			AssessmentType: models.AssessmentTypeTakeHomeProject,
+			Title:          "Transition Test",
+			DueDate:        time.Date(2026, 5, 10, 23, 59, 0, 0, time.UTC),
+		}
+		created, err := assessmentRepo.CreateAssessment(assessment)
+		require.NoError(t, err)
+
+		submissionRepo := NewAssessmentSubmissionRepository(db.Database)
+
+		t.Run("IllegalTransitionChangesNothing", func(t *testing.T) {
+			githubURL := "https://github.com/user/too-early"
+			_, _, err := assessmentRepo.TransitionStatus(created.ID, testUser.ID, models.AssessmentStatusPassed, &models.AssessmentSubmission{
+				SubmissionType: models.SubmissionTypeGithub,
+				GithubURL:      &githubURL,
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, errors.ErrorInvalidTransition, errors.ConvertError(err).Code)
+
+			submissions, err := submissionRepo.ListByAssessmentID(created.ID)
+			require.NoError(t, err)
+			assert.Empty(t, submissions)
+		})
+
+		t.Run("SubmittedWithSubmission", func(t *testing.T) {
+			githubURL := "https://github.com/user/solution"
+			updated, submission, err := assessmentRepo.TransitionStatus(created.ID, testUser.ID, models.AssessmentStatusSubmitted, &models.AssessmentSubmission{
+				SubmissionType: models.SubmissionTypeGithub,
+				GithubURL:      &githubURL,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, models.AssessmentStatusSubmitted, updated.Status)
+			require.NotNil(t, submission)
+			assert.Equal(t, created.ID, submission.AssessmentID)
+
+			submissions, err := submissionRepo.ListByAssessmentID(created.ID)
+			require.NoError(t, err)
+			require.Len(t, submissions, 1)
+			assert.Equal(t, githubURL, *submissions[0].GithubURL)
+		})
+
+		t.Run("WrongUserID", func(t *testing.T) {
+			_, _, err := assessmentRepo.TransitionStatus(created.ID, testUser2.ID, models.AssessmentStatusPassed, nil)
+
+			require.Error(t, err)
+			assert.True(t, errors.IsNotFoundError(err))
+		})
+	})
+
 	t.Run("SoftDeleteAssessment", func(t *testing.T) {
 		assessment := &models.Assessment{
 			UserID:         testUser.ID,
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeTakeHomeProject,
 			Title:          "To Delete",
-			DueDate:        "2026-06-01",
+			DueDate:        time.Date(2026, 6, 1, 23, 59, 0, 0, time.UTC),
 		}
 		created, err := assessmentRepo.CreateAssessment(assessment)
 		require.NoError(t, err)
@@ -275,7 +347,7 @@ func TestAssessmentRepository(t *testing.T) {
 				ApplicationID:  createdApp.ID,
 				AssessmentType: models.AssessmentTypeLiveCoding,
 				Title:          "Cannot Delete",
-				DueDate:        "2026-06-15",
+				DueDate:        time.Date(2026, 6, 15, 23, 59, 0, 0, time.UTC),
 			}
 			created2, err := assessmentRepo.CreateAssessment(assessment2)
 			require.NoError(t, err)
@@ -306,7 +378,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdIsolatedApp.ID,
 			AssessmentType: models.AssessmentTypeTakeHomeProject,
 			Title:          "Will Be Deleted",
-			DueDate:        "2026-07-01",
+			DueDate:        time.Date(2026, 7, 1, 23, 59, 0, 0, time.UTC),
 		}
 		created, err := assessmentRepo.CreateAssessment(assessment)
 		require.NoError(t, err)
@@ -330,7 +402,7 @@ func TestAssessmentRepository(t *testing.T) {
 			ApplicationID:  createdApp.ID,
 			AssessmentType: models.AssessmentTypeTakeHomeProject,
 			Title:          "Submission Test Assessment",
-			DueDate:        "2026-08-01",
+			DueDate:        time.Date(2026, 8, 1, 23, 59, 0, 0, time.UTC),
 		}
 		createdAssessment, err := assessmentRepo.CreateAssessment(assessment)
 		require.NoError(t, err)
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type AssessmentTimeSessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewAssessmentTimeSessionRepository(database *database.Database) *AssessmentTimeSessionRepository {
+	return &AssessmentTimeSessionRepository{
+		db: database.DB,
+	}
+}
+
+// Session times are stored in UTC so a running timer can be measured against
+// the database clock.
+const assessmentTimeSessionColumns = `
+	id, assessment_id, user_id, started_at, ended_at, is_manual, note, created_at,
+	EXTRACT(EPOCH FROM (COALESCE(ended_at, NOW() AT TIME ZONE 'UTC') - started_at))::BIGINT AS duration_seconds
+`
+
+// StartSession starts the assessment's timer. The first session moves an
+// assessment that hasn't been started to in_progress; started reports
+// whether that happened.
+func (r *AssessmentTimeSessionRepository) StartSession(assessmentID, userID uuid.UUID) (session *models.AssessmentTimeSession, started bool, err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := lockAssessment(tx, assessmentID, userID); err != nil {
+		return nil, false, err
+	}
+
+	var running bool
+	err = tx.Get(&running, `
+		SELECT EXISTS (
+			SELECT 1 FROM assessment_time_sessions
+			WHERE assessment_id = $1 AND ended_at IS NULL
+		)
+	`, assessmentID)
+	if err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+	if running {
+		return nil, false, errors.New(errors.ErrorConflict, "a timer is already running for this assessment")
+	}
+
+	session = &models.AssessmentTimeSession{}
+	err = tx.Get(session, `
+		INSERT INTO assessment_time_sessions (assessment_id, user_id, started_at, is_manual)
+		VALUES ($1, $2, $3, FALSE)
+		RETURNING `+assessmentTimeSessionColumns,
+		assessmentID, userID, time.Now().UTC())
+	if err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+
+	started, err = markAssessmentInProgress(tx, assessmentID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+
+	return session, started, nil
+}
+
+// StopSession stops the assessment's running timer.
+func (r *AssessmentTimeSessionRepository) StopSession(assessmentID, userID uuid.UUID) (*models.AssessmentTimeSession, error) {
+	query := `
+		UPDATE assessment_time_sessions
+		SET ended_at = $1
+		WHERE assessment_id = $2 AND user_id = $3 AND ended_at IS NULL
+		RETURNING ` + assessmentTimeSessionColumns
+
+	session := &models.AssessmentTimeSession{}
+	err := r.db.Get(session, query, time.Now().UTC(), assessmentID, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(errors.ErrorNotFound, "no timer is running for this assessment")
+		}
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// CreateManualSession logs time worked without the timer. Like starting the
+// timer, it moves an assessment that hasn't been started to in_progress.
+func (r *AssessmentTimeSessionRepository) CreateManualSession(session *models.AssessmentTimeSession) (*models.AssessmentTimeSession, bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := lockAssessment(tx, session.AssessmentID, session.UserID); err != nil {
+		return nil, false, err
+	}
+
+	created := &models.AssessmentTimeSession{}
+	err = tx.Get(created, `
+		INSERT INTO assessment_time_sessions (assessment_id, user_id, started_at, ended_at, is_manual, note)
+		VALUES ($1, $2, $3, $4, TRUE, $5)
+		RETURNING `+assessmentTimeSessionColumns,
+		session.AssessmentID, session.UserID, session.StartedAt.UTC(), session.EndedAt.UTC(), session.Note)
+	if err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+
+	started, err := markAssessmentInProgress(tx, session.AssessmentID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errors.ConvertError(err)
+	}
+
+	return created, started, nil
+}
+
+func (r *AssessmentTimeSessionRepository) ListByAssessmentID(assessmentID, userID uuid.UUID) ([]*models.AssessmentTimeSession, error) {
+	query := `SELECT ` + assessmentTimeSessionColumns + `
+		FROM assessment_time_sessions
+		WHERE assessment_id = $1 AND user_id = $2
+		ORDER BY started_at ASC
+	`
+
+	var sessions []*models.AssessmentTimeSession
+	err := r.db.Select(&sessions, query, assessmentID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return sessions, nil
+}
+
+func (r *AssessmentTimeSessionRepository) DeleteSession(id, assessmentID, userID uuid.UUID) error {
+	result, err := r.db.Exec(`
+		DELETE FROM assessment_time_sessions
+		WHERE id = $1 AND assessment_id = $2 AND user_id = $3
+	`, id, assessmentID, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "time session not found")
+	}
+
+	return nil
+}
+
+// Assessment outcomes for time stats. Assessments that are not yet passed
+// or failed count as pending.
+const (
+	AssessmentOutcomePassed  = "passed"
+	AssessmentOutcomeFailed  = "failed"
+	AssessmentOutcomePending = "pending"
+)
+
+// AssessmentTimeStats summarises the time spent on assessments of one type
+// that ended with one outcome. Only assessments with tracked time count.
+type AssessmentTimeStats struct {
+	AssessmentType string `json:"assessment_type" db:"assessment_type"`
+	Outcome        string `json:"outcome" db:"outcome"`
+	Assessments    int    `json:"assessments" db:"assessments"`
+	AvgSeconds     int64  `json:"avg_seconds" db:"avg_seconds"`
+	MedianSeconds  int64  `json:"median_seconds" db:"median_seconds"`
+	MinSeconds     int64  `json:"min_seconds" db:"min_seconds"`
+	MaxSeconds     int64  `json:"max_seconds" db:"max_seconds"`
+}
+
+// GetTimeStats compares time spent with outcome for each assessment type.
+func (r *AssessmentTimeSessionRepository) GetTimeStats(userID uuid.UUID) ([]*AssessmentTimeStats, error) {
+	query := `
+		WITH totals AS (
+			SELECT
+				a.id, a.assessment_type,
+				CASE WHEN a.status IN ($2, $3) THEN a.status ELSE $4 END AS outcome,
+				SUM(EXTRACT(EPOCH FROM (COALESCE(s.ended_at, NOW() AT TIME ZONE 'UTC') - s.started_at)))::BIGINT AS seconds
+			FROM assessments a
+			JOIN assessment_time_sessions s ON s.assessment_id = a.id
+			WHERE a.user_id = $1 AND a.deleted_at IS NULL
+			GROUP BY a.id
+		)
+		SELECT
+			assessment_type, outcome,
+			COUNT(*) AS assessments,
+			ROUND(AVG(seconds))::BIGINT AS avg_seconds,
+			ROUND(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY seconds))::BIGINT AS median_seconds,
+			MIN(seconds) AS min_seconds,
+			MAX(seconds) AS max_seconds
+		FROM totals
+		GROUP BY assessment_type, outcome
+		ORDER BY assessment_type, outcome
+	`
+
+	var stats []*AssessmentTimeStats
+	err := r.db.Select(&stats, query, userID,
+		models.AssessmentStatusPassed, models.AssessmentStatusFailed, AssessmentOutcomePending)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return stats, nil
+}
+
+// lockAssessment checks the assessment belongs to the user and holds it
+// until the transaction ends.
+func lockAssessment(tx *sqlx.Tx, assessmentID, userID uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.Get(&id, `
+		SELECT id FROM assessments
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, assessmentID, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return errors.New(errors.ErrorNotFound, "assessment not found or owned by other user")
+		}
+		return err
+	}
+	return nil
+}
+
+func markAssessmentInProgress(tx *sqlx.Tx, assessmentID uuid.UUID) (bool, error) {
+	result, err := tx.Exec(`
+		UPDATE assessments
+		SET status = $1, updated_at = $2
+		WHERE id = $3 AND status = $4
+	`, models.AssessmentStatusInProgress, time.Now(), assessmentID, models.AssessmentStatusNotStarted)
+	if err != nil {
+		return false, errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.ConvertError(err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAssessmentTimeSessionRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	assessmentRepo := NewAssessmentRepository(db.Database)
+	sessionRepo := NewAssessmentTimeSessionRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("timetest@example.com", "Time Test User", string(hashedPassword))
+	require.NoError(t, err)
+
+	testCompany := testutil.CreateTestCompany("Timer Co", "timerco.com")
+	createdCompany, err := companyRepo.CreateCompany(testCompany)
+	require.NoError(t, err)
+
+	testJob := testutil.CreateTestJob(createdCompany.ID, "Engineer", "Description")
+	createdJob, err := jobRepo.CreateJob(testUser.ID, testJob)
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	createdApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	assessment, err := assessmentRepo.CreateAssessment(testutil.CreateTestAssessment(testUser.ID, createdApp.ID,
+		time.Now().AddDate(0, 0, 5).Format("2006-01-02"), models.AssessmentStatusNotStarted))
+	require.NoError(t, err)
+
+	t.Run("StartSessionMovesToInProgress", func(t *testing.T) {
+		session, started, err := sessionRepo.StartSession(assessment.ID, testUser.ID)
+
+		require.NoError(t, err)
+		assert.True(t, started)
+		assert.True(t, session.IsRunning())
+		assert.False(t, session.IsManual)
+
+		updated, err := assessmentRepo.GetAssessmentByID(assessment.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AssessmentStatusInProgress, updated.Status)
+	})
+
+	t.Run("OnlyOneTimerRuns", func(t *testing.T) {
+		_, _, err := sessionRepo.StartSession(assessment.ID, testUser.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("StopSession", func(t *testing.T) {
+		session, err := sessionRepo.StopSession(assessment.ID, testUser.ID)
+
+		require.NoError(t, err)
+		assert.False(t, session.IsRunning())
+		assert.GreaterOrEqual(t, session.DurationSeconds, int64(0))
+
+		_, err = sessionRepo.StopSession(assessment.ID, testUser.ID)
+		assert.Error(t, err)
+	})
+
+	var manual *models.AssessmentTimeSession
+
+	t.Run("CreateManualSession", func(t *testing.T) {
+		startedAt := time.Now().Add(-3 * time.Hour)
+		endedAt := startedAt.Add(90 * time.Minute)
+		note := "Wrote the README"
+
+		var started bool
+		manual, started, err = sessionRepo.CreateManualSession(&models.AssessmentTimeSession{
+			AssessmentID: assessment.ID,
+			UserID:       testUser.ID,
+			StartedAt:    startedAt,
+			EndedAt:      &endedAt,
+			Note:         &note,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, started, "assessment was already in progress")
+		assert.True(t, manual.IsManual)
+		assert.Equal(t, int64(90*60), manual.DurationSeconds)
+	})
+
+	t.Run("ListByAssessmentID", func(t *testing.T) {
+		sessions, err := sessionRepo.ListByAssessmentID(assessment.ID, testUser.ID)
+
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, manual.ID, sessions[0].ID, "sessions are in the order they happened")
+	})
+
+	t.Run("GetTimeStats", func(t *testing.T) {
+		_, err := assessmentRepo.UpdateAssessment(assessment.ID, testUser.ID, map[string]any{"status": models.AssessmentStatusSubmitted})
+		require.NoError(t, err)
+		_, err = assessmentRepo.UpdateAssessment(assessment.ID, testUser.ID, map[string]any{"status": models.AssessmentStatusPassed})
+		require.NoError(t, err)
+
+		stats, err := sessionRepo.GetTimeStats(testUser.ID)
+
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, models.AssessmentTypeTakeHomeProject, stats[0].AssessmentType)
+		assert.Equal(t, AssessmentOutcomePassed, stats[0].Outcome)
+		assert.Equal(t, 1, stats[0].Assessments)
+		assert.GreaterOrEqual(t, stats[0].AvgSeconds, int64(90*60))
+	})
+
+	t.Run("DeleteSession", func(t *testing.T) {
+		require.NoError(t, sessionRepo.DeleteSession(manual.ID, assessment.ID, testUser.ID))
+		assert.Error(t, sessionRepo.DeleteSession(manual.ID, assessment.ID, testUser.ID))
+	})
+
+	t.Run("OtherUsersAssessment", func(t *testing.T) {
+		_, _, err := sessionRepo.StartSession(assessment.ID, uuid.New())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type CalendarSyncRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarSyncRepository(database *database.Database) *CalendarSyncRepository {
+	return &CalendarSyncRepository{
+		db: database.DB,
+	}
+}
+
+const calendarSyncAccountColumns = `
+	user_id, collection_url, username, password_encrypted, timezone, enabled,
+	last_synced_at, last_error, created_at, updated_at
+`
+
+const calendarSyncItemColumns = `
+	id, user_id, item_type, item_id, href, etag, local_hash, status,
+	conflict_detail, last_synced_at, created_at
+`
+
+func (r *CalendarSyncRepository) GetAccount(userID uuid.UUID) (*models.CalendarSyncAccount, error) {
+	query := `SELECT ` + calendarSyncAccountColumns + `
+		FROM calendar_sync_accounts
+		WHERE user_id = $1
+	`
+
+	var account models.CalendarSyncAccount
+	err := r.db.Get(&account, query, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(errors.ErrorNotFound, "calendar sync is not set up")
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// UpsertAccount saves the user's calendar settings. Pointing sync at a
+// different collection forgets what was synced to the old one.
+func (r *CalendarSyncRepository) UpsertAccount(account *models.CalendarSyncAccount) (*models.CalendarSyncAccount, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = tx.Exec(`
+		DELETE FROM calendar_sync_items
+		WHERE user_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM calendar_sync_accounts
+			WHERE user_id = $1 AND collection_url = $2
+		)
+	`, account.UserID, account.CollectionURL)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	query := `
+		INSERT INTO calendar_sync_accounts (
+			user_id, collection_url, username, password_encrypted, timezone, enabled
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			collection_url = EXCLUDED.collection_url,
+			username = EXCLUDED.username,
+			password_encrypted = EXCLUDED.password_encrypted,
+			timezone = EXCLUDED.timezone,
+			enabled = EXCLUDED.enabled,
+			last_error = NULL
+		RETURNING ` + calendarSyncAccountColumns
+
+	var result models.CalendarSyncAccount
+	err = tx.Get(&result, query,
+		account.UserID,
+		account.CollectionURL,
+		account.Username,
+		account.PasswordEncrypted,
+		account.Timezone,
+		account.Enabled,
+	)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return &result, nil
+}
+
+// DeleteAccount turns sync off and forgets its state. Events already in the
+// calendar are left there.
+func (r *CalendarSyncRepository) DeleteAccount(userID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec(`DELETE FROM calendar_sync_items WHERE user_id = $1`, userID); err != nil {
+		return errors.ConvertError(err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM calendar_sync_accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "calendar sync is not set up")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
+
+// ListEnabledAccounts returns every account the scheduler should sync.
+func (r *CalendarSyncRepository) ListEnabledAccounts() ([]*models.CalendarSyncAccount, error) {
+	query := `SELECT ` + calendarSyncAccountColumns + `
+		FROM calendar_sync_accounts
+		WHERE enabled = TRUE
+		ORDER BY last_synced_at ASC NULLS FIRST
+	`
+
+	var accounts []*models.CalendarSyncAccount
+	err := r.db.Select(&accounts, query)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return accounts, nil
+}
+
+// RecordSyncResult stamps the account with the time of a sync run and the
+// error it ended with, if any.
+func (r *CalendarSyncRepository) RecordSyncResult(userID uuid.UUID, syncedAt time.Time, syncErr error) error {
+	var lastError *string
+	if syncErr != nil {
+		message := syncErr.Error()
+		lastError = &message
+	}
+
+	query := `
+		UPDATE calendar_sync_accounts
+		SET last_synced_at = $1, last_error = $2
+		WHERE user_id = $3
+	`
+
+	_, err := r.db.Exec(query, syncedAt, lastError, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (r *CalendarSyncRepository) ListItems(userID uuid.UUID) ([]*models.CalendarSyncItem, error) {
+	query := `SELECT ` + calendarSyncItemColumns + `
+		FROM calendar_sync_items
+		WHERE user_id = $1
+	`
+
+	var items []*models.CalendarSyncItem
+	err := r.db.Select(&items, query, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return items, nil
+}
+
+// ListUnresolvedItems returns conflicts and calendar-side deletions that are
+// waiting for the user to choose a side.
+func (r *CalendarSyncRepository) ListUnresolvedItems(userID uuid.UUID) ([]*models.CalendarSyncItem, error) {
+	query := `SELECT ` + calendarSyncItemColumns + `
+		FROM calendar_sync_items
+		WHERE user_id = $1 AND status <> $2
+		ORDER BY last_synced_at DESC
+	`
+
+	var items []*models.CalendarSyncItem
+	err := r.db.Select(&items, query, userID, models.CalendarSyncStatusSynced)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return items, nil
+}
+
+func (r *CalendarSyncRepository) GetItem(id, userID uuid.UUID) (*models.CalendarSyncItem, error) {
+	query := `SELECT ` + calendarSyncItemColumns + `
+		FROM calendar_sync_items
+		WHERE id = $1 AND user_id = $2
+	`
+
+	var item models.CalendarSyncItem
+	err := r.db.Get(&item, query, id, userID)
+	if err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(errors.ErrorNotFound, "calendar sync item not found")
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// SaveItem records the sync state of an interview or assessment, replacing
+// whatever was recorded for it before.
+func (r *CalendarSyncRepository) SaveItem(item *models.CalendarSyncItem) (*models.CalendarSyncItem, error) {
+	query := `
+		INSERT INTO calendar_sync_items (
+			user_id, item_type, item_id, href, etag, local_hash, status, conflict_detail, last_synced_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, item_type, item_id) DO UPDATE SET
+			href = EXCLUDED.href,
+			etag = EXCLUDED.etag,
+			local_hash = EXCLUDED.local_hash,
+			status = EXCLUDED.status,
+			conflict_detail = EXCLUDED.conflict_detail,
+			last_synced_at = EXCLUDED.last_synced_at
+		RETURNING ` + calendarSyncItemColumns
+
+	var result models.CalendarSyncItem
+	err := r.db.Get(&result, query,
+		item.UserID,
+		item.ItemType,
+		item.ItemID,
+		item.Href,
+		item.ETag,
+		item.LocalHash,
+		item.Status,
+		item.ConflictDetail,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return &result, nil
+}
+
+func (r *CalendarSyncRepository) DeleteItem(id uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM calendar_sync_items WHERE id = $1`, id)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCalendarSyncRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	interviewRepo := NewInterviewRepository(db.Database)
+	syncRepo := NewCalendarSyncRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("caldavtest@example.com", "CalDAV Test User", string(hashedPassword))
+	require.NoError(t, err)
+
+	testCompany := testutil.CreateTestCompany("Calendar Co", "calendarco.com")
+	createdCompany, err := companyRepo.CreateCompany(testCompany)
+	require.NoError(t, err)
+
+	testJob := testutil.CreateTestJob(createdCompany.ID, "Software Engineer", "Build things")
+	createdJob, err := jobRepo.CreateJob(testUser.ID, testJob)
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	createdApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	t.Run("GetAccountNotSetUp", func(t *testing.T) {
+		_, err := syncRepo.GetAccount(testUser.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("UpsertAccount", func(t *testing.T) {
+		account, err := syncRepo.UpsertAccount(&models.CalendarSyncAccount{
+			UserID:            testUser.ID,
+			CollectionURL:     "http://localhost:5232/ditto/interviews/",
+			Username:          "ditto",
+			PasswordEncrypted: "sealed",
+			Timezone:          "Europe/Berlin",
+			Enabled:           true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", account.Timezone)
+
+		accounts, err := syncRepo.ListEnabledAccounts()
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		assert.Equal(t, testUser.ID, accounts[0].UserID)
+	})
+
+	var saved *models.CalendarSyncItem
+	itemID := uuid.New()
+
+	t.Run("SaveItem", func(t *testing.T) {
+		etag := `"1"`
+		saved, err = syncRepo.SaveItem(&models.CalendarSyncItem{
+			UserID:    testUser.ID,
+			ItemType:  models.CalendarItemInterview,
+			ItemID:    itemID,
+			Href:      "/ditto/interviews/ditto-interview-" + itemID.String() + ".ics",
+			ETag:      &etag,
+			LocalHash: "abc",
+			Status:    models.CalendarSyncStatusSynced,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, `"1"`, *saved.ETag)
+
+		// Saving again replaces rather than duplicating
+		detail := "Changed on both sides"
+		saved.Status = models.CalendarSyncStatusConflict
+		saved.ConflictDetail = &detail
+		_, err := syncRepo.SaveItem(saved)
+		require.NoError(t, err)
+
+		items, err := syncRepo.ListItems(testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, models.CalendarSyncStatusConflict, items[0].Status)
+	})
+
+	t.Run("ListUnresolvedItems", func(t *testing.T) {
+		items, err := syncRepo.ListUnresolvedItems(testUser.ID)
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, saved.ID, items[0].ID)
+
+		item, err := syncRepo.GetItem(saved.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Changed on both sides", *item.ConflictDetail)
+	})
+
+	t.Run("RecordSyncResult", func(t *testing.T) {
+		require.NoError(t, syncRepo.RecordSyncResult(testUser.ID, time.Now(), assert.AnError))
+
+		account, err := syncRepo.GetAccount(testUser.ID)
+		require.NoError(t, err)
+		require.NotNil(t, account.LastError)
+		assert.NotNil(t, account.LastSyncedAt)
+	})
+
+	t.Run("ChangingCollectionForgetsItems", func(t *testing.T) {
+		_, err := syncRepo.UpsertAccount(&models.CalendarSyncAccount{
+			UserID:            testUser.ID,
+			CollectionURL:     "http://localhost:5232/ditto/other/",
+			Username:          "ditto",
+			PasswordEncrypted: "sealed",
+			Timezone:          "UTC",
+			Enabled:           true,
+		})
+		require.NoError(t, err)
+
+		items, err := syncRepo.ListItems(testUser.ID)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("ListCalendarInterviewsSkipsCancelled", func(t *testing.T) {
+		scheduledTime := "10:00"
+		kept, err := interviewRepo.CreateInterview(&models.Interview{
+			UserID:        testUser.ID,
+			ApplicationID: createdApp.ID,
+			ScheduledDate: time.Now().AddDate(0, 0, 3),
+			ScheduledTime: &scheduledTime,
+			InterviewType: models.InterviewTypeTechnical,
+		})
+		require.NoError(t, err)
+
+		_, err = interviewRepo.CreateInterview(&models.Interview{
+			UserID:        testUser.ID,
+			ApplicationID: createdApp.ID,
+			ScheduledDate: time.Now().AddDate(0, 0, 4),
+			InterviewType: models.InterviewTypeOnsite,
+			Status:        models.InterviewStatusCancelled,
+		})
+		require.NoError(t, err)
+
+		interviews, err := interviewRepo.ListCalendarInterviews(testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, interviews, 1)
+		assert.Equal(t, kept.ID, interviews[0].ID)
+		assert.Equal(t, "Calendar Co", interviews[0].CompanyName)
+	})
+
+	t.Run("DeleteAccount", func(t *testing.T) {
+		require.NoError(t, syncRepo.DeleteAccount(testUser.ID))
+
+		_, err := syncRepo.GetAccount(testUser.ID)
+		assert.Error(t, err)
+
+		assert.Error(t, syncRepo.DeleteAccount(testUser.ID))
+	})
+}
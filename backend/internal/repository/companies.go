@@ -1,14 +1,15 @@
 package repository
 
 import (
+	"context"
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/companymatch"
+	"ditto-backend/internal/services/enrichment"
 	"ditto-backend/pkg/database"
 	"ditto-backend/pkg/errors"
-	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"log"
-	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
@@ -17,7 +18,8 @@ import (
 )
 
 type CompanyRepository struct {
-	db *sqlx.DB
+	db       *sqlx.DB
+	enricher enrichment.CompanyEnricher
 }
 
 type CompanyWithJobCount struct {
@@ -26,8 +28,15 @@ type CompanyWithJobCount struct {
 }
 
 func NewCompanyRepository(database *database.Database) *CompanyRepository {
+	return NewCompanyRepositoryWithEnricher(database, enrichment.Default())
+}
+
+// NewCompanyRepositoryWithEnricher lets tests swap in enrichment.Fake so no
+// external requests are made.
+func NewCompanyRepositoryWithEnricher(database *database.Database, enricher enrichment.CompanyEnricher) *CompanyRepository {
 	return &CompanyRepository{
-		db: database.DB,
+		db:       database.DB,
+		enricher: enricher,
 	}
 }
 
@@ -37,11 +46,11 @@ func (r *CompanyRepository) CreateCompany(company *models.Company) (*models.Comp
 	company.UpdatedAt = time.Now()
 
 	query := `
-        INSERT INTO companies (id, name, description, website, logo_url, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO companies (id, name, description, website, logo_url, domain, last_enriched_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
     `
 
-	_, err := r.db.Exec(query, company.ID, company.Name, company.Description, company.Website, company.LogoURL, company.CreatedAt, company.UpdatedAt)
+	_, err := r.db.Exec(query, company.ID, company.Name, company.Description, company.Website, company.LogoURL, company.Domain, company.LastEnrichedAt, company.CreatedAt, company.UpdatedAt)
 	if err != nil {
 		return nil, errors.ConvertError(err)
 	}
@@ -227,6 +236,13 @@ func (r *CompanyRepository) GetOrCreateCompany(name string, enrichmentData *mode
 		return existing, nil
 	}
 
+	if enrichmentData != nil && enrichmentData.Domain != "" {
+		existing, err := r.FindCompanyByDomain(enrichmentData.Domain)
+		if err == nil && existing != nil {
+			return existing, nil
+		}
+	}
+
 	company := &models.Company{
 		Name:      strings.TrimSpace(name),
 		CreatedAt: time.Now(),
@@ -296,30 +312,57 @@ func (r *CompanyRepository) FindCompanyByNameFuzzy(name string) (*models.Company
 		return company, nil
 	}
 
+	normalized := companymatch.NormalizeName(name)
+	if normalized == "" {
+		return nil, errors.ConvertError(err)
+	}
+
+	if aliased, aliasErr := r.findCompanyByAlias(normalized); aliasErr == nil {
+		return aliased, nil
+	}
+
+	if match, matchErr := r.findCompanyByNormalizedName(normalized); matchErr == nil {
+		return match, nil
+	}
+
 	return nil, errors.ConvertError(err)
 }
 
 func (r *CompanyRepository) AutocompleteCompanies(input string, limit int) ([]*models.CompanySuggestion, error) {
+	return r.AutocompleteCompaniesForUser(input, limit, uuid.Nil)
+}
+
+// AutocompleteCompaniesForUser ranks matches using the user's private company
+// profiles: after an exact name match, target companies come first (highest
+// priority first) and companies flagged "do not apply" sink to the bottom.
+// Pass uuid.Nil for anonymous requests.
+func (r *CompanyRepository) AutocompleteCompaniesForUser(input string, limit int, userID uuid.UUID) ([]*models.CompanySuggestion, error) {
 	if limit < 0 {
 		limit = 10
 	}
 
 	query := `
-        SELECT id, name, domain, logo_url, website
-        FROM companies
-        WHERE (name ILIKE $1 OR domain ILIKE $1)
-        AND deleted_at IS NULL
+        SELECT c.id, c.name, c.domain, c.logo_url, c.website,
+            COALESCE(p.is_target, false) as is_target, p.priority,
+            COALESCE(p.do_not_apply, false) as do_not_apply
+        FROM companies c
+        LEFT JOIN user_company_profiles p ON p.company_id = c.id AND p.user_id = $4
+        WHERE (c.name ILIKE $1 OR c.domain ILIKE $1)
+        AND c.deleted_at IS NULL
         ORDER BY
-            CASE WHEN LOWER(name) = LOWER($2) THEN 1 ELSE 2 END,
-            length(name),
-            name
+            CASE WHEN LOWER(c.name) = LOWER($2) THEN 1 ELSE 2 END,
+            CASE WHEN p.do_not_apply THEN 1 ELSE 0 END,
+            CASE WHEN p.is_target THEN 0 ELSE 1 END,
+            p.priority DESC NULLS LAST,
+            length(c.name),
+            c.name
         LIMIT $3
     `
 
 	searchTerm := "%" + strings.TrimSpace(input) + "%"
 	exactTerm := strings.TrimSpace(input)
 
-	rows, err := r.db.Query(query, searchTerm, exactTerm, limit)
+	rows, err := r.db.Query(query, searchTerm, exactTerm, limit, userID)
 	if err != nil {
 		return nil, errors.ConvertError(err)
 	}
@@ -330,156 +373,120 @@ func (r *CompanyRepository) AutocompleteCompanies(input string, limit int) ([]*m
 		var id uuid.UUID
 		var name string
 		var domain, logoURL, website *string
+		var isTarget, doNotApply bool
+		var priority *int
 
-		err := rows.Scan(&id, &name, &domain, &logoURL, &website)
+		err := rows.Scan(&id, &name, &domain, &logoURL, &website, &isTarget, &priority, &doNotApply)
 		if err != nil {
 			return nil, errors.ConvertError(err)
 		}
 
 		suggestions = append(suggestions, &models.CompanySuggestion{
-			ID:      &id,
-			Name:    name,
-			Domain:  domain,
-			LogoURL: logoURL,
-			Website: website,
-			Source:  "Saved",
+			ID:         &id,
+			Name:       name,
+			Domain:     domain,
+			LogoURL:    logoURL,
+			Website:    website,
+			Source:     "Saved",
+			IsTarget:   isTarget,
+			Priority:   priority,
+			DoNotApply: doNotApply,
 		})
 	}
 
 	return suggestions, nil
 }
 
+// EnrichCompanyAsync looks up public metadata for a newly created company. It
+// runs detached from the request, so it uses its own timeout.
 func (r *CompanyRepository) EnrichCompanyAsync(companyID uuid.UUID, name string) {
-	enrichmentData, err := r.fetchEnrichmentData(name)
-	if err != nil {
-		log.Printf("Failed to enrich company %s: %v", name, err)
-		return
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	updates := map[string]any{
-		"last_enriched_at": time.Now(),
+	company := &models.Company{ID: companyID, Name: name}
+	if _, err := r.EnrichCompany(ctx, company); err != nil {
+		log.Printf("Failed to enrich company %s: %v", name, err)
 	}
+}
 
-	if enrichmentData.Domain != "" {
-		updates["domain"] = enrichmentData.Domain
+// EnrichCompany runs the enricher for a company and stores any new fields.
+// Fields already set on the company are kept. last_enriched_at is always
+// bumped, even when nothing is found, so the re-enrichment job doesn't retry
+// unknown companies on every run.
+func (r *CompanyRepository) EnrichCompany(ctx context.Context, company *models.Company) (*models.Company, error) {
+	known := &models.CompanyEnrichmentData{}
+	if company.Domain != nil {
+		known.Domain = *company.Domain
 	}
-
-	if enrichmentData.LogoURL != "" {
-		updates["logo_url"] = enrichmentData.LogoURL
+	if company.Website != nil {
+		known.Website = *company.Website
 	}
-
-	if enrichmentData.Website != "" {
-		updates["website"] = enrichmentData.Website
+	if company.LogoURL != nil {
+		known.LogoURL = *company.LogoURL
 	}
 
-	_, err = r.UpdateCompany(companyID, updates)
-	if err != nil {
-		log.Printf("Failed to update enriched company %s: %v", name, err)
-	}
-}
-
-func (r *CompanyRepository) fetchEnrichmentData(name string) (*models.CompanyEnrichmentData, error) {
-	client := &http.Client{Timeout: 5 * time.Second}
-
-	clearoutURL := fmt.Sprintf("https://api.clearout.io/public/companies/autocomplete?query=%s",
-		url.QueryEscape(name))
-
-	response, err := client.Get(clearoutURL)
-	if err != nil {
+	enrichmentData, err := r.enricher.Enrich(ctx, company.Name, known)
+	if err != nil && !stderrors.Is(err, enrichment.ErrNoData) {
 		return nil, err
 	}
 
-	defer response.Body.Close()
-
-	var clearoutResponse struct {
-		Data []struct {
-			Name    string `json:"name"`
-			Domain  string `json:"domain"`
-			LogoURL string `json:"logo_url"`
-			Website string `json:"website"`
-		} `json:"data"`
-	}
-
-	if err := json.NewDecoder(response.Body).Decode(&clearoutResponse); err != nil {
-		return nil, err
+	updates := map[string]any{
+		"last_enriched_at": time.Now(),
 	}
 
-	trimmedName := strings.TrimSpace(name)
+	if enrichmentData != nil {
+		if enrichmentData.Domain != "" && known.Domain == "" {
+			updates["domain"] = enrichmentData.Domain
+		}
 
-	for _, item := range clearoutResponse.Data {
-		if strings.EqualFold(strings.TrimSpace(item.Name), trimmedName) {
-			return &models.CompanyEnrichmentData{
-				Domain:   item.Domain,
-				LogoURL:  item.LogoURL,
-				Website:  item.Website,
-				Verified: true,
-			}, nil
+		if enrichmentData.LogoURL != "" && known.LogoURL == "" {
+			updates["logo_url"] = enrichmentData.LogoURL
 		}
-	}
 
-	if len(clearoutResponse.Data) > 0 {
-		item := clearoutResponse.Data[0]
-		return &models.CompanyEnrichmentData{
-			Domain:   item.Domain,
-			LogoURL:  item.LogoURL,
-			Website:  item.Website,
-			Verified: false,
-		}, nil
+		if enrichmentData.Website != "" && known.Website == "" {
+			updates["website"] = enrichmentData.Website
+		}
 	}
 
-	return nil, fmt.Errorf("no enrichment data found")
+	return r.UpdateCompany(company.ID, updates)
 }
 
-func (c *CompanyRepository) FetchExternalSuggestions(input string, limit int) ([]*models.CompanySuggestion, error) {
-	client := &http.Client{Timeout: 3 * time.Second}
+// GetCompaniesDueForEnrichment returns companies that have never been enriched
+// or were last enriched before the given time, oldest first.
+func (r *CompanyRepository) GetCompaniesDueForEnrichment(enrichedBefore time.Time, limit int) ([]*models.Company, error) {
+	if limit <= 0 {
+		limit = 50
+	}
 
-	clearoutURL := fmt.Sprintf("https://api.clearout.io/public/companies/autocomplete?query=%s",
-		url.QueryEscape(input))
+	query := `
+        SELECT id, name, description, website, logo_url, domain, last_enriched_at, opencorp_id, created_at, updated_at
+        FROM companies
+        WHERE deleted_at IS NULL
+        AND (last_enriched_at IS NULL OR last_enriched_at < $1)
+        ORDER BY last_enriched_at ASC NULLS FIRST, created_at ASC
+        LIMIT $2
+    `
 
-	resp, err := client.Get(clearoutURL)
+	var companies []*models.Company
+	err := r.db.Select(&companies, query, enrichedBefore, limit)
 	if err != nil {
-		return []*models.CompanySuggestion{}, nil
+		return nil, errors.ConvertError(err)
 	}
-	defer resp.Body.Close()
 
-	var clearoutResponse struct {
-		Data []struct {
-			Name    string `json:"name"`
-			Domain  string `json:"domain"`
-			LogoURL string `json:"logo_url"`
-			Website string `json:"website"`
-		} `json:"data"`
-	}
+	return companies, nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&clearoutResponse); err != nil {
+// FetchExternalSuggestions returns autocomplete suggestions from the enricher.
+// Provider failures are not surfaced; local suggestions are still useful.
+func (r *CompanyRepository) FetchExternalSuggestions(input string, limit int) ([]*models.CompanySuggestion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	suggestions, err := r.enricher.Suggest(ctx, input, limit)
+	if err != nil {
+		log.Printf("Failed to fetch company suggestions for %s: %v", input, err)
 		return []*models.CompanySuggestion{}, nil
 	}
 
-	var suggestions []*models.CompanySuggestion
-
-	for i, item := range clearoutResponse.Data {
-		if i >= limit {
-			break
-		}
-
-		suggestion := &models.CompanySuggestion{
-			ID:     nil,
-			Name:   item.Name,
-			Source: "suggestion",
-		}
-
-		if item.Domain != "" {
-			suggestion.Domain = &item.Domain
-		}
-		if item.LogoURL != "" {
-			suggestion.LogoURL = &item.LogoURL
-		}
-		if item.Website != "" {
-			suggestion.Website = &item.Website
-		}
-
-		suggestions = append(suggestions, suggestion)
-
-	}
 	return suggestions, nil
 }
@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/companymatch"
+	"ditto-backend/pkg/errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const companyColumns = `id, name, description, website, logo_url, domain, last_enriched_at, opencorp_id, created_at, updated_at`
+
+// normalizedNameCandidateLimit bounds how many first-word matches are compared
+// in Go when looking a company up by normalized name
+const normalizedNameCandidateLimit = 50
+
+type CompanyMergeResult struct {
+	Company          *models.Company `json:"company"`
+	MergedCompanyIDs []uuid.UUID     `json:"merged_company_ids"`
+	JobsMoved        int64           `json:"jobs_moved"`
+}
+
+// FindCompanyByDomain returns the live company with the given domain.
+func (r *CompanyRepository) FindCompanyByDomain(domain string) (*models.Company, error) {
+	normalized := companymatch.NormalizeDomain(domain)
+	if normalized == "" {
+		return nil, errors.New(errors.ErrorNotFound, "company not found")
+	}
+
+	query := `SELECT ` + companyColumns + `
+        FROM companies
+        WHERE LOWER(domain) IN ($1, $2)
+        AND deleted_at IS NULL
+        ORDER BY created_at ASC
+        LIMIT 1
+    `
+
+	company := &models.Company{}
+	err := r.db.Get(company, query, normalized, "www."+normalized)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return company, nil
+}
+
+func (r *CompanyRepository) findCompanyByAlias(normalizedName string) (*models.Company, error) {
+	query := `
+        SELECT c.id, c.name, c.description, c.website, c.logo_url, c.domain, c.last_enriched_at, c.opencorp_id, c.created_at, c.updated_at
+        FROM company_aliases a
+        JOIN companies c ON a.company_id = c.id
+        WHERE a.normalized_name = $1
+        AND c.deleted_at IS NULL
+    `
+
+	company := &models.Company{}
+	err := r.db.Get(company, query, normalizedName)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return company, nil
+}
+
+// findCompanyByNormalizedName compares normalized names in Go, since the
+// normalization rules (legal suffixes, punctuation) don't exist in SQL.
+// Candidates are narrowed to names whose first word, after an optional "the",
+// is the whole first word of the normalized name, so "Meta" doesn't pull in
+// every "Metamorphic Inc". Normalized words are letters and digits only, so
+// they are safe to put in a pattern.
+func (r *CompanyRepository) findCompanyByNormalizedName(normalizedName string) (*models.Company, error) {
+	firstWord := strings.Fields(normalizedName)[0]
+
+	query := `SELECT ` + companyColumns + `
+        FROM companies
+        WHERE regexp_replace(LOWER(name), '[.'']', '', 'g') ~ ('^[^[:alnum:]]*(the[^[:alnum:]]+)?' || $1 || '([^[:alnum:]]|$)')
+        AND deleted_at IS NULL
+        ORDER BY created_at ASC
+        LIMIT $2
+    `
+
+	var candidates []*models.Company
+	err := r.db.Select(&candidates, query, firstWord, normalizedNameCandidateLimit)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	for _, candidate := range candidates {
+		if companymatch.NormalizeName(candidate.Name) == normalizedName {
+			return candidate, nil
+		}
+	}
+
+	return nil, errors.New(errors.ErrorNotFound, "company not found")
+}
+
+// GetDedupCandidates returns every live company with its job count, for
+// duplicate detection.
+func (r *CompanyRepository) GetDedupCandidates() ([]companymatch.Candidate, error) {
+	query := `
+        SELECT c.id, c.name, c.domain, COUNT(j.id) as job_count
+        FROM companies c
+        LEFT JOIN jobs j ON j.company_id = c.id AND j.deleted_at IS NULL
+        WHERE c.deleted_at IS NULL
+        GROUP BY c.id, c.name, c.domain
+        ORDER BY c.name ASC
+    `
+
+	var candidates []companymatch.Candidate
+	err := r.db.Select(&candidates, query)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return candidates, nil
+}
+
+// MergeCompanies folds duplicates into the surviving company in one
+// transaction: jobs and private profiles are repointed, empty fields on the
+// survivor are filled from the duplicates, each duplicate's name becomes an
+// alias of the survivor, and the duplicates are soft deleted.
+func (r *CompanyRepository) MergeCompanies(survivorID uuid.UUID, duplicateIDs []uuid.UUID) (*CompanyMergeResult, error) {
+	seen := map[uuid.UUID]bool{}
+	dupIDs := make([]uuid.UUID, 0, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		if id == survivorID {
+			return nil, errors.New(errors.ErrorBadRequest, "a company cannot be merged into itself")
+		}
+		if !seen[id] {
+			seen[id] = true
+			dupIDs = append(dupIDs, id)
+		}
+	}
+	if len(dupIDs) == 0 {
+		return nil, errors.New(errors.ErrorBadRequest, "at least one duplicate company is required")
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	lockQuery := `
+        SELECT id, name
+        FROM companies
+        WHERE id = ANY($1) AND deleted_at IS NULL
+        FOR UPDATE
+    `
+
+	var locked []struct {
+		ID   uuid.UUID `db:"id"`
+		Name string    `db:"name"`
+	}
+	allIDs := append([]uuid.UUID{survivorID}, dupIDs...)
+	if err := tx.Select(&locked, lockQuery, pq.Array(allIDs)); err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	if len(locked) != len(allIDs) {
+		return nil, errors.New(errors.ErrorNotFound, "company not found")
+	}
+
+	now := time.Now()
+
+	result, err := tx.Exec(`
+        UPDATE jobs
+        SET company_id = $1, updated_at = $3
+        WHERE company_id = ANY($2)
+    `, survivorID, pq.Array(dupIDs), now)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	jobsMoved, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	// A user keeps their survivor profile if they have one; otherwise their
+	// most recently updated duplicate profile moves over.
+	_, err = tx.Exec(`
+        INSERT INTO user_company_profiles (
+            user_id, company_id, notes, is_target, priority, culture_rating,
+            tech_stack, headcount, funding_stage, do_not_apply
+        )
+        SELECT DISTINCT ON (user_id)
+            user_id, $1, notes, is_target, priority, culture_rating,
+            tech_stack, headcount, funding_stage, do_not_apply
+        FROM user_company_profiles
+        WHERE company_id = ANY($2)
+        ORDER BY user_id, updated_at DESC
+        ON CONFLICT (user_id, company_id) DO NOTHING
+    `, survivorID, pq.Array(dupIDs))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_company_profiles WHERE company_id = ANY($1)`, pq.Array(dupIDs))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	fillQuery := `
+        UPDATE companies
+        SET %[1]s = COALESCE(NULLIF(%[1]s, ''), (
+            SELECT d.%[1]s FROM companies d
+            WHERE d.id = ANY($2) AND NULLIF(d.%[1]s, '') IS NOT NULL
+            LIMIT 1
+        ))
+        WHERE id = $1
+    `
+	for _, column := range []string{"description", "website", "logo_url", "domain"} {
+		if _, err := tx.Exec(fmt.Sprintf(fillQuery, column), survivorID, pq.Array(dupIDs)); err != nil {
+			return nil, errors.ConvertError(err)
+		}
+	}
+
+	_, err = tx.Exec(`
+        UPDATE company_aliases
+        SET company_id = $1
+        WHERE company_id = ANY($2)
+    `, survivorID, pq.Array(dupIDs))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	aliasQuery := `
+        INSERT INTO company_aliases (company_id, alias_name, normalized_name, merged_company_id)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (normalized_name) DO UPDATE SET
+            company_id = EXCLUDED.company_id,
+            alias_name = EXCLUDED.alias_name,
+            merged_company_id = EXCLUDED.merged_company_id
+    `
+	for _, company := range locked {
+		if company.ID == survivorID {
+			continue
+		}
+		normalized := companymatch.NormalizeName(company.Name)
+		if normalized == "" {
+			continue
+		}
+		if _, err := tx.Exec(aliasQuery, survivorID, company.Name, normalized, company.ID); err != nil {
+			return nil, errors.ConvertError(err)
+		}
+	}
+
+	_, err = tx.Exec(`
+        UPDATE companies
+        SET deleted_at = $2, updated_at = $2
+        WHERE id = ANY($1)
+    `, pq.Array(dupIDs), now)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	survivor, err := r.GetCompanyByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompanyMergeResult{
+		Company:          survivor,
+		MergedCompanyIDs: dupIDs,
+		JobsMoved:        jobsMoved,
+	}, nil
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/enrichment"
+	"ditto-backend/internal/testutil"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCompanyMerge(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepositoryWithEnricher(db.Database, enrichment.NewFake())
+	jobRepo := NewJobRepository(db.Database)
+	profileRepo := NewUserCompanyProfileRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	user, err := userRepo.CreateUser("merge@example.com", "Merge User", string(hashedPassword))
+	require.NoError(t, err)
+
+	domain := "globex.com"
+	survivor, err := companyRepo.CreateCompany(&models.Company{Name: "Globex"})
+	require.NoError(t, err)
+	dupLLC, err := companyRepo.CreateCompany(&models.Company{Name: "Globex LLC", Domain: &domain})
+	require.NoError(t, err)
+	dupInc, err := companyRepo.CreateCompany(&models.Company{Name: "globex inc."})
+	require.NoError(t, err)
+
+	_, err = jobRepo.CreateJob(user.ID, testutil.CreateTestJob(dupLLC.ID, "Engineer", "desc"))
+	require.NoError(t, err)
+	_, err = jobRepo.CreateJob(user.ID, testutil.CreateTestJob(dupInc.ID, "Designer", "desc"))
+	require.NoError(t, err)
+
+	_, err = profileRepo.Upsert(&models.UserCompanyProfile{UserID: user.ID, CompanyID: dupLLC.ID, IsTarget: true})
+	require.NoError(t, err)
+
+	t.Run("FindCompanyByNameFuzzy_NormalizedName", func(t *testing.T) {
+		company, err := companyRepo.FindCompanyByNameFuzzy("The Globex Corporation")
+		require.NoError(t, err)
+		assert.Contains(t, []uuid.UUID{survivor.ID, dupLLC.ID, dupInc.ID}, company.ID)
+	})
+
+	t.Run("FindCompanyByNameFuzzy_WholeFirstWord", func(t *testing.T) {
+		for i := 0; i < normalizedNameCandidateLimit; i++ {
+			_, err := companyRepo.CreateCompany(&models.Company{Name: fmt.Sprintf("Metamorphic %d Inc", i)})
+			require.NoError(t, err)
+		}
+		meta, err := companyRepo.CreateCompany(&models.Company{Name: "Meta, Inc."})
+		require.NoError(t, err)
+
+		company, err := companyRepo.FindCompanyByNameFuzzy("Meta")
+		require.NoError(t, err)
+		assert.Equal(t, meta.ID, company.ID, "older companies sharing the prefix don't crowd it out")
+
+		_, err = companyRepo.FindCompanyByNameFuzzy("Metamorphic")
+		assert.Error(t, err)
+	})
+
+	t.Run("GetDedupCandidates", func(t *testing.T) {
+		candidates, err := companyRepo.GetDedupCandidates()
+		require.NoError(t, err)
+
+		counts := map[uuid.UUID]int{}
+		for _, c := range candidates {
+			counts[c.ID] = c.JobCount
+		}
+		assert.Equal(t, 1, counts[dupLLC.ID])
+		assert.Equal(t, 0, counts[survivor.ID])
+	})
+
+	t.Run("RejectsSelfMerge", func(t *testing.T) {
+		_, err := companyRepo.MergeCompanies(survivor.ID, []uuid.UUID{survivor.ID})
+		assert.Error(t, err)
+	})
+
+	t.Run("RejectsUnknownCompany", func(t *testing.T) {
+		_, err := companyRepo.MergeCompanies(survivor.ID, []uuid.UUID{uuid.New()})
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeCompanies", func(t *testing.T) {
+		result, err := companyRepo.MergeCompanies(survivor.ID, []uuid.UUID{dupLLC.ID, dupInc.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.JobsMoved)
+
+		var jobCount int
+		require.NoError(t, db.Get(&jobCount, "SELECT COUNT(*) FROM jobs WHERE company_id = $1", survivor.ID))
+		assert.Equal(t, 2, jobCount)
+
+		_, err = companyRepo.GetCompanyByID(dupLLC.ID)
+		assert.Error(t, err, "duplicates are soft deleted")
+
+		merged, err := companyRepo.FindCompanyByDomain("www.globex.com")
+		require.NoError(t, err)
+		assert.Equal(t, survivor.ID, merged.ID, "survivor takes the duplicate's domain")
+
+		profile, err := profileRepo.GetByCompanyID(user.ID, survivor.ID)
+		require.NoError(t, err)
+		assert.True(t, profile.IsTarget, "private profiles move to the survivor")
+	})
+
+	t.Run("AliasesResolveToSurvivor", func(t *testing.T) {
+		company, err := companyRepo.GetOrCreateCompany("Globex, Inc.", nil)
+		require.NoError(t, err)
+		assert.Equal(t, survivor.ID, company.ID)
+
+		var aliasCount int
+		require.NoError(t, db.Get(&aliasCount, "SELECT COUNT(*) FROM company_aliases WHERE company_id = $1", survivor.ID))
+		assert.Equal(t, 1, aliasCount, "both duplicates normalize to the same alias")
+	})
+}
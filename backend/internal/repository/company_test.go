@@ -1,9 +1,12 @@
 package repository
 
 import (
+	"context"
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/enrichment"
 	"ditto-backend/internal/testutil"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -15,7 +18,8 @@ func TestCompanyRepository(t *testing.T) {
 	defer db.Close(t)
 	db.RunMigrations(t)
 
-	repo := NewCompanyRepository(db.Database)
+	enricher := enrichment.NewFake()
+	repo := NewCompanyRepositoryWithEnricher(db.Database, enricher)
 
 	t.Run("CreateCompany", func(t *testing.T) {
 		company := &models.Company{
@@ -130,6 +134,87 @@ func TestCompanyRepository(t *testing.T) {
 		require.Equal(t, "New Company", company.Name)
 	})
 
+	t.Run("GetOrCreateCompany_WithEnrichmentData", func(t *testing.T) {
+		company, err := repo.GetOrCreateCompany("Enriched Upfront", &models.CompanyEnrichmentData{Domain: "upfront.com"})
+		require.NoError(t, err)
+
+		fetched, err := repo.FindCompanyByNameFuzzy("Enriched Upfront")
+		require.NoError(t, err)
+		require.Equal(t, company.ID, fetched.ID)
+		require.NotNil(t, fetched.Domain)
+		require.Equal(t, "upfront.com", *fetched.Domain)
+		require.NotNil(t, fetched.LastEnrichedAt)
+	})
+
+	t.Run("EnrichCompany", func(t *testing.T) {
+		enricher.Data["enrich me"] = &models.CompanyEnrichmentData{
+			Domain:  "enrichme.com",
+			Website: "https://enrichme.com",
+			LogoURL: "https://enrichme.com/logo.png",
+		}
+
+		website := "https://careers.enrichme.com"
+		company, err := repo.CreateCompany(&models.Company{Name: "Enrich Me", Website: &website})
+		require.NoError(t, err)
+
+		enriched, err := repo.EnrichCompany(context.Background(), company)
+		require.NoError(t, err)
+
+		fetched, err := repo.FindCompanyByNameFuzzy("Enrich Me")
+		require.NoError(t, err)
+		require.Equal(t, enriched.ID, fetched.ID)
+		require.Equal(t, "enrichme.com", *fetched.Domain)
+		require.Equal(t, website, *fetched.Website, "existing fields are kept")
+		require.NotNil(t, fetched.LastEnrichedAt)
+
+		t.Run("NoData", func(t *testing.T) {
+			company, err := repo.CreateCompany(&models.Company{Name: "Unknown To Enricher"})
+			require.NoError(t, err)
+
+			_, err = repo.EnrichCompany(context.Background(), company)
+			require.NoError(t, err)
+
+			fetched, err := repo.FindCompanyByNameFuzzy("Unknown To Enricher")
+			require.NoError(t, err)
+			require.Nil(t, fetched.Domain)
+			require.NotNil(t, fetched.LastEnrichedAt)
+		})
+	})
+
+	t.Run("GetCompaniesDueForEnrichment", func(t *testing.T) {
+		stale, err := repo.CreateCompany(&models.Company{Name: "Stale Enrichment"})
+		require.NoError(t, err)
+
+		due, err := repo.GetCompaniesDueForEnrichment(time.Now(), 1000)
+		require.NoError(t, err)
+
+		found := false
+		for _, c := range due {
+			if c.ID == stale.ID {
+				found = true
+			}
+		}
+		require.True(t, found)
+
+		due, err = repo.GetCompaniesDueForEnrichment(time.Now().Add(-time.Hour), 1000)
+		require.NoError(t, err)
+		for _, c := range due {
+			require.Nil(t, c.LastEnrichedAt, "recently enriched companies are not due")
+		}
+	})
+
+	t.Run("FetchExternalSuggestions", func(t *testing.T) {
+		enricher.Suggestions["ext"] = []*models.CompanySuggestion{
+			{Name: "External One", Source: "suggestion"},
+			{Name: "External Two", Source: "suggestion"},
+		}
+
+		suggestions, err := repo.FetchExternalSuggestions("Ext", 1)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, "External One", suggestions[0].Name)
+	})
+
 	t.Run("UpdateCompany", func(t *testing.T) {
 		// Create a company first
 		company, err := repo.CreateCompany(&models.Company{Name: "Update Me"})
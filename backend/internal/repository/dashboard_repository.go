@@ -14,9 +14,10 @@ import (
 )
 
 type DashboardRepository struct {
-	db         *sqlx.DB
-	statsCache map[uuid.UUID]*cachedStats
-	cacheMu    sync.RWMutex
+	db           *sqlx.DB
+	practiceRepo *PracticeRepository
+	statsCache   map[uuid.UUID]*cachedStats
+	cacheMu      sync.RWMutex
 }
 
 type cachedStats struct {
@@ -35,8 +36,9 @@ type DashboardStats struct {
 
 func NewDashboardRepository(database *database.Database) *DashboardRepository {
 	return &DashboardRepository{
-		db:         database.DB,
-		statsCache: make(map[uuid.UUID]*cachedStats),
+		db:           database.DB,
+		practiceRepo: NewPracticeRepository(database),
+		statsCache:   make(map[uuid.UUID]*cachedStats),
 	}
 }
 
@@ -141,7 +143,8 @@ const (
 	UrgencyScheduled = "scheduled"
 )
 
-// UpcomingItem represents an interview or assessment with countdown info
+// UpcomingItem represents an interview or assessment with countdown info.
+// Practice questions due today are rolled up into a single "practice" item.
 type UpcomingItem struct {
 	ID            uuid.UUID     `json:"id" db:"id"`
 	Type          string        `json:"type" db:"item_type"`
@@ -158,6 +161,8 @@ type CountdownInfo struct {
 	Text      string `json:"text"`
 	Urgency   string `json:"urgency"`
 	DaysUntil int    `json:"days_until"`
+	// HoursUntil is set for deadlines less than a day away, either side of now.
+	HoursUntil *int `json:"hours_until,omitempty"`
 }
 
 type upcomingItemRow struct {
@@ -170,7 +175,8 @@ type upcomingItemRow struct {
 	ApplicationID uuid.UUID `db:"application_id"`
 }
 
-// GetUpcomingItems returns upcoming interviews and assessments for a user
+// GetUpcomingItems returns upcoming interviews and assessments for a user,
+// plus a summary of practice questions due today
 func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, itemType string) ([]UpcomingItem, error) {
 	if limit <= 0 {
 		limit = 4
@@ -207,7 +213,7 @@ func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, item
 			ass.title,
 			c.name as company_name,
 			j.title as job_title,
-			ass.due_date::timestamp as due_date,
+			ass.due_date,
 			ass.application_id
 		FROM assessments ass
 		JOIN applications a ON ass.application_id = a.id
@@ -244,6 +250,9 @@ func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, item
 		`, baseAssessmentQuery)
 		args = []any{userID, models.AssessmentStatusSubmitted, limit}
 
+	case "practice":
+		// Only the practice summary below
+
 	default: // "all" or empty
 		query = fmt.Sprintf(`
 			WITH items AS (
@@ -262,9 +271,11 @@ func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, item
 	}
 
 	var rows []upcomingItemRow
-	err := r.db.Select(&rows, query, args...)
-	if err != nil {
-		return nil, errors.ConvertError(err)
+	if query != "" {
+		err := r.db.Select(&rows, query, args...)
+		if err != nil {
+			return nil, errors.ConvertError(err)
+		}
 	}
 
 	items := make([]UpcomingItem, len(rows))
@@ -272,7 +283,7 @@ func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, item
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	for i, row := range rows {
-		countdown := calculateCountdown(row.DueDate, today)
+		countdown := itemCountdown(row.ItemType, row.DueDate, now, today)
 		link := buildItemLink(row.ItemType, row.ID, row.ApplicationID)
 
 		items[i] = UpcomingItem{
@@ -288,9 +299,53 @@ func (r *DashboardRepository) GetUpcomingItems(userID uuid.UUID, limit int, item
 		}
 	}
 
+	if itemType == "practice" || itemType == "all" || itemType == "" {
+		dueCount, err := r.practiceRepo.CountDue(userID, today.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		if dueCount > 0 {
+			items = insertPracticeItem(items, practiceUpcomingItem(dueCount, today), limit)
+		}
+	}
+
 	return items, nil
 }
 
+func practiceUpcomingItem(dueCount int, today time.Time) UpcomingItem {
+	title := fmt.Sprintf("%d practice questions due", dueCount)
+	if dueCount == 1 {
+		title = "1 practice question due"
+	}
+
+	return UpcomingItem{
+		Type:      "practice",
+		Title:     title,
+		DueDate:   today,
+		Countdown: calculateCountdown(today, today),
+		Link:      "/practice",
+	}
+}
+
+// insertPracticeItem places the practice item after overdue items and before
+// everything else, keeping at most limit items.
+func insertPracticeItem(items []UpcomingItem, item UpcomingItem, limit int) []UpcomingItem {
+	position := 0
+	for position < len(items) && items[position].Countdown.Urgency == UrgencyOverdue {
+		position++
+	}
+
+	result := make([]UpcomingItem, 0, len(items)+1)
+	result = append(result, items[:position]...)
+	result = append(result, item)
+	result = append(result, items[position:]...)
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
 // CalculateCountdown computes countdown info for a given due date (exported for testing)
 func CalculateCountdown(dueDate time.Time, today time.Time) CountdownInfo {
 	return calculateCountdown(dueDate, today)
@@ -333,6 +388,55 @@ func calculateCountdown(dueDate time.Time, today time.Time) CountdownInfo {
 	}
 }
 
+// itemCountdown computes the countdown for an upcoming row. Assessment
+// deadlines have a time of day, so they count down in hours on the last day.
+func itemCountdown(itemType string, dueDate time.Time, now time.Time, today time.Time) CountdownInfo {
+	if itemType == "assessment" {
+		return calculateDeadlineCountdown(dueDate, now, today)
+	}
+	return calculateCountdown(dueDate, today)
+}
+
+// calculateDeadlineCountdown is calculateCountdown for an exact instant. Within
+// a day of the deadline the text switches to hours, and a deadline that passed
+// earlier today is already overdue.
+func calculateDeadlineCountdown(dueDate time.Time, now time.Time, today time.Time) CountdownInfo {
+	countdown := calculateCountdown(dueDate.In(today.Location()), today)
+
+	remaining := dueDate.Sub(now)
+	if remaining >= 24*time.Hour || remaining <= -24*time.Hour {
+		return countdown
+	}
+
+	hours := int(remaining.Hours())
+	countdown.HoursUntil = &hours
+
+	switch {
+	case remaining < 0:
+		countdown.Urgency = UrgencyOverdue
+		switch hours {
+		case 0:
+			countdown.Text = "Under an hour overdue"
+		case -1:
+			countdown.Text = "1 hour overdue"
+		default:
+			countdown.Text = fmt.Sprintf("%d hours overdue", -hours)
+		}
+	default:
+		countdown.Urgency = UrgencyToday
+		switch hours {
+		case 0:
+			countdown.Text = "In under an hour"
+		case 1:
+			countdown.Text = "In 1 hour"
+		default:
+			countdown.Text = fmt.Sprintf("In %d hours", hours)
+		}
+	}
+
+	return countdown
+}
+
 func buildItemLink(itemType string, id uuid.UUID, applicationID uuid.UUID) string {
 	if itemType == "interview" {
 		return fmt.Sprintf("/interviews/%s", id.String())
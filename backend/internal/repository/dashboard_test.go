@@ -12,6 +12,37 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+func TestInsertPracticeItem(t *testing.T) {
+	today := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
+	newItem := func(daysUntil int) UpcomingItem {
+		due := today.AddDate(0, 0, daysUntil)
+		return UpcomingItem{ID: uuid.New(), Type: "interview", DueDate: due, Countdown: calculateCountdown(due, today)}
+	}
+
+	overdue := newItem(-2)
+	todayItem := newItem(0)
+	future := newItem(5)
+	practice := practiceUpcomingItem(3, today)
+
+	assert.Equal(t, "3 practice questions due", practice.Title)
+	assert.Equal(t, UrgencyToday, practice.Countdown.Urgency)
+	assert.Equal(t, "1 practice question due", practiceUpcomingItem(1, today).Title)
+
+	items := insertPracticeItem([]UpcomingItem{overdue, todayItem, future}, practice, 10)
+	require.Len(t, items, 4)
+	assert.Equal(t, overdue.ID, items[0].ID)
+	assert.Equal(t, "practice", items[1].Type)
+	assert.Equal(t, todayItem.ID, items[2].ID)
+
+	items = insertPracticeItem([]UpcomingItem{todayItem, future}, practice, 2)
+	require.Len(t, items, 2)
+	assert.Equal(t, "practice", items[0].Type)
+	assert.Equal(t, todayItem.ID, items[1].ID)
+
+	items = insertPracticeItem(nil, practice, 4)
+	require.Len(t, items, 1)
+}
+
 func TestCalculateCountdown(t *testing.T) {
 	today := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
 
@@ -91,6 +122,52 @@ func TestCalculateCountdown(t *testing.T) {
 	}
 }
 
+func TestCalculateDeadlineCountdown(t *testing.T) {
+	now := time.Date(2026, 2, 6, 10, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC)
+	hours := func(h int) *int { return &h }
+
+	tests := []struct {
+		name            string
+		dueDate         time.Time
+		expectedUrgency string
+		expectedText    string
+		expectedHours   *int
+	}{
+		{"Due in 5 hours", now.Add(5*time.Hour + 10*time.Minute), UrgencyToday, "In 5 hours", hours(5)},
+		{"Due in 1 hour", now.Add(90 * time.Minute), UrgencyToday, "In 1 hour", hours(1)},
+		{"Due in minutes", now.Add(20 * time.Minute), UrgencyToday, "In under an hour", hours(0)},
+		{"Due tomorrow morning", now.Add(20 * time.Hour), UrgencyToday, "In 20 hours", hours(20)},
+		{"Passed earlier today", now.Add(-3 * time.Hour), UrgencyOverdue, "3 hours overdue", hours(-3)},
+		{"Just passed", now.Add(-10 * time.Minute), UrgencyOverdue, "Under an hour overdue", hours(0)},
+		{"Due in 2 days", now.Add(50 * time.Hour), UrgencyUpcoming, "In 2 days", nil},
+		{"Overdue by days", now.Add(-50 * time.Hour), UrgencyOverdue, "2 days overdue", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countdown := calculateDeadlineCountdown(tt.dueDate, now, today)
+
+			assert.Equal(t, tt.expectedUrgency, countdown.Urgency)
+			assert.Equal(t, tt.expectedText, countdown.Text)
+			assert.Equal(t, tt.expectedHours, countdown.HoursUntil)
+		})
+	}
+
+	t.Run("Day is taken from the server's timezone", func(t *testing.T) {
+		la, err := time.LoadLocation("America/Los_Angeles")
+		require.NoError(t, err)
+
+		// 23:59 on the 9th in LA is already the 10th in UTC
+		due := time.Date(2026, 2, 9, 23, 59, 0, 0, la)
+		laToday := time.Date(2026, 2, 6, 0, 0, 0, 0, la)
+		countdown := calculateDeadlineCountdown(due.UTC(), laToday.Add(9*time.Hour), laToday)
+
+		assert.Equal(t, 3, countdown.DaysUntil)
+		assert.Nil(t, countdown.HoursUntil)
+	})
+}
+
 func TestDashboardRepository(t *testing.T) {
 	db := testutil.NewTestDatabase(t)
 	defer db.Close(t)
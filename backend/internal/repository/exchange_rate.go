@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type ExchangeRateRepository struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(database *database.Database) *ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db: database.DB,
+	}
+}
+
+// UpsertRates inserts rates, replacing any existing rate for the same
+// currency and effective date. All rates are written in one transaction.
+func (r *ExchangeRateRepository) UpsertRates(rates []models.ExchangeRate) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	query := `
+		INSERT INTO exchange_rates (currency, rate_to_usd, effective_date, source)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (currency, effective_date) DO UPDATE SET
+			rate_to_usd = EXCLUDED.rate_to_usd,
+			source = EXCLUDED.source
+	`
+
+	for _, rate := range rates {
+		_, err := tx.Exec(query, strings.ToUpper(rate.Currency), rate.RateToUSD, rate.EffectiveDate, rate.Source)
+		if err != nil {
+			return 0, errors.ConvertError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.ConvertError(err)
+	}
+
+	return len(rates), nil
+}
+
+// ListLatest returns the most recent rate for every currency as of the given date
+func (r *ExchangeRateRepository) ListLatest(asOf time.Time) ([]models.ExchangeRate, error) {
+	query := `
+		SELECT DISTINCT ON (currency) id, currency, rate_to_usd, effective_date, source, created_at, updated_at
+		FROM exchange_rates
+		WHERE effective_date <= $1
+		ORDER BY currency, effective_date DESC
+	`
+
+	var rates []models.ExchangeRate
+	err := r.db.Select(&rates, query, asOf)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return rates, nil
+}
+
+// GetRate returns the rate for a currency in effect on the given date
+func (r *ExchangeRateRepository) GetRate(currency string, asOf time.Time) (*models.ExchangeRate, error) {
+	query := `
+		SELECT id, currency, rate_to_usd, effective_date, source, created_at, updated_at
+		FROM exchange_rates
+		WHERE currency = $1 AND effective_date <= $2
+		ORDER BY effective_date DESC
+		LIMIT 1
+	`
+
+	var rate models.ExchangeRate
+	err := r.db.Get(&rate, query, strings.ToUpper(currency), asOf)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(errors.ErrorNotFound, "no exchange rate for currency "+strings.ToUpper(currency))
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	return &rate, nil
+}
+
+func (r *ExchangeRateRepository) GetPreferredCurrency(userID uuid.UUID) (string, error) {
+	query := `SELECT preferred_currency FROM users WHERE id = $1 AND deleted_at IS NULL`
+
+	var currency string
+	err := r.db.Get(&currency, query, userID)
+	if err != nil {
+		return "", errors.ConvertError(err)
+	}
+
+	return currency, nil
+}
+
+func (r *ExchangeRateRepository) SetPreferredCurrency(userID uuid.UUID, currency string) error {
+	query := `UPDATE users SET preferred_currency = $1 WHERE id = $2 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, strings.ToUpper(currency), userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "user not found")
+	}
+
+	return nil
+}
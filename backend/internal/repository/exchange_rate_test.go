@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestExchangeRateRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	rateRepo := NewExchangeRateRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("rates@example.com", "Rates User", string(hashedPassword))
+	require.NoError(t, err)
+
+	oldDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("UpsertRates", func(t *testing.T) {
+		count, err := rateRepo.UpsertRates([]models.ExchangeRate{
+			{Currency: "gbp", RateToUSD: 1.20, EffectiveDate: oldDate},
+			{Currency: "GBP", RateToUSD: 1.25, EffectiveDate: newDate},
+			{Currency: "EUR", RateToUSD: 1.10, EffectiveDate: newDate},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		t.Run("OverwritesSameDate", func(t *testing.T) {
+			_, err := rateRepo.UpsertRates([]models.ExchangeRate{
+				{Currency: "EUR", RateToUSD: 1.08, EffectiveDate: newDate},
+			})
+			require.NoError(t, err)
+
+			rate, err := rateRepo.GetRate("EUR", newDate)
+			require.NoError(t, err)
+			assert.Equal(t, 1.08, rate.RateToUSD)
+		})
+	})
+
+	t.Run("GetRate", func(t *testing.T) {
+		t.Run("UsesRateInEffectOnDate", func(t *testing.T) {
+			rate, err := rateRepo.GetRate("gbp", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+			require.NoError(t, err)
+			assert.Equal(t, 1.20, rate.RateToUSD)
+
+			rate, err = rateRepo.GetRate("GBP", time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, 1.25, rate.RateToUSD)
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			_, err := rateRepo.GetRate("XYZ", time.Now())
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("ListLatest", func(t *testing.T) {
+		rates, err := rateRepo.ListLatest(time.Now())
+		require.NoError(t, err)
+
+		byCurrency := make(map[string]float64)
+		for _, r := range rates {
+			byCurrency[r.Currency] = r.RateToUSD
+		}
+		assert.Equal(t, 1.0, byCurrency["USD"])
+		assert.Equal(t, 1.25, byCurrency["GBP"])
+		assert.Equal(t, 1.08, byCurrency["EUR"])
+	})
+
+	t.Run("PreferredCurrency", func(t *testing.T) {
+		currency, err := rateRepo.GetPreferredCurrency(testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "USD", currency)
+
+		require.NoError(t, rateRepo.SetPreferredCurrency(testUser.ID, "gbp"))
+
+		currency, err = rateRepo.GetPreferredCurrency(testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "GBP", currency)
+
+		assert.Error(t, rateRepo.SetPreferredCurrency(uuid.New(), "EUR"))
+	})
+
+	t.Run("NormalizedApplicationSalaries", func(t *testing.T) {
+		company, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Rates Co", "ratesco.com"))
+		require.NoError(t, err)
+
+		var statusID uuid.UUID
+		err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+		require.NoError(t, err)
+
+		createJobApp := func(title string, min, max float64, currency string) {
+			job := testutil.CreateTestJob(company.ID, title, "desc")
+			job.MinSalary = testutil.Float64Ptr(min)
+			job.MaxSalary = testutil.Float64Ptr(max)
+			job.Currency = testutil.StringPtr(currency)
+			createdJob, err := jobRepo.CreateJob(testUser.ID, job)
+			require.NoError(t, err)
+			_, err = applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+			require.NoError(t, err)
+		}
+
+		// User prefers GBP (set above): 100k USD = 80k GBP, 110k EUR = 95,040 GBP
+		createJobApp("USD Job", 100000, 100000, "USD")
+		createJobApp("EUR Job", 110000, 110000, "EUR")
+		createJobApp("GBP Job", 50000, 60000, "GBP")
+
+		apps, err := applicationRepo.GetApplicationsWithDetails(testUser.ID, &ApplicationFilters{SortBy: "salary", SortOrder: "desc"})
+		require.NoError(t, err)
+		require.Len(t, apps, 3)
+
+		assert.Equal(t, "EUR Job", apps[0].Job.Title)
+		assert.Equal(t, "USD Job", apps[1].Job.Title)
+		assert.Equal(t, "GBP Job", apps[2].Job.Title)
+
+		require.NotNil(t, apps[1].NormalizedMinSalary)
+		assert.InDelta(t, 80000, *apps[1].NormalizedMinSalary, 0.01)
+		require.NotNil(t, apps[1].NormalizedCurrency)
+		assert.Equal(t, "GBP", *apps[1].NormalizedCurrency)
+
+		minSalary := 70000.0
+		filtered, err := applicationRepo.GetApplicationsWithDetails(testUser.ID, &ApplicationFilters{MinSalary: &minSalary})
+		require.NoError(t, err)
+		assert.Len(t, filtered, 2)
+
+		count, err := applicationRepo.GetApplicationCount(testUser.ID, &ApplicationFilters{MinSalary: &minSalary})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
@@ -26,16 +26,19 @@ func (r *FileRepository) CreateFile(file *models.File) (*models.File, error) {
 	file.CreatedAt = time.Now()
 	file.UpdatedAt = time.Now()
 	file.UploadedAt = time.Now()
+	if file.ScanStatus == "" {
+		file.ScanStatus = models.FileScanPending
+	}
 
 	query := `
 		INSERT INTO files (
 			id, user_id, application_id, interview_id, file_name, file_type,
-			file_size, s3_key, uploaded_at, created_at, updated_at
+			file_size, s3_key, scan_status, uploaded_at, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.db.Exec(query, file.ID, file.UserID, file.ApplicationID, file.InterviewID, file.FileName, file.FileType, file.FileSize, file.S3Key, file.UploadedAt, file.CreatedAt, file.UpdatedAt)
+	_, err := r.db.Exec(query, file.ID, file.UserID, file.ApplicationID, file.InterviewID, file.FileName, file.FileType, file.FileSize, file.S3Key, file.ScanStatus, file.UploadedAt, file.CreatedAt, file.UpdatedAt)
 	if err != nil {
 		return nil, errors.ConvertError(err)
 	}
@@ -46,7 +49,8 @@ func (r *FileRepository) CreateFile(file *models.File) (*models.File, error) {
 func (r *FileRepository) GetUserFiles(userID uuid.UUID, applicationID, interviewID *uuid.UUID) ([]*models.File, error) {
 	query := `
 		SELECT id, user_id, application_id, interview_id, file_name, 
-				file_type, file_size, s3_key, uploaded_at, created_at, updated_at
+				file_type, file_size, s3_key, scan_status, scan_signature, scanned_at,
+				uploaded_at, created_at, updated_at
 		FROM files
 		WHERE user_id = $1
 		AND deleted_at IS NULL
@@ -77,6 +81,30 @@ func (r *FileRepository) GetUserFiles(userID uuid.UUID, applicationID, interview
 	return files, nil
 }
 
+// GetFilesByCompany returns files attached to any of the user's applications
+// to a company.
+func (r *FileRepository) GetFilesByCompany(companyID, userID uuid.UUID) ([]*models.File, error) {
+	query := `
+		SELECT f.id, f.user_id, f.application_id, f.interview_id, f.file_name,
+				f.file_type, f.file_size, f.s3_key, f.scan_status, f.scan_signature, f.scanned_at,
+				f.uploaded_at, f.created_at, f.updated_at
+		FROM files f
+		JOIN applications a ON f.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.company_id = $1 AND f.user_id = $2
+		AND f.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY f.uploaded_at DESC
+	`
+
+	var files []*models.File
+	err := r.db.Select(&files, query, companyID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return files, nil
+}
+
 func (r *FileRepository) SoftDeleteFile(fileID, userID uuid.UUID) error {
 	query := `
 		UPDATE files
@@ -103,12 +131,15 @@ func (r *FileRepository) SoftDeleteFile(fileID, userID uuid.UUID) error {
 	return nil
 }
 
+// GetUserStorageUsage sums the user's stored files. Infected files have
+// already been removed from storage, so they don't count.
 func (r *FileRepository) GetUserStorageUsage(userID uuid.UUID) (int64, error) {
 	query := `
 		SELECT COALESCE(SUM(file_size),0)
 		FROM files
 		WHERE user_id = $1
 		AND deleted_at IS NULL
+		AND scan_status != 'infected'
 	`
 
 	var totalBytes int64
@@ -140,7 +171,8 @@ func (r *FileRepository) GetUserFileCount(userID uuid.UUID) (int, error) {
 func (r *FileRepository) GetFileByID(fileID, userID uuid.UUID) (*models.File, error) {
 	query := `
 		SELECT id, user_id, application_id, interview_id, file_name, 
-				file_type, file_size, s3_key, uploaded_at, created_at, updated_at
+				file_type, file_size, s3_key, scan_status, scan_signature, scanned_at,
+				uploaded_at, created_at, updated_at
 		FROM files
 		WHERE user_id = $1
 		AND id = $2
@@ -156,6 +188,92 @@ func (r *FileRepository) GetFileByID(fileID, userID uuid.UUID) (*models.File, er
 	return file, nil
 }
 
+// S3KeyInUse reports whether any file row, deleted or not, already holds
+// s3Key.
+func (r *FileRepository) S3KeyInUse(s3Key string) (bool, error) {
+	var inUse bool
+	err := r.db.Get(&inUse, `SELECT EXISTS(SELECT 1 FROM files WHERE s3_key = $1)`, s3Key)
+	if err != nil {
+		return false, errors.ConvertError(err)
+	}
+
+	return inUse, nil
+}
+
+// GetPendingScans returns files of any user still waiting for a malware scan
+// that were created before the given time. Files with the fewest failed
+// attempts come first, then the oldest, so files that keep failing can't
+// starve newer uploads.
+func (r *FileRepository) GetPendingScans(createdBefore time.Time, limit int) ([]*models.File, error) {
+	query := `
+		SELECT id, user_id, application_id, interview_id, file_name,
+				file_type, file_size, s3_key, scan_status, scan_signature, scanned_at,
+				uploaded_at, created_at, updated_at
+		FROM files
+		WHERE scan_status = $1
+		AND created_at < $2
+		AND deleted_at IS NULL
+		ORDER BY scan_attempts ASC, created_at ASC
+		LIMIT $3
+	`
+
+	var files []*models.File
+	err := r.db.Select(&files, query, models.FileScanPending, createdBefore, limit)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return files, nil
+}
+
+// RecordScanFailure counts a failed scan of a pending file. Once maxAttempts
+// scans have failed the file is marked failed and no longer retried. It
+// returns the file's scan status afterwards.
+func (r *FileRepository) RecordScanFailure(fileID uuid.UUID, maxAttempts int) (string, error) {
+	query := `
+		UPDATE files
+		SET scan_attempts = scan_attempts + 1,
+			scan_status = CASE WHEN scan_attempts + 1 >= $1 THEN $2 ELSE scan_status END,
+			updated_at = $3
+		WHERE id = $4 AND scan_status = $5
+		RETURNING scan_status
+	`
+
+	var status string
+	err := r.db.Get(&status, query, maxAttempts, models.FileScanFailed, time.Now(), fileID, models.FileScanPending)
+	if err != nil {
+		return "", errors.ConvertError(err)
+	}
+
+	return status, nil
+}
+
+// UpdateScanResult records the outcome of a malware scan. signature is the
+// name clamd gave the malware, or nil when the file is clean.
+func (r *FileRepository) UpdateScanResult(fileID uuid.UUID, status string, signature *string) error {
+	query := `
+		UPDATE files
+		SET scan_status = $1, scan_signature = $2, scanned_at = $3, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.Exec(query, status, signature, time.Now(), fileID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "file not found")
+	}
+
+	return nil
+}
+
 func (r *FileRepository) BeginTx() (*sqlx.Tx, error) {
 	return r.db.Beginx()
 }
@@ -165,16 +283,19 @@ func (r *FileRepository) CreateFileTx(tx *sqlx.Tx, file *models.File) (*models.F
 	file.CreatedAt = time.Now()
 	file.UpdatedAt = time.Now()
 	file.UploadedAt = time.Now()
+	if file.ScanStatus == "" {
+		file.ScanStatus = models.FileScanPending
+	}
 
 	query := `
 		INSERT INTO files (
 			id, user_id, application_id, interview_id, file_name, file_type,
-			file_size, s3_key, uploaded_at, created_at, updated_at
+			file_size, s3_key, scan_status, uploaded_at, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := tx.Exec(query, file.ID, file.UserID, file.ApplicationID, file.InterviewID, file.FileName, file.FileType, file.FileSize, file.S3Key, file.UploadedAt, file.CreatedAt, file.UpdatedAt)
+	_, err := tx.Exec(query, file.ID, file.UserID, file.ApplicationID, file.InterviewID, file.FileName, file.FileType, file.FileSize, file.S3Key, file.ScanStatus, file.UploadedAt, file.CreatedAt, file.UpdatedAt)
 	if err != nil {
 		return nil, errors.ConvertError(err)
 	}
@@ -217,7 +338,8 @@ type FileWithDetails struct {
 func (r *FileRepository) GetUserFilesWithDetails(userID uuid.UUID, sortBy string) ([]*FileWithDetails, error) {
 	query := `
 		SELECT f.id, f.user_id, f.application_id, f.interview_id, f.file_name,
-				f.file_type, f.file_size, f.s3_key, f.uploaded_at, f.created_at, f.updated_at,
+				f.file_type, f.file_size, f.s3_key, f.scan_status, f.scan_signature, f.scanned_at,
+				f.uploaded_at, f.created_at, f.updated_at,
 				c.name as application_company,
 				j.title as application_title
 		FROM files f
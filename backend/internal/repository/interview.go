@@ -2,6 +2,7 @@ package repository
 
 import (
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/workflow"
 	"ditto-backend/pkg/database"
 	"ditto-backend/pkg/errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type InterviewRepository struct {
@@ -23,13 +25,27 @@ func NewInterviewRepository(database *database.Database) *InterviewRepository {
 }
 
 func (r *InterviewRepository) CreateInterview(interview *models.Interview) (*models.Interview, error) {
+	created, _, err := r.CreateInterviewWithInterviewers(interview, nil)
+	return created, err
+}
+
+// CreateInterviewWithInterviewers saves an interview and its interviewers in
+// one transaction, so a failed interviewer never leaves a half-imported
+// interview behind.
+func (r *InterviewRepository) CreateInterviewWithInterviewers(interview *models.Interview, interviewers []*models.Interviewer) (*models.Interview, []*models.Interviewer, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
 	interview.ID = uuid.New()
 	interview.CreatedAt = time.Now()
 	interview.UpdatedAt = time.Now()
 
-	nextRoundNumber, err := r.GetNextRoundNumber(interview.ApplicationID)
+	nextRoundNumber, err := nextRoundNumber(tx, interview.ApplicationID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	interview.RoundNumber = nextRoundNumber
@@ -42,24 +58,51 @@ func (r *InterviewRepository) CreateInterview(interview *models.Interview) (*mod
 		INSERT INTO interviews (
 			id, user_id, application_id, round_number, scheduled_date, scheduled_time,
 			duration_minutes, outcome, overall_feeling, went_well, could_improve,
-			confidence_level, interview_type, status, created_at, updated_at
+			confidence_level, interview_type, status, location, meeting_link, ics_uid, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 	`
 
-	_, err = r.db.Exec(query, interview.ID, interview.UserID,
+	_, err = tx.Exec(query, interview.ID, interview.UserID,
 		interview.ApplicationID, interview.RoundNumber, interview.ScheduledDate, interview.ScheduledTime,
 		interview.DurationMinutes, interview.Outcome, interview.OverallFeeling, interview.WentWell,
 		interview.CouldImprove, interview.ConfidenceLevel, interview.InterviewType, interview.Status,
-		interview.CreatedAt, interview.UpdatedAt)
+		interview.Location, interview.MeetingLink, interview.ICSUID, interview.CreatedAt, interview.UpdatedAt)
 	if err != nil {
-		return nil, errors.ConvertError(err)
+		return nil, nil, errors.ConvertError(err)
 	}
 
-	return interview, nil
+	interviewerQuery := `
+		INSERT INTO interviewers (
+			id, interview_id, name, role, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, interviewer := range interviewers {
+		interviewer.ID = uuid.New()
+		interviewer.InterviewID = interview.ID
+		interviewer.CreatedAt = interview.CreatedAt
+		interviewer.UpdatedAt = interview.CreatedAt
+
+		_, err = tx.Exec(interviewerQuery, interviewer.ID, interviewer.InterviewID, interviewer.Name,
+			interviewer.Role, interviewer.CreatedAt, interviewer.UpdatedAt)
+		if err != nil {
+			return nil, nil, errors.ConvertError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+
+	return interview, interviewers, nil
 }
 
 func (r *InterviewRepository) GetNextRoundNumber(applicationID uuid.UUID) (int, error) {
+	return nextRoundNumber(r.db, applicationID)
+}
+
+func nextRoundNumber(q sqlx.Queryer, applicationID uuid.UUID) (int, error) {
 	query := `
         SELECT COALESCE(MAX(round_number), 0) + 1
         FROM interviews
@@ -67,7 +110,7 @@ func (r *InterviewRepository) GetNextRoundNumber(applicationID uuid.UUID) (int,
     `
 
 	var nextRound int
-	err := r.db.Get(&nextRound, query, applicationID)
+	err := sqlx.Get(q, &nextRound, query, applicationID)
 	if err != nil {
 		return 0, errors.ConvertError(err)
 	}
@@ -80,7 +123,7 @@ func (r *InterviewRepository) GetInterviewByID(id, userID uuid.UUID) (*models.In
 		SELECT 
 			id, user_id, application_id, round_number, scheduled_date, scheduled_time,
 			duration_minutes, outcome, overall_feeling, went_well, could_improve,
-			confidence_level, interview_type, status, created_at, updated_at
+			confidence_level, interview_type, status, location, meeting_link, created_at, updated_at
 		FROM interviews
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
@@ -95,8 +138,54 @@ func (r *InterviewRepository) GetInterviewByID(id, userID uuid.UUID) (*models.In
 }
 
 func (r *InterviewRepository) UpdateInterview(interviewID, userID uuid.UUID, updates map[string]any) (*models.Interview, error) {
+	interview, _, err := r.UpdateInterviewWithChange(interviewID, userID, updates, nil)
+	return interview, err
+}
+
+// InterviewChange explains why an interview was moved, cancelled or missed.
+// Both fields are optional.
+type InterviewChange struct {
+	InitiatedBy *string
+	Reason      *string
+}
+
+// UpdateInterviewWithChange applies updates and, in the same transaction,
+// logs an event for each reschedule, cancellation or no-show they cause. A
+// status change must be allowed by workflow.Interview.
+func (r *InterviewRepository) UpdateInterviewWithChange(interviewID, userID uuid.UUID, updates map[string]any, change *InterviewChange) (*models.Interview, []*models.InterviewEvent, error) {
 	if len(updates) == 0 {
-		return r.GetInterviewByID(interviewID, userID)
+		interview, err := r.GetInterviewByID(interviewID, userID)
+		return interview, nil, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	selectQuery := `
+		SELECT
+			id, user_id, application_id, round_number, scheduled_date, scheduled_time,
+			duration_minutes, outcome, overall_feeling, went_well, could_improve,
+			confidence_level, interview_type, status, location, meeting_link, created_at, updated_at
+		FROM interviews
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	before := &models.Interview{}
+	if err := tx.Get(before, selectQuery+" FOR UPDATE", interviewID, userID); err != nil {
+		err = errors.ConvertError(err)
+		if errors.IsNotFoundError(err) {
+			return nil, nil, errors.New(errors.ErrorNotFound, "interview not found or owned by other user")
+		}
+		return nil, nil, err
+	}
+
+	if status, ok := updates["status"].(string); ok {
+		if err := workflow.Interview.Check(before.Status, status); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	setParts := []string{}
@@ -121,21 +210,77 @@ func (r *InterviewRepository) UpdateInterview(interviewID, userID uuid.UUID, upd
         WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL
         `, strings.Join(setParts, ", "), argIndex, argIndex+1)
 
-	result, err := r.db.Exec(query, args...)
-	if err != nil {
-		return nil, errors.ConvertError(err)
+	if _, err := tx.Exec(query, args...); err != nil {
+		return nil, nil, errors.ConvertError(err)
 	}
 
-	rowAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, errors.ConvertError(err)
+	after := &models.Interview{}
+	if err := tx.Get(after, selectQuery, interviewID, userID); err != nil {
+		return nil, nil, errors.ConvertError(err)
 	}
 
-	if rowAffected == 0 {
-		return nil, errors.New(errors.ErrorNotFound, "interview not found or owned by other user")
+	events := interviewChangeEvents(before, after, change)
+	for _, event := range events {
+		if err := insertInterviewEvent(tx, event); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+
+	return after, events, nil
+}
+
+// interviewChangeEvents works out which events an update amounts to. Moving
+// a cancelled or completed interview is not a reschedule.
+func interviewChangeEvents(before, after *models.Interview, change *InterviewChange) []*models.InterviewEvent {
+	if change == nil {
+		change = &InterviewChange{}
+	}
+	newEvent := func(eventType string) *models.InterviewEvent {
+		return &models.InterviewEvent{
+			ID:          uuid.New(),
+			InterviewID: after.ID,
+			UserID:      after.UserID,
+			EventType:   eventType,
+			InitiatedBy: change.InitiatedBy,
+			Reason:      change.Reason,
+			CreatedAt:   time.Now(),
+		}
+	}
+
+	var events []*models.InterviewEvent
+
+	moved := !before.ScheduledDate.Equal(after.ScheduledDate) || !equalStringPtr(before.ScheduledTime, after.ScheduledTime)
+	if moved && after.Status == models.InterviewStatusScheduled {
+		event := newEvent(models.InterviewEventRescheduled)
+		previousDate, newDate := before.ScheduledDate, after.ScheduledDate
+		event.PreviousDate = &previousDate
+		event.PreviousTime = before.ScheduledTime
+		event.NewDate = &newDate
+		event.NewTime = after.ScheduledTime
+		events = append(events, event)
+	}
+
+	if before.Status != after.Status {
+		switch after.Status {
+		case models.InterviewStatusCancelled:
+			events = append(events, newEvent(models.InterviewEventCancelled))
+		case models.InterviewStatusNoShow:
+			events = append(events, newEvent(models.InterviewEventNoShow))
+		}
 	}
 
-	return r.GetInterviewByID(interviewID, userID)
+	return events
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
 func (r *InterviewRepository) SoftDeleteInterview(interviewID, userID uuid.UUID) error {
@@ -167,7 +312,7 @@ func (r *InterviewRepository) GetInterviewsByApplicationID(applicationID, userID
 		SELECT 
 			id, user_id, application_id, round_number, scheduled_date, scheduled_time,
 			duration_minutes, outcome, overall_feeling, went_well, could_improve,
-			confidence_level, interview_type, status, created_at, updated_at
+			confidence_level, interview_type, status, location, meeting_link, created_at, updated_at
 		FROM interviews
 		WHERE application_id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
@@ -186,7 +331,7 @@ func (r *InterviewRepository) GetInterviewsByUser(userID uuid.UUID) ([]*models.I
 		SELECT
 			id, user_id, application_id, round_number, scheduled_date, scheduled_time,
 			duration_minutes, outcome, overall_feeling, went_well, could_improve,
-			confidence_level, interview_type, status, created_at, updated_at
+			confidence_level, interview_type, status, location, meeting_link, created_at, updated_at
 		FROM interviews
 		WHERE user_id = $1 AND deleted_at IS NULL
 	`
@@ -212,7 +357,7 @@ func (r *InterviewRepository) GetInterviewWithApplicationInfo(interviewID, userI
 		SELECT
 			i.id, i.user_id, i.application_id, i.round_number, i.scheduled_date, i.scheduled_time,
 			i.duration_minutes, i.outcome, i.overall_feeling, i.went_well, i.could_improve,
-			i.confidence_level, i.interview_type, i.status, i.created_at, i.updated_at,
+			i.confidence_level, i.interview_type, i.status, i.location, i.meeting_link, i.created_at, i.updated_at,
 			c.name as company_name, j.title as job_title
 		FROM interviews i
 		JOIN applications a ON i.application_id = a.id
@@ -230,6 +375,32 @@ func (r *InterviewRepository) GetInterviewWithApplicationInfo(interviewID, userI
 	return result, nil
 }
 
+// ListCalendarInterviews returns the interviews that belong on the user's
+// synced calendar: everything that hasn't been cancelled.
+func (r *InterviewRepository) ListCalendarInterviews(userID uuid.UUID) ([]*InterviewWithApplicationInfo, error) {
+	query := `
+		SELECT
+			i.id, i.user_id, i.application_id, i.round_number, i.scheduled_date, i.scheduled_time,
+			i.duration_minutes, i.outcome, i.overall_feeling, i.went_well, i.could_improve,
+			i.confidence_level, i.interview_type, i.status, i.location, i.meeting_link, i.created_at, i.updated_at,
+			c.name as company_name, j.title as job_title
+		FROM interviews i
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE i.user_id = $1 AND i.status <> $2 AND i.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY i.scheduled_date ASC
+	`
+
+	var interviews []*InterviewWithApplicationInfo
+	err := r.db.Select(&interviews, query, userID, models.InterviewStatusCancelled)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return interviews, nil
+}
+
 // InterviewListItem holds interview data for list display with application info
 type InterviewListItem struct {
 	models.Interview
@@ -313,7 +484,7 @@ func (r *InterviewRepository) GetInterviewsWithApplicationInfo(userID uuid.UUID,
 		SELECT
 			i.id, i.user_id, i.application_id, i.round_number, i.scheduled_date, i.scheduled_time,
 			i.duration_minutes, i.outcome, i.overall_feeling, i.went_well, i.could_improve,
-			i.confidence_level, i.interview_type, i.status, i.created_at, i.updated_at,
+			i.confidence_level, i.interview_type, i.status, i.location, i.meeting_link, i.created_at, i.updated_at,
 			c.name as company_name, j.title as job_title
 	` + baseQuery + `
 		ORDER BY i.scheduled_date ASC, COALESCE(i.scheduled_time, '00:00:00') ASC
@@ -388,3 +559,120 @@ func (r *InterviewRepository) GetAllRoundsSummary(applicationID uuid.UUID, userI
 
 	return summaries, nil
 }
+
+// CompanyRoundSummary is an interview round across every application the user
+// has made to one company.
+type CompanyRoundSummary struct {
+	ID            uuid.UUID                   `json:"id" db:"id"`
+	ApplicationID uuid.UUID                   `json:"application_id" db:"application_id"`
+	AttemptNumber int                         `json:"attempt_number" db:"attempt_number"`
+	RoundNumber   int                         `json:"round_number" db:"round_number"`
+	InterviewType string                      `json:"interview_type" db:"interview_type"`
+	ScheduledDate string                      `json:"scheduled_date" db:"scheduled_date"`
+	Status        string                      `json:"status" db:"status"`
+	Outcome       *string                     `json:"outcome,omitempty" db:"outcome"`
+	CreatedAt     time.Time                   `json:"created_at" db:"created_at"`
+	Interviewers  []InterviewerSummary        `json:"interviewers"`
+	Questions     []*models.InterviewQuestion `json:"questions"`
+}
+
+// GetAllRoundsSummaryByCompany is GetAllRoundsSummary for every application to
+// a company, with interviewers and questions loaded in one query each rather
+// than per round.
+func (r *InterviewRepository) GetAllRoundsSummaryByCompany(companyID uuid.UUID, userID uuid.UUID) ([]*CompanyRoundSummary, error) {
+	query := `
+		SELECT i.id, i.application_id, a.attempt_number, i.round_number, i.interview_type,
+			i.scheduled_date, i.status, i.outcome, i.created_at
+		FROM interviews i
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.company_id = $1 AND i.user_id = $2
+		AND i.deleted_at IS NULL AND a.deleted_at IS NULL
+		ORDER BY a.applied_at ASC, i.round_number ASC
+	`
+
+	type interviewRow struct {
+		ID            uuid.UUID `db:"id"`
+		ApplicationID uuid.UUID `db:"application_id"`
+		AttemptNumber int       `db:"attempt_number"`
+		RoundNumber   int       `db:"round_number"`
+		InterviewType string    `db:"interview_type"`
+		ScheduledDate time.Time `db:"scheduled_date"`
+		Status        string    `db:"status"`
+		Outcome       *string   `db:"outcome"`
+		CreatedAt     time.Time `db:"created_at"`
+	}
+
+	var rows []interviewRow
+	err := r.db.Select(&rows, query, companyID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	summaries := make([]*CompanyRoundSummary, 0, len(rows))
+	byID := make(map[uuid.UUID]*CompanyRoundSummary, len(rows))
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		summary := &CompanyRoundSummary{
+			ID:            row.ID,
+			ApplicationID: row.ApplicationID,
+			AttemptNumber: row.AttemptNumber,
+			RoundNumber:   row.RoundNumber,
+			InterviewType: row.InterviewType,
+			ScheduledDate: row.ScheduledDate.Format("2006-01-02"),
+			Status:        row.Status,
+			Outcome:       row.Outcome,
+			CreatedAt:     row.CreatedAt,
+			Interviewers:  []InterviewerSummary{},
+			Questions:     []*models.InterviewQuestion{},
+		}
+		summaries = append(summaries, summary)
+		byID[row.ID] = summary
+		ids = append(ids, row.ID)
+	}
+
+	if len(ids) == 0 {
+		return summaries, nil
+	}
+
+	interviewersQuery := `
+		SELECT id, interview_id, name, role, created_at, updated_at
+		FROM interviewers
+		WHERE interview_id = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`
+
+	var interviewers []*models.Interviewer
+	err = r.db.Select(&interviewers, interviewersQuery, pq.Array(ids))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	for _, iv := range interviewers {
+		if summary, ok := byID[iv.InterviewID]; ok {
+			summary.Interviewers = append(summary.Interviewers, InterviewerSummary{
+				Name: iv.Name,
+				Role: iv.Role,
+			})
+		}
+	}
+
+	questionsQuery := `
+		SELECT id, interview_id, question_text, answer_text, "order", bank_entry_id, story_id, created_at, updated_at
+		FROM interview_questions
+		WHERE interview_id = ANY($1) AND deleted_at IS NULL
+		ORDER BY "order" ASC
+	`
+
+	var questions []*models.InterviewQuestion
+	err = r.db.Select(&questions, questionsQuery, pq.Array(ids))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+	for _, q := range questions {
+		if summary, ok := byID[q.InterviewID]; ok {
+			summary.Questions = append(summary.Questions, q)
+		}
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type InterviewEventRepository struct {
+	db *sqlx.DB
+}
+
+func NewInterviewEventRepository(database *database.Database) *InterviewEventRepository {
+	return &InterviewEventRepository{
+		db: database.DB,
+	}
+}
+
+func insertInterviewEvent(tx *sqlx.Tx, event *models.InterviewEvent) error {
+	query := `
+		INSERT INTO interview_events (
+			id, interview_id, user_id, event_type, initiated_by, reason,
+			previous_date, previous_time, new_date, new_time, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := tx.Exec(query,
+		event.ID, event.InterviewID, event.UserID, event.EventType, event.InitiatedBy, event.Reason,
+		event.PreviousDate, event.PreviousTime, event.NewDate, event.NewTime, event.CreatedAt,
+	)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
+
+// ListByInterview returns an interview's events, oldest first
+func (r *InterviewEventRepository) ListByInterview(interviewID, userID uuid.UUID) ([]*models.InterviewEvent, error) {
+	query := `
+		SELECT
+			id, interview_id, user_id, event_type, initiated_by, reason,
+			previous_date, previous_time, new_date, new_time, created_at
+		FROM interview_events
+		WHERE interview_id = $1 AND user_id = $2
+		ORDER BY created_at ASC
+	`
+
+	var events []*models.InterviewEvent
+	err := r.db.Select(&events, query, interviewID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return events, nil
+}
+
+// InterviewEventCounts tallies one kind of event by who initiated it. Events
+// where the user didn't say are only in Total.
+type InterviewEventCounts struct {
+	Total     int `json:"total" db:"total"`
+	ByCompany int `json:"by_company" db:"by_company"`
+	ByMe      int `json:"by_me" db:"by_me"`
+}
+
+// CountByCompany tallies events on the user's interviews with a company,
+// keyed by event type
+func (r *InterviewEventRepository) CountByCompany(companyID, userID uuid.UUID) (map[string]InterviewEventCounts, error) {
+	query := `
+		SELECT
+			e.event_type,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE e.initiated_by = 'company') AS by_company,
+			COUNT(*) FILTER (WHERE e.initiated_by = 'me') AS by_me
+		FROM interview_events e
+		JOIN interviews i ON e.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.company_id = $1 AND e.user_id = $2
+		AND i.deleted_at IS NULL AND a.deleted_at IS NULL
+		GROUP BY e.event_type
+	`
+
+	var rows []struct {
+		EventType string `db:"event_type"`
+		InterviewEventCounts
+	}
+	err := r.db.Select(&rows, query, companyID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	counts := make(map[string]InterviewEventCounts, len(rows))
+	for _, row := range rows {
+		counts[row.EventType] = row.InterviewEventCounts
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"ditto-backend/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestInterviewEventRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	interviewRepo := NewInterviewRepository(db.Database)
+	eventRepo := NewInterviewEventRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("eventtest@example.com", "Event Test User", string(hashedPassword))
+	require.NoError(t, err)
+
+	testCompany := testutil.CreateTestCompany("Event Co", "eventco.com")
+	createdCompany, err := companyRepo.CreateCompany(testCompany)
+	require.NoError(t, err)
+
+	testJob := testutil.CreateTestJob(createdCompany.ID, "Software Engineer", "Build things")
+	createdJob, err := jobRepo.CreateJob(testUser.ID, testJob)
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	createdApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	scheduledTime := "10:00"
+	interview, err := interviewRepo.CreateInterview(&models.Interview{
+		UserID:        testUser.ID,
+		ApplicationID: createdApp.ID,
+		ScheduledDate: time.Now().AddDate(0, 0, 7),
+		ScheduledTime: &scheduledTime,
+		InterviewType: models.InterviewTypeTechnical,
+	})
+	require.NoError(t, err)
+
+	company := models.InitiatedByCompany
+	reason := "Interviewer is out sick"
+
+	t.Run("RescheduleIsLogged", func(t *testing.T) {
+		newDate := time.Now().AddDate(0, 0, 9).Format("2006-01-02")
+		updated, events, err := interviewRepo.UpdateInterviewWithChange(interview.ID, testUser.ID, map[string]any{
+			"scheduled_date": newDate,
+			"scheduled_time": "14:30",
+		}, &InterviewChange{InitiatedBy: &company, Reason: &reason})
+
+		require.NoError(t, err)
+		assert.Equal(t, newDate, updated.ScheduledDate.Format("2006-01-02"))
+		require.Len(t, events, 1)
+		assert.Equal(t, models.InterviewEventRescheduled, events[0].EventType)
+		assert.Equal(t, &company, events[0].InitiatedBy)
+		require.NotNil(t, events[0].PreviousDate)
+		assert.Equal(t, interview.ScheduledDate.Format("2006-01-02"), events[0].PreviousDate.Format("2006-01-02"))
+	})
+
+	t.Run("OtherEditsAreNotLogged", func(t *testing.T) {
+		_, events, err := interviewRepo.UpdateInterviewWithChange(interview.ID, testUser.ID, map[string]any{
+			"outcome": "went fine",
+		}, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("CancellationIsLogged", func(t *testing.T) {
+		_, events, err := interviewRepo.UpdateInterviewWithChange(interview.ID, testUser.ID, map[string]any{
+			"status": models.InterviewStatusCancelled,
+		}, &InterviewChange{InitiatedBy: &company})
+
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, models.InterviewEventCancelled, events[0].EventType)
+	})
+
+	t.Run("CancelledInterviewCantBeCompleted", func(t *testing.T) {
+		_, events, err := interviewRepo.UpdateInterviewWithChange(interview.ID, testUser.ID, map[string]any{
+			"status": models.InterviewStatusCompleted,
+		}, nil)
+
+		require.Error(t, err)
+		assert.Equal(t, errors.ErrorInvalidTransition, errors.ConvertError(err).Code)
+		assert.Empty(t, events)
+	})
+
+	t.Run("ListByInterview", func(t *testing.T) {
+		events, err := eventRepo.ListByInterview(interview.ID, testUser.ID)
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, models.InterviewEventRescheduled, events[0].EventType)
+		assert.Equal(t, reason, *events[0].Reason)
+		assert.Equal(t, models.InterviewEventCancelled, events[1].EventType)
+	})
+
+	t.Run("CountByCompany", func(t *testing.T) {
+		counts, err := eventRepo.CountByCompany(createdCompany.ID, testUser.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, InterviewEventCounts{Total: 1, ByCompany: 1}, counts[models.InterviewEventRescheduled])
+		assert.Equal(t, InterviewEventCounts{Total: 1, ByCompany: 1}, counts[models.InterviewEventCancelled])
+		assert.Zero(t, counts[models.InterviewEventNoShow].Total)
+	})
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"ditto-backend/internal/models"
 	"ditto-backend/pkg/database"
 	"ditto-backend/pkg/errors"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -25,18 +27,21 @@ func NewInterviewNoteRepository(database *database.Database) *InterviewNoteRepos
 
 func (r *InterviewNoteRepository) CreateInterviewNote(interviewNote *models.InterviewNote) (*models.InterviewNote, error) {
 	interviewNote.ID = uuid.New()
+	if interviewNote.ContentFormat == "" {
+		interviewNote.ContentFormat = models.NoteFormatHTML
+	}
 	interviewNote.CreatedAt = time.Now()
 	interviewNote.UpdatedAt = time.Now()
 
 	query := `
 		INSERT INTO interview_notes (
-			id, interview_id, note_type, content, created_at, updated_at
+			id, interview_id, note_type, content, content_format, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	_, err := r.db.Exec(query, interviewNote.ID, interviewNote.InterviewID,
-		interviewNote.NoteType, interviewNote.Content,
+		interviewNote.NoteType, interviewNote.Content, interviewNote.ContentFormat,
 		interviewNote.CreatedAt, interviewNote.UpdatedAt,
 	)
 	if err != nil {
@@ -48,7 +53,7 @@ func (r *InterviewNoteRepository) CreateInterviewNote(interviewNote *models.Inte
 
 func (r *InterviewNoteRepository) GetInterviewNoteByID(interviewNoteID uuid.UUID) (*models.InterviewNote, error) {
 	query := `
-		SELECT id, interview_id, note_type, content, created_at, updated_at
+		SELECT id, interview_id, note_type, content, content_format, created_at, updated_at
 		FROM interview_notes
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -108,7 +113,7 @@ func (r *InterviewNoteRepository) UpdateInterviewNote(interviewNoteID uuid.UUID,
 
 func (r *InterviewNoteRepository) GetInterviewNotesByInterviewID(interviewID uuid.UUID) ([]*models.InterviewNote, error) {
 	query := `
-		SELECT id, interview_id, note_type, content, created_at, updated_at
+		SELECT id, interview_id, note_type, content, content_format, created_at, updated_at
 		FROM interview_notes
 		WHERE interview_id = $1 AND deleted_at IS NULL
 	`
@@ -148,7 +153,7 @@ func (r *InterviewNoteRepository) SoftDeleteInterviewNote(interviewNoteID uuid.U
 
 func (r *InterviewNoteRepository) GetNoteByInterviewAndType(interviewID uuid.UUID, noteType string) (*models.InterviewNote, error) {
 	query := `
-		SELECT id, interview_id, note_type, content, created_at, updated_at
+		SELECT id, interview_id, note_type, content, content_format, created_at, updated_at
 		FROM interview_notes
 		WHERE interview_id = $1 AND note_type = $2 AND deleted_at IS NULL
 	`
@@ -164,3 +169,199 @@ func (r *InterviewNoteRepository) GetNoteByInterviewAndType(interviewID uuid.UUI
 
 	return &interviewNote, nil
 }
+
+const noteRevisionColumns = `
+	id, note_id, revision_number, author_id, content, content_format, content_hash, content_size, created_at
+`
+
+// SaveNote creates or updates the note of the given type on an interview and
+// records the saved content as a new revision, keeping at most maxRevisions
+// revisions per note (zero or less keeps them all). Saving content identical
+// to the latest revision, in the same format, changes nothing and returns a
+// nil revision.
+func (r *InterviewNoteRepository) SaveNote(interviewID uuid.UUID, noteType string, content *string, format string, authorID uuid.UUID, maxRevisions int) (*models.InterviewNote, *models.InterviewNoteRevision, error) {
+	if format == "" {
+		format = models.NoteFormatHTML
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now()
+	hash, size := noteContentHash(content)
+
+	note := &models.InterviewNote{}
+	err = tx.Get(note, `
+		SELECT id, interview_id, note_type, content, content_format, created_at, updated_at
+		FROM interview_notes
+		WHERE interview_id = $1 AND note_type = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, interviewID, noteType)
+
+	var latest struct {
+		RevisionNumber int    `db:"revision_number"`
+		ContentFormat  string `db:"content_format"`
+		ContentHash    string `db:"content_hash"`
+	}
+
+	switch {
+	case err == sql.ErrNoRows:
+		note = &models.InterviewNote{
+			ID:            uuid.New(),
+			InterviewID:   interviewID,
+			NoteType:      noteType,
+			Content:       content,
+			ContentFormat: format,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		_, err = tx.Exec(`
+			INSERT INTO interview_notes (id, interview_id, note_type, content, content_format, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, note.ID, note.InterviewID, note.NoteType, note.Content, note.ContentFormat, note.CreatedAt, note.UpdatedAt)
+		if err != nil {
+			return nil, nil, errors.ConvertError(err)
+		}
+	case err != nil:
+		return nil, nil, errors.ConvertError(err)
+	default:
+		err = tx.Get(&latest, `
+			SELECT revision_number, content_format, content_hash
+			FROM interview_note_revisions
+			WHERE note_id = $1
+			ORDER BY revision_number DESC
+			LIMIT 1
+		`, note.ID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, nil, errors.ConvertError(err)
+		}
+
+		if latest.RevisionNumber > 0 && latest.ContentHash == hash && latest.ContentFormat == format {
+			return note, nil, nil
+		}
+
+		_, err = tx.Exec(`
+			UPDATE interview_notes SET content = $1, content_format = $2, updated_at = $3 WHERE id = $4
+		`, content, format, now, note.ID)
+		if err != nil {
+			return nil, nil, errors.ConvertError(err)
+		}
+		note.Content = content
+		note.ContentFormat = format
+		note.UpdatedAt = now
+	}
+
+	revision := &models.InterviewNoteRevision{
+		ID:             uuid.New(),
+		NoteID:         note.ID,
+		RevisionNumber: latest.RevisionNumber + 1,
+		AuthorID:       &authorID,
+		Content:        content,
+		ContentFormat:  format,
+		ContentHash:    hash,
+		ContentSize:    size,
+		CreatedAt:      now,
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO interview_note_revisions (
+			id, note_id, revision_number, author_id, content, content_format, content_hash, content_size, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, revision.ID, revision.NoteID, revision.RevisionNumber, revision.AuthorID, revision.Content,
+		revision.ContentFormat, revision.ContentHash, revision.ContentSize, revision.CreatedAt)
+	if err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+
+	if maxRevisions > 0 {
+		_, err = tx.Exec(`
+			DELETE FROM interview_note_revisions
+			WHERE note_id = $1 AND revision_number <= $2
+		`, note.ID, revision.RevisionNumber-maxRevisions)
+		if err != nil {
+			return nil, nil, errors.ConvertError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, errors.ConvertError(err)
+	}
+
+	return note, revision, nil
+}
+
+// ListRevisions returns a note's revisions, newest first, without their
+// content.
+func (r *InterviewNoteRepository) ListRevisions(noteID uuid.UUID) ([]*models.InterviewNoteRevision, error) {
+	query := `
+		SELECT id, note_id, revision_number, author_id, content_format, content_hash, content_size, created_at
+		FROM interview_note_revisions
+		WHERE note_id = $1
+		ORDER BY revision_number DESC
+	`
+
+	var revisions []*models.InterviewNoteRevision
+	err := r.db.Select(&revisions, query, noteID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return revisions, nil
+}
+
+func (r *InterviewNoteRepository) GetRevision(noteID uuid.UUID, revisionNumber int) (*models.InterviewNoteRevision, error) {
+	query := `
+		SELECT ` + noteRevisionColumns + `
+		FROM interview_note_revisions
+		WHERE note_id = $1 AND revision_number = $2
+	`
+
+	revision := &models.InterviewNoteRevision{}
+	err := r.db.Get(revision, query, noteID, revisionNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(errors.ErrorNotFound, "note revision not found")
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	return revision, nil
+}
+
+// GetLatestRevision returns the newest revision, which holds the note's
+// current content.
+func (r *InterviewNoteRepository) GetLatestRevision(noteID uuid.UUID) (*models.InterviewNoteRevision, error) {
+	query := `
+		SELECT ` + noteRevisionColumns + `
+		FROM interview_note_revisions
+		WHERE note_id = $1
+		ORDER BY revision_number DESC
+		LIMIT 1
+	`
+
+	revision := &models.InterviewNoteRevision{}
+	err := r.db.Get(revision, query, noteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(errors.ErrorNotFound, "note revision not found")
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	return revision, nil
+}
+
+// noteContentHash returns the hex SHA-256 and byte size of note content, with
+// nil content treated as empty.
+func noteContentHash(content *string) (string, int) {
+	text := ""
+	if content != nil {
+		text = *content
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:]), len(text)
+}
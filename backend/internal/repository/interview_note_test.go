@@ -285,4 +285,76 @@ func TestInterviewNoteRepository(t *testing.T) {
 		assert.Equal(t, 1, len(notesAfter))
 		assert.Equal(t, models.NoteTypeFeedback, notesAfter[0].NoteType)
 	})
+
+	t.Run("SaveNote_RecordsRevisions", func(t *testing.T) {
+		iv := &models.Interview{
+			UserID:        testUser.ID,
+			ApplicationID: createdApp.ID,
+			ScheduledDate: futureDate,
+			InterviewType: models.InterviewTypeTechnical,
+		}
+		isolatedIv, err := interviewRepo.CreateInterview(iv)
+		require.NoError(t, err)
+
+		first := "Ask about on-call"
+		note, revision, err := noteRepo.SaveNote(isolatedIv.ID, models.NoteTypePreparation, &first, models.NoteFormatHTML, testUser.ID, 0)
+		require.NoError(t, err)
+		require.NotNil(t, revision)
+		assert.Equal(t, 1, revision.RevisionNumber)
+		assert.Equal(t, len(first), revision.ContentSize)
+		assert.Len(t, revision.ContentHash, 64)
+
+		second := "Ask about on-call and team size"
+		updated, revision, err := noteRepo.SaveNote(isolatedIv.ID, models.NoteTypePreparation, &second, models.NoteFormatHTML, testUser.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, note.ID, updated.ID)
+		assert.Equal(t, 2, revision.RevisionNumber)
+
+		t.Run("IdenticalContentIsNotARevision", func(t *testing.T) {
+			_, revision, err := noteRepo.SaveNote(isolatedIv.ID, models.NoteTypePreparation, &second, models.NoteFormatHTML, testUser.ID, 0)
+			require.NoError(t, err)
+			assert.Nil(t, revision)
+		})
+
+		t.Run("ListAndGet", func(t *testing.T) {
+			revisions, err := noteRepo.ListRevisions(note.ID)
+			require.NoError(t, err)
+			require.Len(t, revisions, 2)
+			assert.Equal(t, 2, revisions[0].RevisionNumber)
+			assert.Nil(t, revisions[0].Content)
+
+			original, err := noteRepo.GetRevision(note.ID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, first, *original.Content)
+
+			latest, err := noteRepo.GetLatestRevision(note.ID)
+			require.NoError(t, err)
+			assert.Equal(t, second, *latest.Content)
+
+			_, err = noteRepo.GetRevision(note.ID, 99)
+			assert.Error(t, err)
+		})
+
+		t.Run("PrunesOldRevisions", func(t *testing.T) {
+			third := "Ask about on-call, team size and roadmap"
+			_, revision, err := noteRepo.SaveNote(isolatedIv.ID, models.NoteTypePreparation, &third, models.NoteFormatHTML, testUser.ID, 2)
+			require.NoError(t, err)
+			assert.Equal(t, 3, revision.RevisionNumber)
+
+			revisions, err := noteRepo.ListRevisions(note.ID)
+			require.NoError(t, err)
+			require.Len(t, revisions, 2)
+			assert.Equal(t, 2, revisions[1].RevisionNumber)
+		})
+
+		t.Run("FormatChangeIsARevision", func(t *testing.T) {
+			third := "Ask about on-call, team size and roadmap"
+			saved, revision, err := noteRepo.SaveNote(isolatedIv.ID, models.NoteTypePreparation, &third, models.NoteFormatMarkdown, testUser.ID, 0)
+			require.NoError(t, err)
+			require.NotNil(t, revision)
+			assert.Equal(t, 4, revision.RevisionNumber)
+			assert.Equal(t, models.NoteFormatMarkdown, saved.ContentFormat)
+			assert.Equal(t, models.NoteFormatMarkdown, revision.ContentFormat)
+		})
+	})
 }
@@ -46,13 +46,14 @@ func (r *InterviewQuestionRepository) CreateInterviewQuestion(interviewQuestion
 
 	query := `
 		INSERT INTO interview_questions (
-			id, interview_id, question_text, answer_text, "order", created_at, updated_at
+			id, interview_id, question_text, answer_text, "order", bank_entry_id, story_id, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.db.Exec(query, interviewQuestion.ID, interviewQuestion.InterviewID,
 		interviewQuestion.QuestionText, interviewQuestion.AnswerText, interviewQuestion.Order,
+		interviewQuestion.BankEntryID, interviewQuestion.StoryID,
 		interviewQuestion.CreatedAt, interviewQuestion.UpdatedAt,
 	)
 	if err != nil {
@@ -86,16 +87,16 @@ func (r *InterviewQuestionRepository) CreateInterviewQuestions(questions []*mode
 
 	query := `
 		INSERT INTO interview_questions (
-			id, interview_id, question_text, answer_text, "order", created_at, updated_at
+			id, interview_id, question_text, answer_text, "order", bank_entry_id, story_id, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	for _, q := range questions {
 		_, err := r.db.Exec(
 			query,
 			q.ID, q.InterviewID, q.QuestionText, q.AnswerText,
-			q.Order, q.CreatedAt, q.UpdatedAt,
+			q.Order, q.BankEntryID, q.StoryID, q.CreatedAt, q.UpdatedAt,
 		)
 		if err != nil {
 			return nil, errors.ConvertError(err)
@@ -107,7 +108,7 @@ func (r *InterviewQuestionRepository) CreateInterviewQuestions(questions []*mode
 
 func (r *InterviewQuestionRepository) GetInterviewQuestionByID(interviewQuestionID uuid.UUID) (*models.InterviewQuestion, error) {
 	query := `
-		SELECT id, interview_id, question_text, answer_text, "order", created_at, updated_at
+		SELECT id, interview_id, question_text, answer_text, "order", bank_entry_id, story_id, created_at, updated_at
 		FROM interview_questions
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -172,7 +173,7 @@ func (r *InterviewQuestionRepository) UpdateInterviewQuestion(interviewQuestionI
 
 func (r *InterviewQuestionRepository) GetInterviewQuestionByInterviewID(interviewID uuid.UUID) ([]*models.InterviewQuestion, error) {
 	query := `
-		SELECT id, interview_id, question_text, answer_text, "order", created_at, updated_at
+		SELECT id, interview_id, question_text, answer_text, "order", bank_entry_id, story_id, created_at, updated_at
 		FROM interview_questions
 		WHERE interview_id = $1 AND deleted_at IS NULL
 		ORDER BY "order" ASC
@@ -3,6 +3,7 @@ package repository
 import (
 	"ditto-backend/internal/models"
 	"ditto-backend/internal/testutil"
+	"ditto-backend/pkg/errors"
 	"testing"
 	"time"
 
@@ -148,6 +149,65 @@ func TestInterviewRepository(t *testing.T) {
 		})
 	})
 
+	t.Run("CreateInterviewWithInterviewers", func(t *testing.T) {
+		importApp := testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID)
+		createdImportApp, err := applicationRepo.CreateApplication(testUser.ID, importApp)
+		require.NoError(t, err)
+
+		uid := "invite-123@calendar.example.com"
+		newImported := func() *models.Interview {
+			return &models.Interview{
+				UserID:        testUser.ID,
+				ApplicationID: createdImportApp.ID,
+				ScheduledDate: futureDate,
+				InterviewType: models.InterviewTypeTechnical,
+				ICSUID:        &uid,
+			}
+		}
+
+		t.Run("SavesInterviewers", func(t *testing.T) {
+			created, interviewers, err := interviewRepo.CreateInterviewWithInterviewers(newImported(), []*models.Interviewer{
+				{Name: "Ada Lovelace"},
+				{Name: "Alan Turing"},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, interviewers, 2)
+			for _, interviewer := range interviewers {
+				assert.Equal(t, created.ID, interviewer.InterviewID)
+			}
+
+			saved, err := NewInterviewerRepository(db.Database).GetInterviewerByInterview(created.ID)
+			require.NoError(t, err)
+			assert.Len(t, saved, 2)
+		})
+
+		t.Run("RejectsDuplicateUID", func(t *testing.T) {
+			_, _, err := interviewRepo.CreateInterviewWithInterviewers(newImported(), []*models.Interviewer{{Name: "Grace Hopper"}})
+
+			require.Error(t, err)
+			assert.Equal(t, errors.ErrorConflict, errors.ConvertError(err).Code)
+
+			interviews, err := interviewRepo.GetInterviewsByApplicationID(createdImportApp.ID, testUser.ID)
+			require.NoError(t, err)
+			assert.Len(t, interviews, 1)
+		})
+
+		t.Run("SameUIDForAnotherUser", func(t *testing.T) {
+			otherApp := testutil.CreateTestApplication(testUser2.ID, createdJob.ID, statusID)
+			createdOtherApp, err := applicationRepo.CreateApplication(testUser2.ID, otherApp)
+			require.NoError(t, err)
+
+			interview := newImported()
+			interview.UserID = testUser2.ID
+			interview.ApplicationID = createdOtherApp.ID
+
+			_, _, err = interviewRepo.CreateInterviewWithInterviewers(interview, nil)
+
+			require.NoError(t, err)
+		})
+	})
+
 	t.Run("GetInterviewByID", func(t *testing.T) {
 		interview := &models.Interview{
 			UserID:        testUser.ID,
@@ -406,4 +466,49 @@ func TestInterviewRepository(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, 3, nextRound)
 	})
+
+	t.Run("GetAllRoundsSummaryByCompany", func(t *testing.T) {
+		otherCompany, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Rounds Co", "roundsco.com"))
+		require.NoError(t, err)
+
+		otherJob, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(otherCompany.ID, "Engineer", "desc"))
+		require.NoError(t, err)
+
+		firstApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, otherJob.ID, statusID))
+		require.NoError(t, err)
+		secondApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, otherJob.ID, statusID))
+		require.NoError(t, err)
+
+		interviewerRepo := NewInterviewerRepository(db.Database)
+		questionRepo := NewInterviewQuestionRepository(db.Database)
+
+		for _, appID := range []uuid.UUID{firstApp.ID, secondApp.ID} {
+			interview, err := interviewRepo.CreateInterview(&models.Interview{
+				UserID:        testUser.ID,
+				ApplicationID: appID,
+				ScheduledDate: futureDate,
+				InterviewType: models.InterviewTypeTechnical,
+			})
+			require.NoError(t, err)
+
+			_, err = interviewerRepo.CreateInterviewer(&models.Interviewer{InterviewID: interview.ID, Name: "Pat"})
+			require.NoError(t, err)
+			_, err = questionRepo.CreateInterviewQuestion(&models.InterviewQuestion{InterviewID: interview.ID, QuestionText: "Design a cache"})
+			require.NoError(t, err)
+		}
+
+		rounds, err := interviewRepo.GetAllRoundsSummaryByCompany(otherCompany.ID, testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, rounds, 2)
+		for _, round := range rounds {
+			require.Len(t, round.Interviewers, 1)
+			assert.Equal(t, "Pat", round.Interviewers[0].Name)
+			require.Len(t, round.Questions, 1)
+			assert.Equal(t, "Design a cache", round.Questions[0].QuestionText)
+		}
+
+		rounds, err = interviewRepo.GetAllRoundsSummaryByCompany(otherCompany.ID, testUser2.ID)
+		require.NoError(t, err)
+		assert.Empty(t, rounds)
+	})
 }
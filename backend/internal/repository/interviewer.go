@@ -47,7 +47,9 @@ func (r *InterviewerRepository) CreateInterviewer(interviewer *models.Interviewe
 
 func (r *InterviewerRepository) GetInterviewerByInterview(interviewID uuid.UUID) ([]*models.Interviewer, error) {
 	query := `
-		SELECT id, interview_id, name, role, created_at, updated_at
+		SELECT
+			id, interview_id, name, role, thank_you_sent, thank_you_sent_at,
+			thank_you_channel, thank_you_template, created_at, updated_at
 		FROM interviewers
 		WHERE interview_id = $1 AND deleted_at IS NULL
 	`
@@ -63,7 +65,9 @@ func (r *InterviewerRepository) GetInterviewerByInterview(interviewID uuid.UUID)
 
 func (r *InterviewerRepository) GetInterviewerByID(interviewerID uuid.UUID) (*models.Interviewer, error) {
 	query := `
-		SELECT id, interview_id, name, role, created_at, updated_at
+		SELECT
+			id, interview_id, name, role, thank_you_sent, thank_you_sent_at,
+			thank_you_channel, thank_you_template, created_at, updated_at
 		FROM interviewers
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -174,6 +174,25 @@ func TestInterviewerRepository(t *testing.T) {
 			assert.Equal(t, "Updated Name", updated.Name)
 		})
 
+		t.Run("ThankYou", func(t *testing.T) {
+			assert.False(t, created.ThankYouSent)
+
+			sentAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+			updated, err := interviewerRepo.UpdateInterviewer(created.ID, map[string]any{
+				"thank_you_sent":     true,
+				"thank_you_sent_at":  sentAt,
+				"thank_you_channel":  models.ThankYouChannelEmail,
+				"thank_you_template": "Thanks for your time today",
+			})
+
+			require.NoError(t, err)
+			assert.True(t, updated.ThankYouSent)
+			require.NotNil(t, updated.ThankYouSentAt)
+			assert.Equal(t, "2026-03-02", updated.ThankYouSentAt.Format("2006-01-02"))
+			assert.Equal(t, models.ThankYouChannelEmail, *updated.ThankYouChannel)
+			assert.Equal(t, "Thanks for your time today", *updated.ThankYouTemplate)
+		})
+
 		t.Run("NotFound", func(t *testing.T) {
 			updates := map[string]any{"name": "Ghost"}
 
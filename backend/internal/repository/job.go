@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type JobRepository struct {
@@ -52,11 +53,11 @@ func (r *JobRepository) CreateJob(userID uuid.UUID, job *models.Job) (*models.Jo
 	job.IsExpired = false
 
 	query := `
-        INSERT INTO jobs (id, company_id, title, job_description, location, job_type, min_salary, max_salary, currency, is_expired, source_url, platform, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        INSERT INTO jobs (id, company_id, title, job_description, location, job_type, min_salary, max_salary, currency, pay_period, is_expired, source_url, platform, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
     `
 
-	_, err = tx.Exec(query, job.ID, job.CompanyID, job.Title, job.JobDescription, job.Location, job.JobType, job.MinSalary, job.MaxSalary, job.Currency, job.IsExpired, job.SourceURL, job.Platform, job.CreatedAt, job.UpdatedAt)
+	_, err = tx.Exec(query, job.ID, job.CompanyID, job.Title, job.JobDescription, job.Location, job.JobType, job.MinSalary, job.MaxSalary, job.Currency, job.PayPeriod, job.IsExpired, job.SourceURL, job.Platform, job.CreatedAt, job.UpdatedAt)
 	if err != nil {
 		return nil, errors.ConvertError(err)
 	}
@@ -91,7 +92,7 @@ func (r *JobRepository) GetJobsByUser(userID uuid.UUID, filters *JobFilters) ([]
 
 	query := `
         SELECT j.id, j.company_id, j.title, j.job_description, j.location, j.job_type,
-            j.min_salary, j.max_salary, j.currency, j.is_expired, j.source_url, j.platform, j.created_at, j.updated_at
+            j.min_salary, j.max_salary, j.currency, j.pay_period, j.is_expired, j.expiry_checked_at, j.source_url, j.platform, j.created_at, j.updated_at
         FROM jobs j
         INNER JOIN user_jobs uj ON j.id = uj.id
         WHERE uj.user_id = $1 AND j.deleted_at IS NULL
@@ -158,7 +159,7 @@ func (r *JobRepository) GetJobByID(jobID, userID uuid.UUID) (*models.Job, error)
 	job := &models.Job{}
 	query := `
         SELECT j.id, j.company_id, j.title, j.job_description, j.location,
-            j.job_type, j.min_salary, j.max_salary, j.currency, j.is_expired, j.source_url, j.platform, j.created_at, j.updated_at
+            j.job_type, j.min_salary, j.max_salary, j.currency, j.pay_period, j.is_expired, j.expiry_checked_at, j.source_url, j.platform, j.created_at, j.updated_at
         FROM jobs j
         INNER JOIN user_jobs uj ON j.id = uj.id
         WHERE j.id = $1 AND uj.user_id = $2 AND j.deleted_at IS NULL
@@ -186,9 +187,9 @@ func (r *JobRepository) GetJobsWithCompany(userID uuid.UUID, filters *JobFilters
 
 	query := `
         SELECT j.id, j.company_id, j.title, j.job_description, j.location,
-            j.job_type, j.min_salary, j.max_salary, j.currency, j.is_expired, j.source_url, j.platform, j.created_at, j.updated_at,
+            j.job_type, j.min_salary, j.max_salary, j.currency, j.pay_period, j.is_expired, j.expiry_checked_at, j.source_url, j.platform, j.created_at, j.updated_at,
             c.id as "company.id", c.name as "company.name", c.description as "company.description", c.website as "company.website",
-            c.logo_url as "company.logo_url", c.created_at as "company.created_at", c.updated_at as "company.updated_at",
+            c.logo_url as "company.logo_url", c.created_at as "company.created_at", c.updated_at as "company.updated_at"
         FROM jobs j
         INNER JOIN user_jobs uj ON j.id = uj.id
         INNER JOIN companies c ON j.company_id = c.id
@@ -211,8 +212,8 @@ func (r *JobRepository) GetJobsWithCompany(userID uuid.UUID, filters *JobFilters
 
 		err := rows.Scan(
 			&job.ID, &job.CompanyID, &job.Title, &job.JobDescription,
-			&job.Location, &job.JobType, &job.MinSalary, &job.MaxSalary, &job.Currency,
-			&job.IsExpired, &job.SourceURL, &job.Platform, &job.CreatedAt, &job.UpdatedAt,
+			&job.Location, &job.JobType, &job.MinSalary, &job.MaxSalary, &job.Currency, &job.PayPeriod,
+			&job.IsExpired, &job.ExpiryCheckedAt, &job.SourceURL, &job.Platform, &job.CreatedAt, &job.UpdatedAt,
 			&company.ID, &company.Name, &company.Description, &company.Website,
 			&company.LogoURL, &company.CreatedAt, &company.UpdatedAt,
 		)
@@ -358,3 +359,78 @@ func (r *JobRepository) GetJobCount(userID uuid.UUID, filters *JobFilters) (int,
 
 	return count, nil
 }
+
+// GetJobsDueForExpiryCheck returns unexpired jobs with a source URL that are
+// still attached to an active application and haven't been checked since
+// checkedBefore. Never-attempted jobs come first, then the least recently
+// attempted, so jobs whose checks keep failing don't starve the rest.
+func (r *JobRepository) GetJobsDueForExpiryCheck(checkedBefore time.Time, limit int) ([]*models.Job, error) {
+	query := `
+        SELECT j.id, j.company_id, j.title, j.job_description, j.location,
+            j.job_type, j.min_salary, j.max_salary, j.currency, j.pay_period, j.is_expired, j.expiry_checked_at, j.source_url, j.platform, j.created_at, j.updated_at
+        FROM jobs j
+        WHERE j.deleted_at IS NULL
+            AND j.is_expired = FALSE
+            AND j.source_url IS NOT NULL AND j.source_url != ''
+            AND (j.expiry_checked_at IS NULL OR j.expiry_checked_at < $1)
+            AND EXISTS (
+                SELECT 1 FROM applications a
+                JOIN application_status s ON a.application_status_id = s.id
+                WHERE a.job_id = j.id AND a.deleted_at IS NULL
+                    AND s.name IN ('Saved', 'Applied', 'Interview')
+            )
+        ORDER BY j.expiry_attempted_at ASC NULLS FIRST
+        LIMIT $2
+    `
+
+	var jobs []*models.Job
+	err := r.db.Select(&jobs, query, checkedBefore, limit)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return jobs, nil
+}
+
+// MarkExpiryAttempted records that the checker picked up jobs, whether or not
+// their check succeeds, moving them to the back of the queue.
+func (r *JobRepository) MarkExpiryAttempted(jobIDs []uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET expiry_attempted_at = $1
+        WHERE id = ANY($2)
+    `
+
+	_, err := r.db.Exec(query, time.Now(), pq.Array(jobIDs))
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
+
+// MarkExpiryChecked records an expiry check for a job. A job that has been
+// marked expired is never flipped back by the checker.
+func (r *JobRepository) MarkExpiryChecked(jobID uuid.UUID, expired bool) error {
+	query := `
+        UPDATE jobs
+        SET expiry_checked_at = $1, is_expired = is_expired OR $2
+        WHERE id = $3 AND deleted_at IS NULL
+    `
+
+	result, err := r.db.Exec(query, time.Now(), expired, jobID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "job not found")
+	}
+
+	return nil
+}
@@ -211,6 +211,25 @@ func TestJobRepositoryBasics(t *testing.T) {
 		require.Nil(t, deletedJob)
 	})
 
+	t.Run("GetJobsWithCompany", func(t *testing.T) {
+		job, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(testCompany.ID, "Checked Job", "desc"))
+		require.NoError(t, err)
+		require.NoError(t, jobRepo.MarkExpiryChecked(job.ID, false))
+
+		jobs, err := jobRepo.GetJobsWithCompany(testUser.ID, nil)
+		require.NoError(t, err)
+
+		var found *JobWithCompany
+		for _, j := range jobs {
+			if j.ID == job.ID {
+				found = j
+			}
+		}
+		require.NotNil(t, found)
+		require.NotNil(t, found.ExpiryCheckedAt)
+		require.Equal(t, testCompany.Name, found.Company.Name)
+	})
+
 	t.Run("GetJobCount", func(t *testing.T) {
 		// Get count of jobs for user
 		filters := &JobFilters{}
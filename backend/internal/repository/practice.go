@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"database/sql"
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/practice"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PracticeRepository struct {
+	db *sqlx.DB
+}
+
+func NewPracticeRepository(database *database.Database) *PracticeRepository {
+	return &PracticeRepository{
+		db: database.DB,
+	}
+}
+
+// practiceSources lists everything a user can practice: their question bank
+// entries, plus logged interview questions that are not linked to an entry
+// (a linked question is practiced through its entry). Expects the user ID as
+// $1.
+const practiceSources = `
+	practice_sources AS (
+		SELECT 'bank_entry' AS source,
+			NULL::uuid AS interview_question_id,
+			qb.id AS bank_entry_id,
+			qb.question_text,
+			qb.model_answer AS answer_text,
+			qb.topics,
+			NULL::text AS company_name,
+			NULL::uuid AS interview_id
+		FROM question_bank_entries qb
+		WHERE qb.user_id = $1 AND qb.deleted_at IS NULL
+
+		UNION ALL
+
+		SELECT 'interview_question' AS source,
+			iq.id AS interview_question_id,
+			NULL::uuid AS bank_entry_id,
+			iq.question_text,
+			iq.answer_text,
+			'{}'::text[] AS topics,
+			c.name AS company_name,
+			i.id AS interview_id
+		FROM interview_questions iq
+		JOIN interviews i ON iq.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE i.user_id = $1 AND iq.bank_entry_id IS NULL
+			AND iq.deleted_at IS NULL AND i.deleted_at IS NULL AND a.deleted_at IS NULL
+	)
+`
+
+const practiceCardMatchesSource = `
+	(pc.interview_question_id = s.interview_question_id OR pc.bank_entry_id = s.bank_entry_id)
+`
+
+const practiceItemColumns = `
+	pc.id, pc.user_id, pc.interview_question_id, pc.bank_entry_id, pc.ease_factor,
+	pc.interval_days, pc.repetitions, pc.due_at, pc.last_reviewed_at, pc.created_at, pc.updated_at,
+	s.source, s.question_text, s.answer_text, s.topics, s.company_name, s.interview_id
+`
+
+// SyncCards brings the user's cards in line with their practice sources. A
+// question linked to a bank entry since it got a card hands the card, and its
+// schedule, to the entry if the entry has none yet. Cards left without a
+// source are deleted, and every source without a card gets one, due
+// immediately.
+func (r *PracticeRepository) SyncCards(userID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// The most recently reviewed card wins when several linked questions
+	// share an entry
+	_, err = tx.Exec(`
+		UPDATE practice_cards pc
+		SET interview_question_id = NULL, bank_entry_id = linked.bank_entry_id
+		FROM (
+			SELECT DISTINCT ON (iq.bank_entry_id) card.id, iq.bank_entry_id
+			FROM practice_cards card
+			JOIN interview_questions iq ON iq.id = card.interview_question_id
+			JOIN question_bank_entries qb ON qb.id = iq.bank_entry_id AND qb.deleted_at IS NULL
+			WHERE card.user_id = $1
+				AND NOT EXISTS (SELECT 1 FROM practice_cards entry_card WHERE entry_card.bank_entry_id = iq.bank_entry_id)
+			ORDER BY iq.bank_entry_id, card.last_reviewed_at DESC NULLS LAST
+		) linked
+		WHERE pc.id = linked.id
+	`, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	_, err = tx.Exec(`
+		WITH `+practiceSources+`
+		DELETE FROM practice_cards pc
+		WHERE pc.user_id = $1
+			AND NOT EXISTS (SELECT 1 FROM practice_sources s WHERE `+practiceCardMatchesSource+`)
+	`, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	_, err = tx.Exec(`
+		WITH `+practiceSources+`
+		INSERT INTO practice_cards (user_id, interview_question_id, bank_entry_id, due_at)
+		SELECT $1, s.interview_question_id, s.bank_entry_id, $2
+		FROM practice_sources s
+		WHERE NOT EXISTS (
+			SELECT 1 FROM practice_cards pc WHERE `+practiceCardMatchesSource+`
+		)
+		ON CONFLICT DO NOTHING
+	`, userID, time.Now())
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
+
+// ListDue returns cards due at or before asOf. Cards that have been reviewed
+// before come first, most overdue first, then new cards.
+func (r *PracticeRepository) ListDue(userID uuid.UUID, asOf time.Time, limit int) ([]*models.PracticeItem, error) {
+	query := `
+		WITH ` + practiceSources + `
+		SELECT ` + practiceItemColumns + `
+		FROM practice_cards pc
+		JOIN practice_sources s ON ` + practiceCardMatchesSource + `
+		WHERE pc.user_id = $1 AND pc.due_at <= $2
+		ORDER BY pc.last_reviewed_at IS NULL, pc.due_at ASC, pc.created_at ASC
+		LIMIT $3
+	`
+
+	var items []*models.PracticeItem
+	err := r.db.Select(&items, query, userID, asOf, limit)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return items, nil
+}
+
+// CountDue counts questions due at or before asOf, like ListDue. Sources
+// without a card yet are counted too, since new cards are due as soon as they
+// are created.
+func (r *PracticeRepository) CountDue(userID uuid.UUID, asOf time.Time) (int, error) {
+	query := `
+		WITH ` + practiceSources + `
+		SELECT COUNT(*)
+		FROM practice_sources s
+		LEFT JOIN practice_cards pc ON ` + practiceCardMatchesSource + `
+		WHERE pc.id IS NULL OR pc.due_at <= $2
+	`
+
+	var count int
+	err := r.db.Get(&count, query, userID, asOf)
+	if err != nil {
+		return 0, errors.ConvertError(err)
+	}
+
+	return count, nil
+}
+
+func (r *PracticeRepository) GetItem(cardID, userID uuid.UUID) (*models.PracticeItem, error) {
+	query := `
+		WITH ` + practiceSources + `
+		SELECT ` + practiceItemColumns + `
+		FROM practice_cards pc
+		JOIN practice_sources s ON ` + practiceCardMatchesSource + `
+		WHERE pc.user_id = $1 AND pc.id = $2
+	`
+
+	item := &models.PracticeItem{}
+	err := r.db.Get(item, query, userID, cardID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(errors.ErrorNotFound, "practice card not found")
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	return item, nil
+}
+
+// RecordReview grades a card, reschedules it with SM-2 and logs the attempt.
+func (r *PracticeRepository) RecordReview(cardID, userID uuid.UUID, grade int, reviewedAt time.Time) (*models.PracticeReview, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var card models.PracticeCard
+	err = tx.Get(&card, `
+		SELECT id, user_id, interview_question_id, bank_entry_id, ease_factor, interval_days,
+			repetitions, due_at, last_reviewed_at, created_at, updated_at
+		FROM practice_cards
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`, cardID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New(errors.ErrorNotFound, "practice card not found")
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	state, dueAt := practice.Review(practice.State{
+		EaseFactor:   card.EaseFactor,
+		IntervalDays: card.IntervalDays,
+		Repetitions:  card.Repetitions,
+	}, grade, reviewedAt)
+
+	_, err = tx.Exec(`
+		UPDATE practice_cards
+		SET ease_factor = $1, interval_days = $2, repetitions = $3, due_at = $4,
+			last_reviewed_at = $5, updated_at = $5
+		WHERE id = $6
+	`, state.EaseFactor, state.IntervalDays, state.Repetitions, dueAt, reviewedAt, cardID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	review := &models.PracticeReview{
+		ID:           uuid.New(),
+		CardID:       cardID,
+		UserID:       userID,
+		Grade:        grade,
+		EaseFactor:   state.EaseFactor,
+		IntervalDays: state.IntervalDays,
+		ReviewedAt:   reviewedAt,
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO practice_reviews (id, card_id, user_id, grade, ease_factor, interval_days, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, review.ID, review.CardID, review.UserID, review.Grade, review.EaseFactor, review.IntervalDays, review.ReviewedAt)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return review, nil
+}
+
+// ListReviews returns a card's attempts, newest first.
+func (r *PracticeRepository) ListReviews(cardID, userID uuid.UUID) ([]*models.PracticeReview, error) {
+	query := `
+		SELECT id, card_id, user_id, grade, ease_factor, interval_days, reviewed_at
+		FROM practice_reviews
+		WHERE card_id = $1 AND user_id = $2
+		ORDER BY reviewed_at DESC
+	`
+
+	var reviews []*models.PracticeReview
+	err := r.db.Select(&reviews, query, cardID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPracticeRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	interviewRepo := NewInterviewRepository(db.Database)
+	questionRepo := NewInterviewQuestionRepository(db.Database)
+	bankRepo := NewQuestionBankRepository(db.Database)
+	practiceRepo := NewPracticeRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("practice@example.com", "Practice User", string(hashedPassword))
+	require.NoError(t, err)
+	otherUser, err := userRepo.CreateUser("practice2@example.com", "Other Practice User", string(hashedPassword))
+	require.NoError(t, err)
+
+	company, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Practice Co", "practice.example"))
+	require.NoError(t, err)
+	job, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(company.ID, "Backend Engineer", "APIs"))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	app, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, job.ID, statusID))
+	require.NoError(t, err)
+	interview, err := interviewRepo.CreateInterview(testutil.CreateTestInterview(testUser.ID, app.ID, time.Now(), models.InterviewTypeTechnical))
+	require.NoError(t, err)
+
+	entry, err := bankRepo.Create(&models.QuestionBankEntry{UserID: testUser.ID, QuestionText: "Design a rate limiter"})
+	require.NoError(t, err)
+
+	_, err = questionRepo.CreateInterviewQuestions([]*models.InterviewQuestion{
+		{InterviewID: interview.ID, QuestionText: "Design a rate limiter", BankEntryID: &entry.ID},
+		{InterviewID: interview.ID, QuestionText: "Tell me about a conflict"},
+	})
+	require.NoError(t, err)
+
+	t.Run("CountDue_BeforeSync", func(t *testing.T) {
+		count, err := practiceRepo.CountDue(testUser.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		// The linked question is practiced through its bank entry
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("SyncCards_IsIdempotent", func(t *testing.T) {
+		require.NoError(t, practiceRepo.SyncCards(testUser.ID))
+		require.NoError(t, practiceRepo.SyncCards(testUser.ID))
+
+		var cards int
+		err := db.Get(&cards, "SELECT COUNT(*) FROM practice_cards WHERE user_id = $1", testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, cards)
+	})
+
+	var due []*models.PracticeItem
+	t.Run("ListDue", func(t *testing.T) {
+		due, err = practiceRepo.ListDue(testUser.ID, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+
+		for _, item := range due {
+			if item.Source == models.PracticeSourceInterviewQuestion {
+				require.NotNil(t, item.CompanyName)
+				assert.Equal(t, "Practice Co", *item.CompanyName)
+			}
+		}
+
+		other, err := practiceRepo.ListDue(otherUser.ID, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, other)
+	})
+
+	t.Run("RecordReview_Reschedules", func(t *testing.T) {
+		require.NotEmpty(t, due)
+		cardID := due[0].ID
+		reviewedAt := time.Now()
+
+		review, err := practiceRepo.RecordReview(cardID, testUser.ID, 5, reviewedAt)
+		require.NoError(t, err)
+		assert.Equal(t, 1, review.IntervalDays)
+
+		item, err := practiceRepo.GetItem(cardID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, item.Repetitions)
+		assert.True(t, item.DueAt.After(reviewedAt))
+		require.NotNil(t, item.LastReviewedAt)
+
+		remaining, err := practiceRepo.ListDue(testUser.ID, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Len(t, remaining, 1)
+
+		reviews, err := practiceRepo.ListReviews(cardID, testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, 5, reviews[0].Grade)
+	})
+
+	t.Run("RecordReview_OtherUser", func(t *testing.T) {
+		require.NotEmpty(t, due)
+		_, err := practiceRepo.RecordReview(due[0].ID, otherUser.ID, 3, time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("CountDue_MatchesListDue", func(t *testing.T) {
+		asOf := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+		_, err := db.Exec("UPDATE practice_cards SET due_at = $1 WHERE user_id = $2", asOf, testUser.ID)
+		require.NoError(t, err)
+
+		count, err := practiceRepo.CountDue(testUser.ID, asOf)
+		require.NoError(t, err)
+		listed, err := practiceRepo.ListDue(testUser.ID, asOf, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "cards due exactly at asOf are counted")
+		assert.Len(t, listed, count)
+	})
+
+	t.Run("SyncCards_MovesRelinkedQuestionCard", func(t *testing.T) {
+		var questionID uuid.UUID
+		require.NoError(t, db.Get(&questionID, "SELECT id FROM interview_questions WHERE question_text = 'Tell me about a conflict'"))
+		var cardID uuid.UUID
+		require.NoError(t, db.Get(&cardID, "SELECT id FROM practice_cards WHERE interview_question_id = $1", questionID))
+
+		conflictEntry, err := bankRepo.Create(&models.QuestionBankEntry{UserID: testUser.ID, QuestionText: "Tell me about a conflict"})
+		require.NoError(t, err)
+		_, err = questionRepo.UpdateInterviewQuestion(questionID, map[string]any{"bank_entry_id": conflictEntry.ID})
+		require.NoError(t, err)
+
+		require.NoError(t, practiceRepo.SyncCards(testUser.ID))
+
+		var entryID uuid.UUID
+		require.NoError(t, db.Get(&entryID, "SELECT bank_entry_id FROM practice_cards WHERE id = $1", cardID))
+		assert.Equal(t, conflictEntry.ID, entryID, "the entry keeps the question's schedule")
+
+		var cards int
+		require.NoError(t, db.Get(&cards, "SELECT COUNT(*) FROM practice_cards WHERE user_id = $1", testUser.ID))
+		assert.Equal(t, 2, cards)
+	})
+
+	t.Run("SyncCards_DeletesCardsWithoutSource", func(t *testing.T) {
+		created, err := questionRepo.CreateInterviewQuestions([]*models.InterviewQuestion{
+			{InterviewID: interview.ID, QuestionText: "Rate limiter follow-up"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, practiceRepo.SyncCards(testUser.ID))
+
+		// The rate limiter entry already has a card, so this one has nowhere to go
+		_, err = questionRepo.UpdateInterviewQuestion(created[0].ID, map[string]any{"bank_entry_id": entry.ID})
+		require.NoError(t, err)
+		require.NoError(t, practiceRepo.SyncCards(testUser.ID))
+
+		var orphans int
+		require.NoError(t, db.Get(&orphans, "SELECT COUNT(*) FROM practice_cards WHERE interview_question_id = $1", created[0].ID))
+		assert.Zero(t, orphans)
+
+		var cards int
+		require.NoError(t, db.Get(&cards, "SELECT COUNT(*) FROM practice_cards WHERE user_id = $1", testUser.ID))
+		assert.Equal(t, 2, cards)
+	})
+}
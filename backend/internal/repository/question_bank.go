@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/questionbank"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type QuestionBankRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuestionBankRepository(database *database.Database) *QuestionBankRepository {
+	return &QuestionBankRepository{
+		db: database.DB,
+	}
+}
+
+type QuestionBankFilters struct {
+	Topic      string
+	Difficulty string
+	Search     string
+}
+
+const questionBankColumns = `
+	qb.id, qb.user_id, qb.question_text, qb.topics, qb.difficulty, qb.model_answer,
+	qb.created_at, qb.updated_at
+`
+
+// questionBankUsageJoin aggregates how often each entry was asked, counting
+// only questions on interviews that still exist.
+const questionBankUsageJoin = `
+	LEFT JOIN (
+		SELECT iq.bank_entry_id,
+			COUNT(*) AS times_asked,
+			COUNT(DISTINCT j.company_id) AS company_count,
+			MAX(i.scheduled_date) AS last_asked_at
+		FROM interview_questions iq
+		JOIN interviews i ON iq.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE i.user_id = $1 AND iq.deleted_at IS NULL AND i.deleted_at IS NULL
+			AND iq.bank_entry_id IS NOT NULL
+		GROUP BY iq.bank_entry_id
+	) usage ON usage.bank_entry_id = qb.id
+`
+
+func (r *QuestionBankRepository) Create(entry *models.QuestionBankEntry) (*models.QuestionBankEntry, error) {
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+	if entry.Topics == nil {
+		entry.Topics = pq.StringArray{}
+	}
+
+	query := `
+		INSERT INTO question_bank_entries (
+			id, user_id, question_text, topics, difficulty, model_answer, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(query, entry.ID, entry.UserID, entry.QuestionText, entry.Topics,
+		entry.Difficulty, entry.ModelAnswer, entry.CreatedAt, entry.UpdatedAt)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return entry, nil
+}
+
+func (r *QuestionBankRepository) GetByID(id, userID uuid.UUID) (*models.QuestionBankEntryWithStats, error) {
+	query := `
+		SELECT ` + questionBankColumns + `,
+			COALESCE(usage.times_asked, 0) AS times_asked,
+			COALESCE(usage.company_count, 0) AS company_count,
+			usage.last_asked_at
+		FROM question_bank_entries qb
+		` + questionBankUsageJoin + `
+		WHERE qb.id = $2 AND qb.user_id = $1 AND qb.deleted_at IS NULL
+	`
+
+	entry := &models.QuestionBankEntryWithStats{}
+	err := r.db.Get(entry, query, userID, id)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return entry, nil
+}
+
+// List returns the user's bank entries with usage counts, most asked first.
+func (r *QuestionBankRepository) List(userID uuid.UUID, filters *QuestionBankFilters) ([]*models.QuestionBankEntryWithStats, error) {
+	conditions := []string{"qb.user_id = $1", "qb.deleted_at IS NULL"}
+	args := []any{userID}
+	argIndex := 2
+
+	if filters != nil {
+		if filters.Topic != "" {
+			conditions = append(conditions, fmt.Sprintf("$%d = ANY(qb.topics)", argIndex))
+			args = append(args, filters.Topic)
+			argIndex++
+		}
+		if filters.Difficulty != "" {
+			conditions = append(conditions, fmt.Sprintf("qb.difficulty = $%d", argIndex))
+			args = append(args, filters.Difficulty)
+			argIndex++
+		}
+		if filters.Search != "" {
+			conditions = append(conditions, fmt.Sprintf("qb.question_text ILIKE $%d", argIndex))
+			args = append(args, "%"+escapeLike(filters.Search)+"%")
+		}
+	}
+
+	query := `
+		SELECT ` + questionBankColumns + `,
+			COALESCE(usage.times_asked, 0) AS times_asked,
+			COALESCE(usage.company_count, 0) AS company_count,
+			usage.last_asked_at
+		FROM question_bank_entries qb
+		` + questionBankUsageJoin + `
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY times_asked DESC, qb.created_at DESC
+	`
+
+	var entries []*models.QuestionBankEntryWithStats
+	err := r.db.Select(&entries, query, args...)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return entries, nil
+}
+
+// ListCandidates returns every live entry's text for similarity matching.
+func (r *QuestionBankRepository) ListCandidates(userID uuid.UUID) ([]questionbank.Candidate, error) {
+	query := `
+		SELECT id, question_text
+		FROM question_bank_entries
+		WHERE user_id = $1 AND deleted_at IS NULL
+	`
+
+	var candidates []questionbank.Candidate
+	err := r.db.Select(&candidates, query, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return candidates, nil
+}
+
+// GetUsages lists every interview in which the entry was asked, newest first.
+func (r *QuestionBankRepository) GetUsages(id, userID uuid.UUID) ([]*models.QuestionBankUsage, error) {
+	query := `
+		SELECT iq.id AS interview_question_id, i.id AS interview_id, i.application_id,
+			i.scheduled_date, i.round_number, i.interview_type,
+			c.id AS company_id, c.name AS company_name, j.title AS job_title,
+			iq.question_text AS asked_as, iq.answer_text
+		FROM interview_questions iq
+		JOIN interviews i ON iq.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE iq.bank_entry_id = $1 AND i.user_id = $2
+			AND iq.deleted_at IS NULL AND i.deleted_at IS NULL
+		ORDER BY i.scheduled_date DESC, i.round_number DESC
+	`
+
+	var usages []*models.QuestionBankUsage
+	err := r.db.Select(&usages, query, id, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return usages, nil
+}
+
+func (r *QuestionBankRepository) Update(id, userID uuid.UUID, updates map[string]any) (*models.QuestionBankEntryWithStats, error) {
+	if len(updates) == 0 {
+		return r.GetByID(id, userID)
+	}
+
+	setParts := []string{}
+	args := []any{}
+	argIndex := 1
+
+	for field, value := range updates {
+		setParts = append(setParts, fmt.Sprintf("%s = $%d", field, argIndex))
+		args = append(args, value)
+		argIndex++
+	}
+
+	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
+	args = append(args, time.Now())
+	argIndex++
+
+	args = append(args, id, userID)
+
+	query := fmt.Sprintf(`
+		UPDATE question_bank_entries
+		SET %s
+		WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL
+	`, strings.Join(setParts, ", "), argIndex, argIndex+1)
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, errors.New(errors.ErrorNotFound, "question bank entry not found")
+	}
+
+	return r.GetByID(id, userID)
+}
+
+// SoftDelete removes an entry and unlinks the interview questions that
+// pointed at it; the questions themselves are kept.
+func (r *QuestionBankRepository) SoftDelete(id, userID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now()
+
+	result, err := tx.Exec(`
+		UPDATE question_bank_entries
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+	`, now, id, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "question bank entry not found")
+	}
+
+	_, err = tx.Exec(`
+		UPDATE interview_questions
+		SET bank_entry_id = NULL, updated_at = $1
+		WHERE bank_entry_id = $2
+	`, now, id)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
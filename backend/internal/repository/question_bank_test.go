@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestQuestionBankRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	interviewRepo := NewInterviewRepository(db.Database)
+	questionRepo := NewInterviewQuestionRepository(db.Database)
+	bankRepo := NewQuestionBankRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("bank@example.com", "Bank User", string(hashedPassword))
+	require.NoError(t, err)
+	otherUser, err := userRepo.CreateUser("bank2@example.com", "Other Bank User", string(hashedPassword))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	newInterview := func(companyName string, daysAgo int) *models.Interview {
+		company, err := companyRepo.CreateCompany(testutil.CreateTestCompany(companyName, ""))
+		require.NoError(t, err)
+		job, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(company.ID, "Backend Engineer", "APIs"))
+		require.NoError(t, err)
+		app, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, job.ID, statusID))
+		require.NoError(t, err)
+		interview, err := interviewRepo.CreateInterview(&models.Interview{
+			UserID:        testUser.ID,
+			ApplicationID: app.ID,
+			ScheduledDate: time.Now().AddDate(0, 0, -daysAgo),
+			InterviewType: models.InterviewTypeTechnical,
+		})
+		require.NoError(t, err)
+		return interview
+	}
+
+	difficulty := models.QuestionDifficultyHard
+	entry, err := bankRepo.Create(&models.QuestionBankEntry{
+		UserID:       testUser.ID,
+		QuestionText: "Design a rate limiter",
+		Topics:       pq.StringArray{"system-design", "distributed-systems"},
+		Difficulty:   &difficulty,
+	})
+	require.NoError(t, err)
+
+	unused, err := bankRepo.Create(&models.QuestionBankEntry{UserID: testUser.ID, QuestionText: "Reverse a linked list"})
+	require.NoError(t, err)
+
+	first := newInterview("Bank Co One", 10)
+	second := newInterview("Bank Co Two", 2)
+	for _, interview := range []*models.Interview{first, second} {
+		_, err := questionRepo.CreateInterviewQuestion(&models.InterviewQuestion{
+			InterviewID:  interview.ID,
+			QuestionText: "How would you design a rate limiter?",
+			BankEntryID:  &entry.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("GetByID_IncludesUsageStats", func(t *testing.T) {
+		found, err := bankRepo.GetByID(entry.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.TimesAsked)
+		assert.Equal(t, 2, found.CompanyCount)
+		require.NotNil(t, found.LastAskedAt)
+		assert.Equal(t, []string{"system-design", "distributed-systems"}, []string(found.Topics))
+	})
+
+	t.Run("GetByID_OtherUser", func(t *testing.T) {
+		_, err := bankRepo.GetByID(entry.ID, otherUser.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		entries, err := bankRepo.List(testUser.ID, nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, entry.ID, entries[0].ID)
+		assert.Equal(t, 0, entries[1].TimesAsked)
+
+		entries, err = bankRepo.List(testUser.ID, &QuestionBankFilters{Topic: "system-design"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		entries, err = bankRepo.List(testUser.ID, &QuestionBankFilters{Search: "linked"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, unused.ID, entries[0].ID)
+	})
+
+	t.Run("GetUsages", func(t *testing.T) {
+		usages, err := bankRepo.GetUsages(entry.ID, testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, usages, 2)
+		assert.Equal(t, "Bank Co Two", usages[0].CompanyName)
+		assert.Equal(t, "Bank Co One", usages[1].CompanyName)
+
+		usages, err = bankRepo.GetUsages(entry.ID, otherUser.ID)
+		require.NoError(t, err)
+		assert.Empty(t, usages)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		updated, err := bankRepo.Update(unused.ID, testUser.ID, map[string]any{
+			"model_answer": "Iterate with three pointers",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, updated.ModelAnswer)
+		assert.Equal(t, "Iterate with three pointers", *updated.ModelAnswer)
+
+		_, err = bankRepo.Update(unused.ID, otherUser.ID, map[string]any{"model_answer": "nope"})
+		assert.Error(t, err)
+	})
+
+	t.Run("SoftDelete_UnlinksQuestions", func(t *testing.T) {
+		err := bankRepo.SoftDelete(entry.ID, testUser.ID)
+		require.NoError(t, err)
+
+		questions, err := questionRepo.GetInterviewQuestionByInterviewID(first.ID)
+		require.NoError(t, err)
+		require.Len(t, questions, 1)
+		assert.Nil(t, questions[0].BankEntryID)
+
+		candidates, err := bankRepo.ListCandidates(testUser.ID)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, unused.ID, candidates[0].ID)
+	})
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"ditto-backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// HasRole reports whether the user has been granted the named role.
+func (r *UserRepository) HasRole(userID uuid.UUID, role string) (bool, error) {
+	query := `
+        SELECT EXISTS (
+            SELECT 1 FROM user_roles ur
+            JOIN roles ro ON ur.role_id = ro.id
+            WHERE ur.user_id = $1 AND ro.name = $2
+        )
+    `
+
+	var hasRole bool
+	if err := r.db.Get(&hasRole, query, userID, role); err != nil {
+		return false, errors.ConvertError(err)
+	}
+
+	return hasRole, nil
+}
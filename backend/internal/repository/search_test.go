@@ -71,7 +71,7 @@ func TestSearchRepository(t *testing.T) {
 		ApplicationID:  createdAppA.ID,
 		AssessmentType: models.AssessmentTypeTakeHomeProject,
 		Title:          "Distributed Systems Architecture Challenge",
-		DueDate:        "2026-04-01",
+		DueDate:        time.Date(2026, 4, 1, 23, 59, 0, 0, time.UTC),
 		Instructions:   testutil.StringPtr("Design a fault tolerant message queue with replication"),
 	}
 	createdAssessmentA, err := assessmentRepo.CreateAssessment(assessmentA)
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type StoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewStoryRepository(database *database.Database) *StoryRepository {
+	return &StoryRepository{
+		db: database.DB,
+	}
+}
+
+const storyColumns = `
+	s.id, s.user_id, s.title, s.situation, s.task, s.action, s.result, s.competencies,
+	s.created_at, s.updated_at
+`
+
+// storyUsageJoin aggregates how often each story was told, counting only
+// questions on interviews that still exist. Expects the user ID as $1.
+const storyUsageJoin = `
+	LEFT JOIN (
+		SELECT iq.story_id,
+			COUNT(*) AS times_told,
+			COUNT(DISTINCT j.company_id) AS company_count,
+			MAX(i.scheduled_date) AS last_told_at
+		FROM interview_questions iq
+		JOIN interviews i ON iq.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		WHERE i.user_id = $1 AND iq.story_id IS NOT NULL
+			AND iq.deleted_at IS NULL AND i.deleted_at IS NULL
+		GROUP BY iq.story_id
+	) usage ON usage.story_id = s.id
+`
+
+const storyStatsColumns = `
+	COALESCE(usage.times_told, 0) AS times_told,
+	COALESCE(usage.company_count, 0) AS company_count,
+	usage.last_told_at
+`
+
+// interviewerNames is the sorted names of an interview's interviewers, for an
+// interview aliased as i.
+const interviewerNames = `
+	COALESCE((
+		SELECT array_agg(iv.name ORDER BY iv.name)
+		FROM interviewers iv
+		WHERE iv.interview_id = i.id AND iv.deleted_at IS NULL
+	), '{}') AS interviewers
+`
+
+func (r *StoryRepository) Create(story *models.Story) (*models.Story, error) {
+	story.ID = uuid.New()
+	story.CreatedAt = time.Now()
+	story.UpdatedAt = time.Now()
+	if story.Competencies == nil {
+		story.Competencies = pq.StringArray{}
+	}
+
+	query := `
+		INSERT INTO stories (
+			id, user_id, title, situation, task, action, result, competencies, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.Exec(query, story.ID, story.UserID, story.Title, story.Situation, story.Task,
+		story.Action, story.Result, story.Competencies, story.CreatedAt, story.UpdatedAt)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return story, nil
+}
+
+func (r *StoryRepository) GetByID(id, userID uuid.UUID) (*models.StoryWithStats, error) {
+	query := `
+		SELECT ` + storyColumns + `, ` + storyStatsColumns + `
+		FROM stories s
+		` + storyUsageJoin + `
+		WHERE s.id = $2 AND s.user_id = $1 AND s.deleted_at IS NULL
+	`
+
+	story := &models.StoryWithStats{}
+	err := r.db.Get(story, query, userID, id)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return story, nil
+}
+
+// List returns the user's stories, optionally only those tagged with a
+// competency, least recently told first so fresh stories surface.
+func (r *StoryRepository) List(userID uuid.UUID, competency string) ([]*models.StoryWithStats, error) {
+	conditions := []string{"s.user_id = $1", "s.deleted_at IS NULL"}
+	args := []any{userID}
+
+	if competency != "" {
+		conditions = append(conditions, "$2 = ANY(s.competencies)")
+		args = append(args, competency)
+	}
+
+	query := `
+		SELECT ` + storyColumns + `, ` + storyStatsColumns + `
+		FROM stories s
+		` + storyUsageJoin + `
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY usage.last_told_at ASC NULLS FIRST, s.title ASC
+	`
+
+	var stories []*models.StoryWithStats
+	err := r.db.Select(&stories, query, args...)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return stories, nil
+}
+
+// GetUsages lists every time the user's stories were told, newest first. A
+// nil storyID returns usages of all stories; a nil companyID means any
+// company.
+func (r *StoryRepository) GetUsages(userID uuid.UUID, storyID, companyID *uuid.UUID) ([]*models.StoryUsage, error) {
+	conditions := []string{
+		"s.user_id = $1", "s.deleted_at IS NULL",
+		"iq.deleted_at IS NULL", "i.deleted_at IS NULL", "a.deleted_at IS NULL",
+	}
+	args := []any{userID}
+	argIndex := 2
+
+	if storyID != nil {
+		conditions = append(conditions, fmt.Sprintf("s.id = $%d", argIndex))
+		args = append(args, *storyID)
+		argIndex++
+	}
+	if companyID != nil {
+		conditions = append(conditions, fmt.Sprintf("c.id = $%d", argIndex))
+		args = append(args, *companyID)
+	}
+
+	query := `
+		SELECT s.id AS story_id, iq.id AS interview_question_id, iq.question_text,
+			i.id AS interview_id, i.scheduled_date, i.round_number, i.interview_type,
+			c.id AS company_id, c.name AS company_name,
+			` + interviewerNames + `
+		FROM interview_questions iq
+		JOIN stories s ON iq.story_id = s.id
+		JOIN interviews i ON iq.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY i.scheduled_date DESC, i.round_number DESC
+	`
+
+	var usages []*models.StoryUsage
+	err := r.db.Select(&usages, query, args...)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return usages, nil
+}
+
+// GetAudience returns the company and interviewers of one of the user's
+// interviews.
+func (r *StoryRepository) GetAudience(interviewID, userID uuid.UUID) (*models.StoryAudience, error) {
+	query := `
+		SELECT i.id AS interview_id, c.id AS company_id, c.name AS company_name,
+			` + interviewerNames + `
+		FROM interviews i
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE i.id = $1 AND i.user_id = $2 AND i.deleted_at IS NULL AND a.deleted_at IS NULL
+	`
+
+	audience := &models.StoryAudience{}
+	err := r.db.Get(audience, query, interviewID, userID)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return audience, nil
+}
+
+func (r *StoryRepository) Update(id, userID uuid.UUID, updates map[string]any) (*models.StoryWithStats, error) {
+	if len(updates) == 0 {
+		return r.GetByID(id, userID)
+	}
+
+	setParts := []string{}
+	args := []any{}
+	argIndex := 1
+
+	for field, value := range updates {
+		setParts = append(setParts, fmt.Sprintf("%s = $%d", field, argIndex))
+		args = append(args, value)
+		argIndex++
+	}
+
+	setParts = append(setParts, fmt.Sprintf("updated_at = $%d", argIndex))
+	args = append(args, time.Now())
+	argIndex++
+
+	args = append(args, id, userID)
+
+	query := fmt.Sprintf(`
+		UPDATE stories
+		SET %s
+		WHERE id = $%d AND user_id = $%d AND deleted_at IS NULL
+	`, strings.Join(setParts, ", "), argIndex, argIndex+1)
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, errors.New(errors.ErrorNotFound, "story not found")
+	}
+
+	return r.GetByID(id, userID)
+}
+
+// SoftDelete removes a story and unlinks the interview questions answered
+// with it; the questions themselves are kept.
+func (r *StoryRepository) SoftDelete(id, userID uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now()
+
+	result, err := tx.Exec(`
+		UPDATE stories
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+	`, now, id, userID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "story not found")
+	}
+
+	_, err = tx.Exec(`
+		UPDATE interview_questions
+		SET story_id = NULL, updated_at = $1
+		WHERE story_id = $2
+	`, now, id)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.ConvertError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestStoryRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	jobRepo := NewJobRepository(db.Database)
+	applicationRepo := NewApplicationRepository(db.Database)
+	interviewRepo := NewInterviewRepository(db.Database)
+	interviewerRepo := NewInterviewerRepository(db.Database)
+	questionRepo := NewInterviewQuestionRepository(db.Database)
+	storyRepo := NewStoryRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	testUser, err := userRepo.CreateUser("stories@example.com", "Story User", string(hashedPassword))
+	require.NoError(t, err)
+	otherUser, err := userRepo.CreateUser("stories2@example.com", "Other Story User", string(hashedPassword))
+	require.NoError(t, err)
+
+	company, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Story Co", "story.example"))
+	require.NoError(t, err)
+	job, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(company.ID, "Engineering Manager", "People"))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	err = db.Get(&statusID, "SELECT id FROM application_status LIMIT 1")
+	require.NoError(t, err)
+
+	app, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, job.ID, statusID))
+	require.NoError(t, err)
+	interview, err := interviewRepo.CreateInterview(testutil.CreateTestInterview(testUser.ID, app.ID, time.Now().AddDate(0, 0, -3), models.InterviewTypeBehavioral))
+	require.NoError(t, err)
+	_, err = interviewerRepo.CreateInterviewer(&models.Interviewer{InterviewID: interview.ID, Name: "Sam Lee"})
+	require.NoError(t, err)
+
+	situation := "Checkout was down on Black Friday"
+	story, err := storyRepo.Create(&models.Story{
+		UserID:       testUser.ID,
+		Title:        "Payments outage",
+		Situation:    &situation,
+		Competencies: pq.StringArray{"ownership", "crisis-management"},
+	})
+	require.NoError(t, err)
+
+	untold, err := storyRepo.Create(&models.Story{UserID: testUser.ID, Title: "Mentoring a junior", Competencies: pq.StringArray{"leadership"}})
+	require.NoError(t, err)
+
+	question, err := questionRepo.CreateInterviewQuestion(&models.InterviewQuestion{
+		InterviewID:  interview.ID,
+		QuestionText: "Tell me about a time things went wrong",
+		StoryID:      &story.ID,
+	})
+	require.NoError(t, err)
+
+	t.Run("GetByID_IncludesStats", func(t *testing.T) {
+		found, err := storyRepo.GetByID(story.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.TimesTold)
+		assert.Equal(t, 1, found.CompanyCount)
+		require.NotNil(t, found.Situation)
+
+		_, err = storyRepo.GetByID(story.ID, otherUser.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("List_LeastRecentlyToldFirst", func(t *testing.T) {
+		stories, err := storyRepo.List(testUser.ID, "")
+		require.NoError(t, err)
+		require.Len(t, stories, 2)
+		assert.Equal(t, untold.ID, stories[0].ID)
+
+		stories, err = storyRepo.List(testUser.ID, "leadership")
+		require.NoError(t, err)
+		require.Len(t, stories, 1)
+		assert.Equal(t, untold.ID, stories[0].ID)
+	})
+
+	t.Run("GetUsages", func(t *testing.T) {
+		usages, err := storyRepo.GetUsages(testUser.ID, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, usages, 1)
+		assert.Equal(t, question.ID, usages[0].InterviewQuestionID)
+		assert.Equal(t, "Story Co", usages[0].CompanyName)
+		assert.Equal(t, []string{"Sam Lee"}, []string(usages[0].Interviewers))
+
+		otherCompany := uuid.New()
+		usages, err = storyRepo.GetUsages(testUser.ID, nil, &otherCompany)
+		require.NoError(t, err)
+		assert.Empty(t, usages)
+	})
+
+	t.Run("GetAudience", func(t *testing.T) {
+		audience, err := storyRepo.GetAudience(interview.ID, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, company.ID, audience.CompanyID)
+		assert.Equal(t, []string{"Sam Lee"}, []string(audience.Interviewers))
+
+		_, err = storyRepo.GetAudience(interview.ID, otherUser.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Update_ClearsSection", func(t *testing.T) {
+		var cleared *string
+		updated, err := storyRepo.Update(story.ID, testUser.ID, map[string]any{"situation": cleared})
+		require.NoError(t, err)
+		assert.Nil(t, updated.Situation)
+	})
+
+	t.Run("SoftDelete_UnlinksQuestions", func(t *testing.T) {
+		require.NoError(t, storyRepo.SoftDelete(story.ID, testUser.ID))
+
+		found, err := questionRepo.GetInterviewQuestionByID(question.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found.StoryID)
+
+		err = storyRepo.SoftDelete(story.ID, testUser.ID)
+		assert.Error(t, err)
+	})
+}
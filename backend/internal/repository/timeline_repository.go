@@ -113,7 +113,7 @@ func (r *TimelineRepository) GetTimelineItems(userID uuid.UUID, filters Timeline
 			ass.title,
 			c.name as company_name,
 			j.title as job_title,
-			ass.due_date::timestamp as due_date,
+			ass.due_date,
 			ass.application_id
 		FROM assessments ass
 		JOIN applications a ON ass.application_id = a.id
@@ -208,8 +208,11 @@ func (r *TimelineRepository) GetTimelineItems(userID uuid.UUID, filters Timeline
 	items := make([]TimelineItem, len(rows))
 
 	for i, row := range rows {
-		countdown := calculateCountdown(row.DueDate, today)
+		countdown := itemCountdown(row.ItemType, row.DueDate, now, today)
 		dateGroup := calculateDateGroup(row.DueDate, today)
+		if row.ItemType == "assessment" {
+			dateGroup = calculateDeadlineDateGroup(row.DueDate, now, today)
+		}
 		link := buildItemLink(row.ItemType, row.ID, row.ApplicationID)
 
 		items[i] = TimelineItem{
@@ -258,6 +261,15 @@ func buildRangeCondition(rangeFilter string, today time.Time, columnName string)
 	}
 }
 
+// calculateDeadlineDateGroup groups an assessment deadline by the day it falls
+// on locally, except that one which has already passed is overdue.
+func calculateDeadlineDateGroup(dueDate time.Time, now time.Time, today time.Time) string {
+	if dueDate.Before(now) {
+		return DateGroupOverdue
+	}
+	return calculateDateGroup(dueDate.In(today.Location()), today)
+}
+
 func calculateDateGroup(dueDate time.Time, today time.Time) string {
 	dueDay := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
 	daysUntil := int(dueDay.Sub(today).Hours() / 24)
@@ -201,6 +201,11 @@ func (r *UserRepository) SoftDeleteUser(userID uuid.UUID) error {
 		return errors.NewDatabaseError("failed to delete notification preferences", err)
 	}
 
+	_, err = tx.Exec("DELETE FROM assessment_time_sessions WHERE user_id = $1", userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete assessment time sessions", err)
+	}
+
 	_, err = tx.Exec(`
 		UPDATE assessment_submissions SET deleted_at = $1
 		WHERE assessment_id IN (SELECT id FROM assessments WHERE user_id = $2)
@@ -222,6 +227,47 @@ func (r *UserRepository) SoftDeleteUser(userID uuid.UUID) error {
 		return errors.NewDatabaseError("failed to delete interview questions", err)
 	}
 
+	_, err = tx.Exec("DELETE FROM practice_cards WHERE user_id = $1", userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete practice cards", err)
+	}
+
+	_, err = tx.Exec("UPDATE stories SET deleted_at = $1 WHERE user_id = $2 AND deleted_at IS NULL", now, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete stories", err)
+	}
+
+	_, err = tx.Exec("UPDATE question_bank_entries SET deleted_at = $1 WHERE user_id = $2 AND deleted_at IS NULL", now, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete question bank entries", err)
+	}
+
+	_, err = tx.Exec("DELETE FROM interview_events WHERE user_id = $1", userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete interview events", err)
+	}
+
+	_, err = tx.Exec("DELETE FROM calendar_sync_items WHERE user_id = $1", userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete calendar sync items", err)
+	}
+
+	_, err = tx.Exec("DELETE FROM calendar_sync_accounts WHERE user_id = $1", userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete calendar sync account", err)
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM interview_note_revisions
+		WHERE note_id IN (
+			SELECT n.id FROM interview_notes n
+			JOIN interviews i ON n.interview_id = i.id
+			WHERE i.user_id = $1
+		)`, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to delete interview note revisions", err)
+	}
+
 	_, err = tx.Exec(`
 		UPDATE interview_notes SET deleted_at = $1
 		WHERE interview_id IN (SELECT id FROM interviews WHERE user_id = $2)
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/database"
+	"ditto-backend/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type UserCompanyProfileRepository struct {
+	db *sqlx.DB
+}
+
+func NewUserCompanyProfileRepository(database *database.Database) *UserCompanyProfileRepository {
+	return &UserCompanyProfileRepository{
+		db: database.DB,
+	}
+}
+
+const userCompanyProfileColumns = `
+	user_id, company_id, notes, is_target, priority, culture_rating, tech_stack,
+	headcount, funding_stage, do_not_apply, created_at, updated_at
+`
+
+// GetByCompanyID returns the user's profile for a company, or an empty default
+// profile if they haven't saved one.
+func (r *UserCompanyProfileRepository) GetByCompanyID(userID, companyID uuid.UUID) (*models.UserCompanyProfile, error) {
+	query := `SELECT ` + userCompanyProfileColumns + `
+		FROM user_company_profiles
+		WHERE user_id = $1 AND company_id = $2
+	`
+
+	var profile models.UserCompanyProfile
+	err := r.db.Get(&profile, query, userID, companyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultUserCompanyProfile(userID, companyID), nil
+		}
+		return nil, errors.ConvertError(err)
+	}
+
+	return &profile, nil
+}
+
+// GetByCompanyIDs returns the user's saved profiles for the given companies,
+// keyed by company ID. Companies without a profile are absent from the map.
+func (r *UserCompanyProfileRepository) GetByCompanyIDs(userID uuid.UUID, companyIDs []uuid.UUID) (map[uuid.UUID]*models.UserCompanyProfile, error) {
+	profiles := make(map[uuid.UUID]*models.UserCompanyProfile, len(companyIDs))
+	if len(companyIDs) == 0 {
+		return profiles, nil
+	}
+
+	query := `SELECT ` + userCompanyProfileColumns + `
+		FROM user_company_profiles
+		WHERE user_id = $1 AND company_id = ANY($2)
+	`
+
+	var rows []*models.UserCompanyProfile
+	err := r.db.Select(&rows, query, userID, pq.Array(companyIDs))
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	for _, profile := range rows {
+		profiles[profile.CompanyID] = profile
+	}
+
+	return profiles, nil
+}
+
+func (r *UserCompanyProfileRepository) Upsert(profile *models.UserCompanyProfile) (*models.UserCompanyProfile, error) {
+	if profile.TechStack == nil {
+		profile.TechStack = pq.StringArray{}
+	}
+
+	query := `
+		INSERT INTO user_company_profiles (
+			user_id, company_id, notes, is_target, priority, culture_rating,
+			tech_stack, headcount, funding_stage, do_not_apply
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (user_id, company_id) DO UPDATE SET
+			notes = EXCLUDED.notes,
+			is_target = EXCLUDED.is_target,
+			priority = EXCLUDED.priority,
+			culture_rating = EXCLUDED.culture_rating,
+			tech_stack = EXCLUDED.tech_stack,
+			headcount = EXCLUDED.headcount,
+			funding_stage = EXCLUDED.funding_stage,
+			do_not_apply = EXCLUDED.do_not_apply
+		RETURNING ` + userCompanyProfileColumns
+
+	var result models.UserCompanyProfile
+	err := r.db.Get(&result, query,
+		profile.UserID,
+		profile.CompanyID,
+		profile.Notes,
+		profile.IsTarget,
+		profile.Priority,
+		profile.CultureRating,
+		profile.TechStack,
+		profile.Headcount,
+		profile.FundingStage,
+		profile.DoNotApply,
+	)
+	if err != nil {
+		return nil, errors.ConvertError(err)
+	}
+
+	return &result, nil
+}
+
+func (r *UserCompanyProfileRepository) Delete(userID, companyID uuid.UUID) error {
+	query := `
+		DELETE FROM user_company_profiles
+		WHERE user_id = $1 AND company_id = $2
+	`
+
+	result, err := r.db.Exec(query, userID, companyID)
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.ConvertError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrorNotFound, "company profile not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/testutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUserCompanyProfileRepository(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	userRepo := NewUserRepository(db.Database)
+	companyRepo := NewCompanyRepository(db.Database)
+	profileRepo := NewUserCompanyProfileRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	user, err := userRepo.CreateUser("profiles@example.com", "Profile User", string(hashedPassword))
+	require.NoError(t, err)
+	otherUser, err := userRepo.CreateUser("profiles2@example.com", "Other Profile User", string(hashedPassword))
+	require.NoError(t, err)
+
+	company, err := companyRepo.CreateCompany(&models.Company{Name: "Profiled Inc"})
+	require.NoError(t, err)
+
+	t.Run("GetByCompanyID_Default", func(t *testing.T) {
+		profile, err := profileRepo.GetByCompanyID(user.ID, company.ID)
+		require.NoError(t, err)
+		assert.Equal(t, company.ID, profile.CompanyID)
+		assert.False(t, profile.IsTarget)
+		assert.Empty(t, profile.TechStack)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		notes := "Strong eng blog"
+		rating := 4
+		stage := models.FundingStageSeriesB
+
+		saved, err := profileRepo.Upsert(&models.UserCompanyProfile{
+			UserID:        user.ID,
+			CompanyID:     company.ID,
+			Notes:         &notes,
+			IsTarget:      true,
+			CultureRating: &rating,
+			TechStack:     pq.StringArray{"Go", "Postgres"},
+			FundingStage:  &stage,
+		})
+		require.NoError(t, err)
+		assert.True(t, saved.IsTarget)
+		assert.Equal(t, []string{"Go", "Postgres"}, []string(saved.TechStack))
+
+		saved, err = profileRepo.Upsert(&models.UserCompanyProfile{
+			UserID:     user.ID,
+			CompanyID:  company.ID,
+			DoNotApply: true,
+		})
+		require.NoError(t, err)
+		assert.False(t, saved.IsTarget)
+		assert.True(t, saved.DoNotApply)
+		assert.Nil(t, saved.Notes)
+
+		t.Run("RejectsInvalidRating", func(t *testing.T) {
+			bad := 9
+			_, err := profileRepo.Upsert(&models.UserCompanyProfile{UserID: user.ID, CompanyID: company.ID, CultureRating: &bad})
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("ProfilesArePrivate", func(t *testing.T) {
+		profile, err := profileRepo.GetByCompanyID(otherUser.ID, company.ID)
+		require.NoError(t, err)
+		assert.False(t, profile.DoNotApply)
+
+		profiles, err := profileRepo.GetByCompanyIDs(otherUser.ID, []uuid.UUID{company.ID})
+		require.NoError(t, err)
+		assert.Empty(t, profiles)
+
+		profiles, err = profileRepo.GetByCompanyIDs(user.ID, []uuid.UUID{company.ID})
+		require.NoError(t, err)
+		assert.Contains(t, profiles, company.ID)
+	})
+
+	t.Run("AutocompleteRanking", func(t *testing.T) {
+		avoid, err := companyRepo.CreateCompany(&models.Company{Name: "Rankco A"})
+		require.NoError(t, err)
+		_, err = companyRepo.CreateCompany(&models.Company{Name: "Rankco B"})
+		require.NoError(t, err)
+		target, err := companyRepo.CreateCompany(&models.Company{Name: "Rankco C"})
+		require.NoError(t, err)
+
+		_, err = profileRepo.Upsert(&models.UserCompanyProfile{UserID: user.ID, CompanyID: avoid.ID, DoNotApply: true})
+		require.NoError(t, err)
+		_, err = profileRepo.Upsert(&models.UserCompanyProfile{UserID: user.ID, CompanyID: target.ID, IsTarget: true})
+		require.NoError(t, err)
+
+		suggestions, err := companyRepo.AutocompleteCompaniesForUser("Rankco", 10, user.ID)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 3)
+		assert.Equal(t, "Rankco C", suggestions[0].Name)
+		assert.True(t, suggestions[0].IsTarget)
+		assert.Equal(t, "Rankco B", suggestions[1].Name)
+		assert.Equal(t, "Rankco A", suggestions[2].Name)
+		assert.True(t, suggestions[2].DoNotApply)
+
+		anonymous, err := companyRepo.AutocompleteCompanies("Rankco", 10)
+		require.NoError(t, err)
+		require.Len(t, anonymous, 3)
+		assert.Equal(t, "Rankco A", anonymous[0].Name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, profileRepo.Delete(user.ID, company.ID))
+		assert.Error(t, profileRepo.Delete(user.ID, company.ID))
+	})
+}
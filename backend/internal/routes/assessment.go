@@ -3,13 +3,28 @@ package routes
 import (
 	"ditto-backend/internal/handlers"
 	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/github"
 	"ditto-backend/internal/utils"
+	"log"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAssessmentRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
-	assessmentHandler := handlers.NewAssessmentHandler(appState)
+// RegisterAssessmentRoutes registers assessment and submission routes.
+// fileScanner may be nil when uploads aren't scanned.
+func RegisterAssessmentRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState, fileScanner *services.FileScanner) {
+	s3Service, err := newFileStorage()
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage for assessments: %v", err)
+	}
+
+	// Archives can take a while to download, so the timeout is generous
+	githubClient := github.NewAPIClient(&http.Client{Timeout: 60 * time.Second}, getEnv("GITHUB_TOKEN", ""))
+
+	assessmentHandler := handlers.NewAssessmentHandler(appState, githubClient, s3Service, fileScanner)
 
 	assessments := apiGroup.Group("/assessments")
 	assessments.Use(middleware.AuthMiddleware())
@@ -23,12 +38,18 @@ func RegisterAssessmentRoutes(apiGroup *gin.RouterGroup, appState *utils.AppStat
 		assessments.PATCH("/:id/status", assessmentHandler.UpdateStatus)
 		assessments.DELETE("/:id", assessmentHandler.DeleteAssessment)
 		assessments.POST("/:id/submissions", assessmentHandler.CreateSubmission)
+		assessments.GET("/:id/time-sessions", assessmentHandler.ListTimeSessions)
+		assessments.POST("/:id/time-sessions", assessmentHandler.CreateTimeSession)
+		assessments.POST("/:id/time-sessions/start", assessmentHandler.StartTimeSession)
+		assessments.POST("/:id/time-sessions/stop", assessmentHandler.StopTimeSession)
+		assessments.DELETE("/:id/time-sessions/:sessionId", assessmentHandler.DeleteTimeSession)
 	}
 
 	submissions := apiGroup.Group("/assessment-submissions")
 	submissions.Use(middleware.AuthMiddleware())
 	submissions.Use(middleware.CSRFMiddleware())
 	{
+		submissions.GET("/:submissionId/github-status", assessmentHandler.GetSubmissionGithubStatus)
 		submissions.DELETE("/:submissionId", assessmentHandler.DeleteSubmission)
 	}
 }
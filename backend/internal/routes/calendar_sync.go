@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/services/caldav"
+	"ditto-backend/internal/utils"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterCalendarSyncRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	// Without a key calendar passwords can't be stored, so sync stays off
+	sealer, err := caldav.NewSealer(getEnv("CALDAV_ENCRYPTION_KEY", ""))
+	if err != nil {
+		log.Printf("Calendar sync disabled: %v", err)
+	}
+
+	calendarSyncHandler := handlers.NewCalendarSyncHandler(appState, sealer)
+
+	calendarSync := apiGroup.Group("/calendar-sync")
+	calendarSync.Use(middleware.AuthMiddleware())
+	calendarSync.Use(middleware.CSRFMiddleware())
+	{
+		calendarSync.GET("", calendarSyncHandler.GetCalendarSync)
+		calendarSync.PUT("", calendarSyncHandler.UpdateCalendarSync)
+		calendarSync.DELETE("", calendarSyncHandler.DeleteCalendarSync)
+		calendarSync.POST("/run", calendarSyncHandler.RunCalendarSync)
+		calendarSync.POST("/items/:id/resolve", calendarSyncHandler.ResolveCalendarSyncItem)
+	}
+}
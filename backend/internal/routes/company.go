@@ -3,6 +3,7 @@ package routes
 import (
 	"ditto-backend/internal/handlers"
 	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/models"
 	"ditto-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -10,19 +11,27 @@ import (
 
 func RegisterCompanyRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
 	companyHandler := handlers.NewCompanyHandler(appState)
+	// Companies are shared by every user, so only admins can merge them
+	requireAdmin := middleware.RequireRole(appState.DB, models.RoleAdmin)
 
 	companies := apiGroup.Group("/companies")
 	{
 		companies.GET("", companyHandler.GetCompanies)
-		companies.GET("/autocomplete", companyHandler.AutocompleteCompanies)
-		companies.GET("/search", companyHandler.SearchCompanies)
+		companies.GET("/autocomplete", middleware.OptionalAuthMiddleware(), companyHandler.AutocompleteCompanies)
+		companies.GET("/search", middleware.OptionalAuthMiddleware(), companyHandler.SearchCompanies)
+		companies.GET("/duplicates", middleware.AuthMiddleware(), requireAdmin, companyHandler.GetDuplicateCompanies)
 		companies.GET("/:id", companyHandler.GetCompany)
+		companies.GET("/:id/overview", middleware.AuthMiddleware(), companyHandler.GetCompanyOverview)
+		companies.GET("/:id/profile", middleware.AuthMiddleware(), companyHandler.GetCompanyProfile)
 
 		companies.POST("/select", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.SelectOrCreateCompany)
 		companies.POST("", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.CreateCompany)
+		companies.POST("/:id/merge", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), requireAdmin, companyHandler.MergeCompanies)
 
 		companies.PUT("/:id", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.UpdateCompany)
+		companies.PUT("/:id/profile", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.UpdateCompanyProfile)
 
 		companies.DELETE("/:id", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.DeleteCompany)
+		companies.DELETE("/:id/profile", middleware.AuthMiddleware(), middleware.CSRFMiddleware(), companyHandler.DeleteCompanyProfile)
 	}
 }
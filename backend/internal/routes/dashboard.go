@@ -17,5 +17,6 @@ func RegisterDashboardRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState
 	{
 		dashboard.GET("/stats", dashboardHandler.GetStats)
 		dashboard.GET("/upcoming", dashboardHandler.GetUpcomingItems)
+		dashboard.GET("/assessment-time", dashboardHandler.GetAssessmentTimeStats)
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterExchangeRateRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	exchangeRateHandler := handlers.NewExchangeRateHandler(appState)
+
+	exchangeRates := apiGroup.Group("/exchange-rates")
+	exchangeRates.Use(middleware.AuthMiddleware())
+	exchangeRates.Use(middleware.CSRFMiddleware())
+	{
+		exchangeRates.GET("", exchangeRateHandler.ListExchangeRates)
+	}
+
+	users := apiGroup.Group("/users")
+	users.Use(middleware.AuthMiddleware())
+	users.Use(middleware.CSRFMiddleware())
+	{
+		users.GET("/currency-preference", exchangeRateHandler.GetCurrencyPreference)
+		users.PUT("/currency-preference", exchangeRateHandler.UpdateCurrencyPreference)
+	}
+}
@@ -4,35 +4,27 @@ import (
 	"ditto-backend/internal/handlers"
 	"ditto-backend/internal/middleware"
 	"ditto-backend/internal/repository"
-	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/internal/services"
 	"ditto-backend/internal/utils"
 	"log"
 	"os"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterFileRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+// RegisterFileRoutes registers upload and file management routes.
+// fileScanner may be nil when uploads aren't scanned.
+func RegisterFileRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState, fileScanner *services.FileScanner) {
 	fileRepo := repository.NewFileRepository(appState.DB)
 
-	s3Config := s3service.Config{
-		Region:          getEnv("AWS_REGION", "us-east-1"),
-		Bucket:          getEnv("AWS_S3_BUCKET", ""),
-		AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
-		SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
-		Endpoint:        getEnv("AWS_ENDPOINT", ""),
-		URLExpiry:       15 * time.Minute,
-	}
-
-	s3Service, err := s3service.NewS3Service(s3Config)
+	s3Service, err := newFileStorage()
 	if err != nil {
-		log.Fatalf("Failed to initialize S3 service: %v", err)
+		log.Fatalf("Failed to initialize file storage: %v", err)
 	}
 
 	rateLimiter := middleware.NewRateLimiter(appState.DB)
 
-	fileHandler := handlers.NewFileHandler(fileRepo, s3Service)
+	fileHandler := handlers.NewFileHandler(fileRepo, s3Service, fileScanner)
 
 	files := apiGroup.Group("/files")
 	files.Use(middleware.AuthMiddleware())
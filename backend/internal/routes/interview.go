@@ -4,12 +4,24 @@ import (
 	"ditto-backend/internal/handlers"
 	"ditto-backend/internal/middleware"
 	"ditto-backend/internal/utils"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// defaultConflictBufferMinutes is the gap interviews need between them before
+// they stop being reported as conflicts, unless INTERVIEW_CONFLICT_BUFFER_MINUTES
+// says otherwise.
+const defaultConflictBufferMinutes = 15
+
 func RegisterInterviewRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
-	interviewHandler := handlers.NewInterviewHandler(appState)
+	bufferMinutes, err := strconv.Atoi(getEnv("INTERVIEW_CONFLICT_BUFFER_MINUTES", strconv.Itoa(defaultConflictBufferMinutes)))
+	if err != nil || bufferMinutes < 0 {
+		bufferMinutes = defaultConflictBufferMinutes
+	}
+
+	interviewHandler := handlers.NewInterviewHandler(appState, time.Duration(bufferMinutes)*time.Minute)
 
 	interviews := apiGroup.Group("/interviews")
 	interviews.Use(middleware.AuthMiddleware())
@@ -17,9 +29,12 @@ func RegisterInterviewRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState
 	{
 		interviews.POST("", interviewHandler.CreateInterview)
 		interviews.GET("", interviewHandler.ListInterviews)
+		interviews.GET("/conflicts", interviewHandler.GetConflicts)
+		interviews.POST("/import-ics", interviewHandler.ImportICS)
 		interviews.GET("/:id", interviewHandler.GetInterviewByID)
 		interviews.GET("/:id/details", interviewHandler.GetInterviewWithDetails)
 		interviews.GET("/:id/with-context", interviewHandler.GetInterviewWithContext)
+		interviews.GET("/:id/prep-packet", interviewHandler.GetPrepPacket)
 		interviews.PUT("/:id", interviewHandler.UpdateInterview)
 		interviews.DELETE("/:id", interviewHandler.DeleteInterview)
 	}
@@ -4,17 +4,31 @@ import (
 	"ditto-backend/internal/handlers"
 	"ditto-backend/internal/middleware"
 	"ditto-backend/internal/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+// defaultNoteRevisions is how many revisions are kept per interview note
+// unless INTERVIEW_NOTE_MAX_REVISIONS says otherwise (0 keeps them all).
+const defaultNoteRevisions = 50
+
 func RegisterInterviewNoteRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
-	noteHandler := handlers.NewInterviewNoteHandler(appState)
+	maxRevisions, err := strconv.Atoi(getEnv("INTERVIEW_NOTE_MAX_REVISIONS", strconv.Itoa(defaultNoteRevisions)))
+	if err != nil {
+		maxRevisions = defaultNoteRevisions
+	}
+
+	noteHandler := handlers.NewInterviewNoteHandler(appState, maxRevisions)
 
 	interviews := apiGroup.Group("/interviews")
 	interviews.Use(middleware.AuthMiddleware())
 	interviews.Use(middleware.CSRFMiddleware())
 	{
 		interviews.POST("/:id/notes", noteHandler.CreateOrUpdateNote)
+		interviews.GET("/:id/notes/:type/revisions", noteHandler.ListRevisions)
+		interviews.GET("/:id/notes/:type/revisions/:revision", noteHandler.GetRevision)
+		interviews.POST("/:id/notes/:type/revisions/:revision/restore", noteHandler.RestoreRevision)
+		interviews.GET("/:id/notes/:type/diff", noteHandler.DiffRevisions)
 	}
 }
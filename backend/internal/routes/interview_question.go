@@ -26,6 +26,8 @@ func RegisterInterviewQuestionRoutes(apiGroup *gin.RouterGroup, appState *utils.
 	questions.Use(middleware.CSRFMiddleware())
 	{
 		questions.PUT("/:id", questionHandler.UpdateQuestion)
+		questions.PUT("/:id/bank-entry", questionHandler.LinkBankEntry)
+		questions.PUT("/:id/story", questionHandler.LinkStory)
 		questions.DELETE("/:id", questionHandler.DeleteQuestion)
 	}
 }
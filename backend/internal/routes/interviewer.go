@@ -25,6 +25,7 @@ func RegisterInterviewerRoutes(apiGroup *gin.RouterGroup, appState *utils.AppSta
 	interviewers.Use(middleware.CSRFMiddleware())
 	{
 		interviewers.PUT("/:id", interviewerHandler.UpdateInterviewer)
+		interviewers.PUT("/:id/thank-you", interviewerHandler.UpdateThankYou)
 		interviewers.DELETE("/:id", interviewerHandler.DeleteInterviewer)
 	}
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/utils"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterNoteRenderRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	s3Service, err := newFileStorage()
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage for notes: %v", err)
+	}
+
+	renderHandler := handlers.NewNoteRenderHandler(appState, s3Service)
+
+	notes := apiGroup.Group("/notes")
+	notes.Use(middleware.AuthMiddleware())
+	notes.Use(middleware.CSRFMiddleware())
+	{
+		notes.POST("/render", renderHandler.RenderNote)
+	}
+}
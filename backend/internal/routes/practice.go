@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPracticeRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	practiceHandler := handlers.NewPracticeHandler(appState)
+
+	practice := apiGroup.Group("/practice")
+	practice.Use(middleware.AuthMiddleware())
+	practice.Use(middleware.CSRFMiddleware())
+	{
+		practice.GET("/due", practiceHandler.GetDue)
+		practice.GET("/:id", practiceHandler.GetCard)
+		practice.POST("/:id/review", practiceHandler.ReviewCard)
+	}
+}
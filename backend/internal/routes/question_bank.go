@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterQuestionBankRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	bankHandler := handlers.NewQuestionBankHandler(appState)
+
+	bank := apiGroup.Group("/question-bank")
+	bank.Use(middleware.AuthMiddleware())
+	bank.Use(middleware.CSRFMiddleware())
+	{
+		bank.GET("", bankHandler.ListEntries)
+		bank.GET("/suggest", bankHandler.SuggestEntries)
+		bank.POST("", bankHandler.CreateEntry)
+		bank.GET("/:id", bankHandler.GetEntry)
+		bank.PUT("/:id", bankHandler.UpdateEntry)
+		bank.DELETE("/:id", bankHandler.DeleteEntry)
+	}
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/services"
+	"ditto-backend/internal/services/clamav"
+	"ditto-backend/internal/services/localstore"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/internal/utils"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StorageBackendS3    = "s3"
+	StorageBackendLocal = "local"
+)
+
+// newFileStorage builds the storage backend chosen by STORAGE_BACKEND. The
+// local backend is stateless apart from its directory, so every route group
+// can build its own.
+func newFileStorage() (s3service.S3ServiceInterface, error) {
+	if getEnv("STORAGE_BACKEND", StorageBackendS3) == StorageBackendLocal {
+		return newLocalStorage()
+	}
+
+	return s3service.NewS3Service(s3service.Config{
+		Region:          getEnv("AWS_REGION", "us-east-1"),
+		Bucket:          getEnv("AWS_S3_BUCKET", ""),
+		AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+		SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		Endpoint:        getEnv("AWS_ENDPOINT", ""),
+		URLExpiry:       handlers.PresignedURLExpiry,
+	})
+}
+
+func newLocalStorage() (*localstore.Service, error) {
+	return localstore.NewService(localstore.Config{
+		Root:      getEnv("LOCAL_STORAGE_PATH", "./storage"),
+		Secret:    getEnv("LOCAL_STORAGE_SECRET", ""),
+		BaseURL:   getEnv("LOCAL_STORAGE_BASE_URL", "http://localhost:"+getEnv("PORT", "8081")),
+		URLExpiry: handlers.PresignedURLExpiry,
+	})
+}
+
+// NewFileScanner builds the malware scanner for uploads from CLAMAV_ADDRESS.
+// It returns nil when that is unset, and uploads are then not scanned.
+func NewFileScanner(appState *utils.AppState) (*services.FileScanner, error) {
+	address := getEnv("CLAMAV_ADDRESS", "")
+	if address == "" {
+		return nil, nil
+	}
+
+	storage, err := newFileStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewFileScanner(appState.DB, storage, clamav.NewClient(address, time.Minute)), nil
+}
+
+// RegisterStorageRoutes serves signed uploads and downloads when files are
+// kept on the local filesystem. With S3 the browser talks to the bucket.
+func RegisterStorageRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	if getEnv("STORAGE_BACKEND", StorageBackendS3) != StorageBackendLocal {
+		return
+	}
+
+	store, err := newLocalStorage()
+	if err != nil {
+		log.Fatalf("Failed to initialize local storage: %v", err)
+	}
+
+	storageHandler := handlers.NewStorageHandler(store)
+
+	storage := apiGroup.Group("/storage")
+	{
+		storage.GET("/*key", storageHandler.Download)
+		storage.PUT("/*key", storageHandler.Upload)
+	}
+}
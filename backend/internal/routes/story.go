@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"ditto-backend/internal/handlers"
+	"ditto-backend/internal/middleware"
+	"ditto-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStoryRoutes(apiGroup *gin.RouterGroup, appState *utils.AppState) {
+	storyHandler := handlers.NewStoryHandler(appState)
+
+	stories := apiGroup.Group("/stories")
+	stories.Use(middleware.AuthMiddleware())
+	stories.Use(middleware.CSRFMiddleware())
+	{
+		stories.GET("", storyHandler.ListStories)
+		stories.GET("/report", storyHandler.GetStoryReport)
+		stories.POST("", storyHandler.CreateStory)
+		stories.GET("/:id", storyHandler.GetStory)
+		stories.PUT("/:id", storyHandler.UpdateStory)
+		stories.DELETE("/:id", storyHandler.DeleteStory)
+	}
+}
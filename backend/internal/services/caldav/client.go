@@ -0,0 +1,262 @@
+// Package caldav keeps a CalDAV calendar collection (RFC 4791) in step with
+// Ditto's interviews and assessment deadlines. It talks plain WebDAV: list
+// the collection's ETags with PROPFIND, then GET, PUT and DELETE individual
+// event resources with If-Match / If-None-Match so that neither side silently
+// overwrites an edit made on the other.
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnauthorized          = errors.New("calendar server rejected the credentials")
+	ErrNotFound              = errors.New("calendar resource not found")
+	ErrPreconditionFailed    = errors.New("calendar resource changed on the server")
+	ErrNotCalendarCollection = errors.New("URL is not a calendar collection")
+)
+
+// maxResponseSize caps how much of any one response is read.
+const maxResponseSize = 5 << 20
+
+// Client reads and writes event resources in one calendar collection.
+type Client struct {
+	collection *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the collection at collectionURL, e.g.
+// http://localhost:5232/alice/interviews/ on Radicale.
+func NewClient(collectionURL, username, password string) (*Client, error) {
+	u, err := url.Parse(strings.TrimSpace(collectionURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid collection URL %q", collectionURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return &Client{
+		collection: u,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Href is the path of the resource that holds the event with uid.
+func (c *Client) Href(uid string) string {
+	return c.collection.Path + uid + ".ics"
+}
+
+const propfindResourceType = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/></d:prop></d:propfind>`
+
+const propfindETags = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getetag/></d:prop></d:propfind>`
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Status string `xml:"status"`
+			Prop   struct {
+				ETag         string `xml:"getetag"`
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+					Calendar   *struct{} `xml:"calendar"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// Check confirms the collection exists, the credentials work and that it is
+// a calendar rather than a plain WebDAV folder.
+func (c *Client) Check(ctx context.Context) error {
+	ms, err := c.propfind(ctx, c.collection.Path, "0", propfindResourceType)
+	if err != nil {
+		return err
+	}
+	for _, resp := range ms.Responses {
+		for _, ps := range resp.Propstat {
+			if ps.Prop.ResourceType.Calendar != nil {
+				return nil
+			}
+		}
+	}
+	return ErrNotCalendarCollection
+}
+
+// List returns the ETag of every event resource in the collection, keyed by
+// href.
+func (c *Client) List(ctx context.Context) (map[string]string, error) {
+	ms, err := c.propfind(ctx, c.collection.Path, "1", propfindETags)
+	if err != nil {
+		return nil, err
+	}
+
+	etags := make(map[string]string, len(ms.Responses))
+	for _, resp := range ms.Responses {
+		href, err := c.normalizeHref(resp.Href)
+		if err != nil || href == c.collection.Path {
+			continue
+		}
+		for _, ps := range resp.Propstat {
+			if !strings.Contains(ps.Status, " 200 ") || ps.Prop.ResourceType.Collection != nil {
+				continue
+			}
+			etags[href] = ps.Prop.ETag
+		}
+	}
+	return etags, nil
+}
+
+// Get fetches a resource and its current ETag.
+func (c *Client) Get(ctx context.Context, href string) ([]byte, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, href, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil {
+		return nil, "", err
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// Put writes a resource. With an empty etag the resource must not exist yet;
+// otherwise it must still have that ETag. Either way a concurrent change on
+// the server fails with ErrPreconditionFailed. The new ETag is returned when
+// the server sends one.
+func (c *Client) Put(ctx context.Context, href string, data []byte, etag string) (string, error) {
+	headers := map[string]string{"Content-Type": "text/calendar; charset=utf-8"}
+	if etag == "" {
+		headers["If-None-Match"] = "*"
+	} else {
+		headers["If-Match"] = etag
+	}
+
+	resp, err := c.do(ctx, http.MethodPut, href, data, headers)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil {
+		return "", err
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Delete removes a resource if it still has etag. Resources that are already
+// gone are not an error.
+func (c *Client) Delete(ctx context.Context, href, etag string) error {
+	var headers map[string]string
+	if etag != "" {
+		headers = map[string]string{"If-Match": etag}
+	}
+
+	resp, err := c.do(ctx, http.MethodDelete, href, nil, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) propfind(ctx context.Context, href, depth, body string) (*multistatus, error) {
+	resp, err := c.do(ctx, "PROPFIND", href, []byte(body), map[string]string{
+		"Content-Type": "application/xml; charset=utf-8",
+		"Depth":        depth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, ErrNotCalendarCollection
+	}
+
+	ms := &multistatus{}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(ms); err != nil {
+		return nil, fmt.Errorf("reading PROPFIND response: %w", err)
+	}
+	return ms, nil
+}
+
+func (c *Client) do(ctx context.Context, method, href string, body []byte, headers map[string]string) (*http.Response, error) {
+	target := c.collection.ResolveReference(&url.URL{Path: href})
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, target.Redacted(), err)
+	}
+	return resp, nil
+}
+
+// normalizeHref turns an href from a multistatus response, which servers may
+// send as a full URL or percent-encoded, into the same form Href produces.
+func (c *Client) normalizeHref(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	resolved := c.collection.ResolveReference(u)
+	if resolved.Host != c.collection.Host {
+		return "", fmt.Errorf("href %q is on another host", raw)
+	}
+	return resolved.Path, nil
+}
+
+func statusError(resp *http.Response) error {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	default:
+		return fmt.Errorf("calendar server returned %s", resp.Status)
+	}
+}
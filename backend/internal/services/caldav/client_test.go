@@ -0,0 +1,155 @@
+package caldav
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ditto-backend/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItem() *Item {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	return &Item{
+		Type:   "interview",
+		ID:     uuid.MustParse("6f1c8d2e-3b7a-4c1d-9e5f-0a2b4c6d8e10"),
+		Title:  "Acme technical interview (round 2)",
+		Start:  start,
+		End:    start.Add(time.Hour),
+		AllDay: false,
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("check accepts a calendar collection", func(t *testing.T) {
+		server := testutil.NewCalDAVServer(t)
+		client, err := NewClient(server.CollectionURL(), server.Username, server.Password)
+		require.NoError(t, err)
+
+		assert.NoError(t, client.Check(ctx))
+	})
+
+	t.Run("check reports bad credentials", func(t *testing.T) {
+		server := testutil.NewCalDAVServer(t)
+		client, err := NewClient(server.CollectionURL(), server.Username, "wrong")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, client.Check(ctx), ErrUnauthorized)
+	})
+
+	t.Run("check reports a missing collection", func(t *testing.T) {
+		server := testutil.NewCalDAVServer(t)
+		client, err := NewClient(server.URL+"/calendars/other/", server.Username, server.Password)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, client.Check(ctx), ErrNotFound)
+	})
+
+	t.Run("rejects URLs that are not http", func(t *testing.T) {
+		_, err := NewClient("ftp://example.com/cal/", "", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("create, list, update and delete with ETags", func(t *testing.T) {
+		server := testutil.NewCalDAVServer(t)
+		client, err := NewClient(server.CollectionURL(), server.Username, server.Password)
+		require.NoError(t, err)
+
+		item := testItem()
+		href := client.Href(item.UID())
+		assert.Equal(t, "/calendars/test/ditto-interview-6f1c8d2e-3b7a-4c1d-9e5f-0a2b4c6d8e10.ics", href)
+
+		etag, err := client.Put(ctx, href, item.Render(time.Now()), "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, etag)
+
+		etags, err := client.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{href: etag}, etags)
+
+		// Creating again must not overwrite
+		_, err = client.Put(ctx, href, item.Render(time.Now()), "")
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+		newETag, err := client.Put(ctx, href, item.Render(time.Now()), etag)
+		require.NoError(t, err)
+		assert.NotEqual(t, etag, newETag)
+
+		// The old ETag is stale now
+		_, err = client.Put(ctx, href, item.Render(time.Now()), etag)
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		assert.ErrorIs(t, client.Delete(ctx, href, etag), ErrPreconditionFailed)
+
+		data, gotETag, err := client.Get(ctx, href)
+		require.NoError(t, err)
+		assert.Equal(t, newETag, gotETag)
+		assert.Contains(t, string(data), "UID:"+item.UID())
+
+		require.NoError(t, client.Delete(ctx, href, newETag))
+		_, _, err = client.Get(ctx, href)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// Deleting something already gone is fine
+		assert.NoError(t, client.Delete(ctx, href, ""))
+	})
+
+	t.Run("list sees edits made by other clients", func(t *testing.T) {
+		server := testutil.NewCalDAVServer(t)
+		client, err := NewClient(server.CollectionURL(), server.Username, server.Password)
+		require.NoError(t, err)
+
+		item := testItem()
+		href := client.Href(item.UID())
+		etag, err := client.Put(ctx, href, item.Render(time.Now()), "")
+		require.NoError(t, err)
+
+		server.SetResource(href, item.Render(time.Now()))
+
+		etags, err := client.List(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, etag, etags[href])
+	})
+}
+
+func TestSealer(t *testing.T) {
+	t.Run("requires a secret", func(t *testing.T) {
+		_, err := NewSealer("")
+		assert.ErrorIs(t, err, ErrNoEncryptionKey)
+	})
+
+	t.Run("round trips and never stores plaintext", func(t *testing.T) {
+		sealer, err := NewSealer("server-secret")
+		require.NoError(t, err)
+
+		sealed, err := sealer.Seal("hunter2")
+		require.NoError(t, err)
+		assert.NotContains(t, sealed, "hunter2")
+
+		again, err := sealer.Seal("hunter2")
+		require.NoError(t, err)
+		assert.NotEqual(t, sealed, again, "each seal uses a fresh nonce")
+
+		opened, err := sealer.Open(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", opened)
+	})
+
+	t.Run("a different secret can't open it", func(t *testing.T) {
+		sealer, err := NewSealer("server-secret")
+		require.NoError(t, err)
+		other, err := NewSealer("rotated-secret")
+		require.NoError(t, err)
+
+		sealed, err := sealer.Seal("hunter2")
+		require.NoError(t, err)
+
+		_, err = other.Open(sealed)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,143 @@
+package caldav
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/ics"
+
+	"github.com/google/uuid"
+)
+
+var ErrEventMissing = errors.New("calendar resource does not contain the synced event")
+
+// Item is an interview or assessment deadline as it should appear in the
+// calendar. Timed items start and end at instants; all-day items cover the
+// dates from Start up to, but not including, End.
+type Item struct {
+	Type        string
+	ID          uuid.UUID
+	Title       string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// UID identifies the item's event across syncs. It is also the resource name.
+func (i *Item) UID() string {
+	return "ditto-" + i.Type + "-" + i.ID.String()
+}
+
+// Hash summarises everything pushed to the calendar, so a later sync can tell
+// whether Ditto's copy has changed since.
+func (i *Item) Hash() string {
+	sum := sha256.New()
+	for _, field := range []string{
+		i.Title, i.Description, i.Location, i.URL,
+		i.Start.UTC().Format(time.RFC3339), i.End.UTC().Format(time.RFC3339),
+		fmt.Sprint(i.AllDay),
+	} {
+		sum.Write([]byte(field))
+		sum.Write([]byte{0})
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// Render writes the item as a single-event iCalendar object. Timed events
+// are written in UTC so that no VTIMEZONE is needed.
+func (i *Item) Render(stamp time.Time) []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Ditto//Interview Tracker//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("BEGIN", "VEVENT")
+	line("UID", i.UID())
+	line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+	if i.AllDay {
+		line("DTSTART;VALUE=DATE", i.Start.Format("20060102"))
+		line("DTEND;VALUE=DATE", i.End.Format("20060102"))
+	} else {
+		line("DTSTART", i.Start.UTC().Format("20060102T150405Z"))
+		line("DTEND", i.End.UTC().Format("20060102T150405Z"))
+	}
+	line("SUMMARY", escapeText(i.Title))
+	if i.Description != "" {
+		line("DESCRIPTION", escapeText(i.Description))
+	}
+	if i.Location != "" {
+		line("LOCATION", escapeText(i.Location))
+	}
+	if i.URL != "" {
+		line("URL", escapeText(i.URL))
+	}
+	line("TRANSP", transparency(i.Type))
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// FindEvent parses a calendar resource and returns the event with uid.
+// Floating times are read in loc.
+func FindEvent(data []byte, uid string, loc *time.Location) (*ics.Event, error) {
+	cal, err := ics.Parse(data, loc)
+	if err != nil {
+		if errors.Is(err, ics.ErrNoEvents) {
+			return nil, ErrEventMissing
+		}
+		return nil, err
+	}
+	for idx := range cal.Events {
+		if cal.Events[idx].UID == uid {
+			return &cal.Events[idx], nil
+		}
+	}
+	return nil, ErrEventMissing
+}
+
+// Assessment deadlines are shown as free time so they don't block the day;
+// interviews, timed or all-day, are busy.
+func transparency(itemType string) string {
+	if itemType == models.CalendarItemAssessment {
+		return "TRANSPARENT"
+	}
+	return "OPAQUE"
+}
+
+func escapeText(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, ";", `\;`)
+	value = strings.ReplaceAll(value, ",", `\,`)
+	value = strings.ReplaceAll(value, "\r\n", `\n`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// writeFolded writes a content line, folding it at 75 octets without
+// splitting a UTF-8 sequence.
+func writeFolded(b *strings.Builder, line string) {
+	const limit = 75
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,106 @@
+package caldav
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	stamp := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("timed event round trips through the parser", func(t *testing.T) {
+		item := testItem()
+		item.Description = "Backend Engineer at Acme; bring notes, questions\nand water"
+		item.Location = "Room 4, Main St"
+		item.URL = "https://meet.example.com/abc"
+
+		data := item.Render(stamp)
+		assert.Contains(t, string(data), "DTSTART:20250310T140000Z\r\n")
+		assert.Contains(t, string(data), "TRANSP:OPAQUE\r\n")
+
+		event, err := FindEvent(data, item.UID(), time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, item.Title, event.Summary)
+		assert.Equal(t, item.Description, event.Description)
+		assert.Equal(t, item.Location, event.Location)
+		assert.True(t, item.Start.Equal(event.Start))
+		assert.True(t, item.End.Equal(event.End))
+		assert.False(t, event.AllDay)
+	})
+
+	t.Run("all-day event", func(t *testing.T) {
+		item := testItem()
+		item.Type = "assessment"
+		item.Start = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+		item.End = item.Start.AddDate(0, 0, 1)
+		item.AllDay = true
+
+		data := string(item.Render(stamp))
+		assert.Contains(t, data, "DTSTART;VALUE=DATE:20250314\r\n")
+		assert.Contains(t, data, "DTEND;VALUE=DATE:20250315\r\n")
+		assert.Contains(t, data, "TRANSP:TRANSPARENT\r\n")
+	})
+
+	t.Run("timed assessment deadline is free time", func(t *testing.T) {
+		item := testItem()
+		item.Type = "assessment"
+
+		assert.Contains(t, string(item.Render(stamp)), "TRANSP:TRANSPARENT\r\n")
+	})
+
+	t.Run("all-day interview is busy", func(t *testing.T) {
+		item := testItem()
+		item.Start = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+		item.End = item.Start.AddDate(0, 0, 1)
+		item.AllDay = true
+
+		assert.Contains(t, string(item.Render(stamp)), "TRANSP:OPAQUE\r\n")
+	})
+
+	t.Run("URL can't inject properties", func(t *testing.T) {
+		item := testItem()
+		item.URL = "https://meet.example.com/abc\r\nATTENDEE:mailto:someone@example.com"
+
+		data := string(item.Render(stamp))
+		assert.Contains(t, data, `URL:https://meet.example.com/abc\nATTENDEE:mailto:someone@example.com`)
+		assert.NotContains(t, data, "\r\nATTENDEE")
+	})
+
+	t.Run("long lines are folded without splitting characters", func(t *testing.T) {
+		item := testItem()
+		item.Title = strings.TrimSpace(strings.Repeat("Entretien technique — ", 10))
+
+		data := item.Render(stamp)
+		for _, line := range strings.Split(string(data), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+
+		event, err := FindEvent(data, item.UID(), time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, item.Title, event.Summary)
+	})
+
+	t.Run("missing event", func(t *testing.T) {
+		item := testItem()
+		_, err := FindEvent(item.Render(stamp), "someone-else", time.UTC)
+		assert.ErrorIs(t, err, ErrEventMissing)
+	})
+}
+
+func TestItemHash(t *testing.T) {
+	item := testItem()
+	same := *item
+	assert.Equal(t, item.Hash(), same.Hash())
+
+	moved := *item
+	moved.Start = moved.Start.Add(30 * time.Minute)
+	assert.NotEqual(t, item.Hash(), moved.Hash())
+
+	renamed := *item
+	renamed.Title = "Acme onsite"
+	assert.NotEqual(t, item.Hash(), renamed.Hash())
+}
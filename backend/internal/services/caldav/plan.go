@@ -0,0 +1,104 @@
+package caldav
+
+import (
+	"ditto-backend/internal/models"
+)
+
+// Op is what a sync run has to do for one item.
+type Op int
+
+const (
+	// OpCreate writes an item that has never been synced.
+	OpCreate Op = iota
+	// OpPush overwrites the calendar's copy with Ditto's.
+	OpPush
+	// OpPull copies a change made in the calendar back into Ditto.
+	OpPull
+	// OpConflict marks an item changed on both sides for the user to resolve.
+	OpConflict
+	// OpRemoteDeleted handles an event that was deleted from the calendar.
+	OpRemoteDeleted
+	// OpDeleteRemote removes the event of an item deleted or cancelled in Ditto.
+	OpDeleteRemote
+)
+
+func (op Op) String() string {
+	switch op {
+	case OpCreate:
+		return "create"
+	case OpPush:
+		return "push"
+	case OpPull:
+		return "pull"
+	case OpConflict:
+		return "conflict"
+	case OpRemoteDeleted:
+		return "remote_deleted"
+	case OpDeleteRemote:
+		return "delete_remote"
+	}
+	return "unknown"
+}
+
+// Action is one step of a sync run. Item is nil for OpDeleteRemote and
+// Synced is nil for OpCreate. RemoteETag is the ETag the calendar reported
+// for the resource, empty if it is not there.
+type Action struct {
+	Op         Op
+	Item       *Item
+	Synced     *models.CalendarSyncItem
+	RemoteETag string
+}
+
+// Plan compares Ditto's items and the last recorded sync state with the
+// ETags currently in the calendar and works out what to do. A side counts as
+// changed when its hash (Ditto) or ETag (calendar) differs from the recorded
+// one; items already waiting on the user to resolve a conflict are left alone.
+func Plan(items []*Item, synced []*models.CalendarSyncItem, remote map[string]string) []Action {
+	byKey := make(map[string]*models.CalendarSyncItem, len(synced))
+	for _, s := range synced {
+		byKey[s.ItemType+":"+s.ItemID.String()] = s
+	}
+
+	var actions []Action
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		key := item.Type + ":" + item.ID.String()
+		seen[key] = true
+
+		state, ok := byKey[key]
+		if !ok {
+			actions = append(actions, Action{Op: OpCreate, Item: item})
+			continue
+		}
+		if state.Status != models.CalendarSyncStatusSynced {
+			continue
+		}
+
+		etag, present := remote[state.Href]
+		localChanged := item.Hash() != state.LocalHash
+		remoteChanged := present && state.ETag != nil && *state.ETag != etag
+
+		switch {
+		case !present && localChanged:
+			actions = append(actions, Action{Op: OpConflict, Item: item, Synced: state})
+		case !present:
+			actions = append(actions, Action{Op: OpRemoteDeleted, Item: item, Synced: state})
+		case localChanged && remoteChanged:
+			actions = append(actions, Action{Op: OpConflict, Item: item, Synced: state, RemoteETag: etag})
+		case localChanged:
+			actions = append(actions, Action{Op: OpPush, Item: item, Synced: state, RemoteETag: etag})
+		case remoteChanged:
+			actions = append(actions, Action{Op: OpPull, Item: item, Synced: state, RemoteETag: etag})
+		}
+	}
+
+	for _, state := range synced {
+		if seen[state.ItemType+":"+state.ItemID.String()] {
+			continue
+		}
+		actions = append(actions, Action{Op: OpDeleteRemote, Synced: state, RemoteETag: remote[state.Href]})
+	}
+
+	return actions
+}
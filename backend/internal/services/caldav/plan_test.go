@@ -0,0 +1,118 @@
+package caldav
+
+import (
+	"testing"
+	"time"
+
+	"ditto-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func syncedState(item *Item, href, etag string) *models.CalendarSyncItem {
+	return &models.CalendarSyncItem{
+		ID:        uuid.New(),
+		ItemType:  item.Type,
+		ItemID:    item.ID,
+		Href:      href,
+		ETag:      &etag,
+		LocalHash: item.Hash(),
+		Status:    models.CalendarSyncStatusSynced,
+	}
+}
+
+func TestPlan(t *testing.T) {
+	const href = "/cal/item.ics"
+
+	tests := []struct {
+		name   string
+		change func(item *Item, remote map[string]string)
+		want   Op
+	}{
+		{
+			name:   "changed in Ditto only",
+			change: func(item *Item, remote map[string]string) { item.Start = item.Start.Add(time.Hour) },
+			want:   OpPush,
+		},
+		{
+			name:   "changed in the calendar only",
+			change: func(item *Item, remote map[string]string) { remote[href] = `"2"` },
+			want:   OpPull,
+		},
+		{
+			name: "changed on both sides",
+			change: func(item *Item, remote map[string]string) {
+				item.Title = "Renamed"
+				remote[href] = `"2"`
+			},
+			want: OpConflict,
+		},
+		{
+			name:   "deleted from the calendar",
+			change: func(item *Item, remote map[string]string) { delete(remote, href) },
+			want:   OpRemoteDeleted,
+		},
+		{
+			name: "deleted from the calendar after changing in Ditto",
+			change: func(item *Item, remote map[string]string) {
+				item.Title = "Renamed"
+				delete(remote, href)
+			},
+			want: OpConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := testItem()
+			state := syncedState(item, href, `"1"`)
+			remote := map[string]string{href: `"1"`}
+
+			tt.change(item, remote)
+
+			actions := Plan([]*Item{item}, []*models.CalendarSyncItem{state}, remote)
+			require.Len(t, actions, 1)
+			assert.Equal(t, tt.want, actions[0].Op)
+			assert.Equal(t, state, actions[0].Synced)
+		})
+	}
+
+	t.Run("unchanged items need nothing", func(t *testing.T) {
+		item := testItem()
+		state := syncedState(item, href, `"1"`)
+
+		actions := Plan([]*Item{item}, []*models.CalendarSyncItem{state}, map[string]string{href: `"1"`})
+		assert.Empty(t, actions)
+	})
+
+	t.Run("new items are created", func(t *testing.T) {
+		item := testItem()
+
+		actions := Plan([]*Item{item}, nil, map[string]string{})
+		require.Len(t, actions, 1)
+		assert.Equal(t, OpCreate, actions[0].Op)
+		assert.Nil(t, actions[0].Synced)
+	})
+
+	t.Run("items gone from Ditto are deleted from the calendar", func(t *testing.T) {
+		item := testItem()
+		state := syncedState(item, href, `"1"`)
+
+		actions := Plan(nil, []*models.CalendarSyncItem{state}, map[string]string{href: `"3"`})
+		require.Len(t, actions, 1)
+		assert.Equal(t, OpDeleteRemote, actions[0].Op)
+		assert.Equal(t, `"3"`, actions[0].RemoteETag)
+	})
+
+	t.Run("unresolved items are left alone", func(t *testing.T) {
+		item := testItem()
+		state := syncedState(item, href, `"1"`)
+		state.Status = models.CalendarSyncStatusConflict
+		item.Title = "Renamed"
+
+		actions := Plan([]*Item{item}, []*models.CalendarSyncItem{state}, map[string]string{href: `"2"`})
+		assert.Empty(t, actions)
+	})
+}
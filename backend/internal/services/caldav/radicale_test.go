@@ -0,0 +1,93 @@
+package caldav
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAgainstCalDAVServer runs the client against a real server. Point
+// CALDAV_TEST_URL at a principal the test may create calendars under, e.g.
+// http://localhost:5232/ditto/ for the Radicale in docker-compose.test.yml.
+func TestAgainstCalDAVServer(t *testing.T) {
+	baseURL := os.Getenv("CALDAV_TEST_URL")
+	if baseURL == "" {
+		t.Skip("CALDAV_TEST_URL not set")
+	}
+	username := os.Getenv("CALDAV_TEST_USERNAME")
+	if username == "" {
+		username = "ditto"
+	}
+	password := os.Getenv("CALDAV_TEST_PASSWORD")
+
+	ctx := context.Background()
+	collectionURL := strings.TrimSuffix(baseURL, "/") + "/ditto-test-" + uuid.NewString() + "/"
+	makeCalendar(t, collectionURL, username, password)
+
+	client, err := NewClient(collectionURL, username, password)
+	require.NoError(t, err)
+	require.NoError(t, client.Check(ctx))
+
+	item := testItem()
+	href := client.Href(item.UID())
+
+	etag, err := client.Put(ctx, href, item.Render(time.Now()), "")
+	require.NoError(t, err)
+	if etag == "" {
+		_, etag, err = client.Get(ctx, href)
+		require.NoError(t, err)
+	}
+
+	etags, err := client.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, etag, etags[href])
+
+	_, err = client.Put(ctx, href, item.Render(time.Now()), "")
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+	item.Start = item.Start.Add(time.Hour)
+	item.End = item.End.Add(time.Hour)
+	_, err = client.Put(ctx, href, item.Render(time.Now()), etag)
+	require.NoError(t, err)
+
+	data, _, err := client.Get(ctx, href)
+	require.NoError(t, err)
+	event, err := FindEvent(data, item.UID(), time.UTC)
+	require.NoError(t, err)
+	assert.True(t, item.Start.Equal(event.Start))
+
+	assert.ErrorIs(t, client.Delete(ctx, href, etag), ErrPreconditionFailed)
+
+	etags, err = client.List(ctx)
+	require.NoError(t, err)
+	require.NoError(t, client.Delete(ctx, href, etags[href]))
+
+	etags, err = client.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, etags)
+}
+
+func makeCalendar(t *testing.T, collectionURL, username, password string) {
+	t.Helper()
+
+	send := func(method string) *http.Response {
+		req, err := http.NewRequest(method, collectionURL, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth(username, password)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := send("MKCALENDAR")
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "MKCALENDAR %s", collectionURL)
+	t.Cleanup(func() { send(http.MethodDelete) })
+}
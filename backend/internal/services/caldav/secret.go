@@ -0,0 +1,62 @@
+package caldav
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var ErrNoEncryptionKey = errors.New("calendar sync encryption key is not configured")
+
+// Sealer encrypts calendar passwords at rest with AES-256-GCM. The key is
+// derived from a server secret, so rotating the secret invalidates every
+// stored password and users have to enter theirs again.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer returns a Sealer keyed by secret, or ErrNoEncryptionKey when the
+// secret is empty.
+func NewSealer(secret string) (*Sealer, error) {
+	if secret == "" {
+		return nil, ErrNoEncryptionKey
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encrypts plaintext and returns it base64 encoded with its nonce.
+func (s *Sealer) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open reverses Seal.
+func (s *Sealer) Open(sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < s.aead.NonceSize() {
+		return "", fmt.Errorf("stored calendar password is corrupt")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("stored calendar password cannot be decrypted: %w", err)
+	}
+	return string(plaintext), nil
+}
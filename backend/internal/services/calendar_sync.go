@@ -0,0 +1,443 @@
+package services
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/caldav"
+	"ditto-backend/internal/services/ics"
+	"ditto-backend/internal/services/schedule"
+	"ditto-backend/pkg/database"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// calendarChangeReason is logged against interviews moved or cancelled from
+// the synced calendar.
+const calendarChangeReason = "Changed in synced calendar"
+
+// assessmentEventLength is how long an assessment deadline shows in the
+// calendar, ending when the assessment is due.
+const assessmentEventLength = 30 * time.Minute
+
+// CalendarSyncResult counts what one sync run did.
+type CalendarSyncResult struct {
+	Created   int `json:"created"`
+	Pushed    int `json:"pushed"`
+	Pulled    int `json:"pulled"`
+	Deleted   int `json:"deleted"`
+	Conflicts int `json:"conflicts"`
+	Failed    int `json:"failed"`
+}
+
+// CalendarSyncService mirrors a user's interviews and assessment deadlines
+// into their CalDAV calendar and copies time changes and deletions made there
+// back into Ditto.
+type CalendarSyncService struct {
+	syncRepo       *repository.CalendarSyncRepository
+	interviewRepo  *repository.InterviewRepository
+	assessmentRepo *repository.AssessmentRepository
+	sealer         *caldav.Sealer
+}
+
+func NewCalendarSyncService(database *database.Database, sealer *caldav.Sealer) *CalendarSyncService {
+	return &CalendarSyncService{
+		syncRepo:       repository.NewCalendarSyncRepository(database),
+		interviewRepo:  repository.NewInterviewRepository(database),
+		assessmentRepo: repository.NewAssessmentRepository(database),
+		sealer:         sealer,
+	}
+}
+
+// Client opens the account's stored password and returns a client for its
+// collection.
+func (s *CalendarSyncService) Client(account *models.CalendarSyncAccount) (*caldav.Client, error) {
+	password, err := s.sealer.Open(account.PasswordEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	return caldav.NewClient(account.CollectionURL, account.Username, password)
+}
+
+// Sync runs one two-way sync for the account and records how it went.
+func (s *CalendarSyncService) Sync(ctx context.Context, account *models.CalendarSyncAccount) (*CalendarSyncResult, error) {
+	result, err := s.sync(ctx, account)
+	if recordErr := s.syncRepo.RecordSyncResult(account.UserID, time.Now(), err); recordErr != nil {
+		return result, recordErr
+	}
+	return result, err
+}
+
+func (s *CalendarSyncService) sync(ctx context.Context, account *models.CalendarSyncAccount) (*CalendarSyncResult, error) {
+	client, err := s.Client(account)
+	if err != nil {
+		return nil, err
+	}
+	loc := accountLocation(account)
+
+	items, err := s.localItems(account, loc)
+	if err != nil {
+		return nil, err
+	}
+	synced, err := s.syncRepo.ListItems(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CalendarSyncResult{}
+	var failures []error
+	for _, action := range caldav.Plan(items, synced, remote) {
+		err := s.apply(ctx, client, account, loc, action, result)
+		if errors.Is(err, caldav.ErrUnauthorized) {
+			return result, err
+		}
+		if err != nil {
+			result.Failed++
+			failures = append(failures, fmt.Errorf("%s %s: %w", action.Op, syncedHref(action), err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return result, fmt.Errorf("%d calendar changes failed: %w", len(failures), errors.Join(failures...))
+	}
+	return result, nil
+}
+
+func (s *CalendarSyncService) apply(ctx context.Context, client *caldav.Client, account *models.CalendarSyncAccount, loc *time.Location, action caldav.Action, result *CalendarSyncResult) error {
+	switch action.Op {
+	case caldav.OpCreate:
+		if err := s.push(ctx, client, account, action.Item, ""); err != nil {
+			return err
+		}
+		result.Created++
+
+	case caldav.OpPush:
+		err := s.push(ctx, client, account, action.Item, action.RemoteETag)
+		if errors.Is(err, caldav.ErrPreconditionFailed) {
+			result.Conflicts++
+			return s.markConflict(action.Synced)
+		}
+		if err != nil {
+			return err
+		}
+		result.Pushed++
+
+	case caldav.OpPull:
+		if err := s.pull(ctx, client, account, loc, action.Item, action.Synced); err != nil {
+			return err
+		}
+		result.Pulled++
+
+	case caldav.OpConflict:
+		result.Conflicts++
+		return s.markConflict(action.Synced)
+
+	case caldav.OpRemoteDeleted:
+		if err := s.acceptRemoteDeletion(account, action.Item, action.Synced); err != nil {
+			return err
+		}
+		result.Deleted++
+
+	case caldav.OpDeleteRemote:
+		err := client.Delete(ctx, action.Synced.Href, action.RemoteETag)
+		if errors.Is(err, caldav.ErrPreconditionFailed) {
+			// Edited in the calendar since we listed it; try again next run
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.syncRepo.DeleteItem(action.Synced.ID); err != nil {
+			return err
+		}
+		result.Deleted++
+	}
+
+	return nil
+}
+
+// Resolve settles a conflict or calendar-side deletion by keeping either
+// Ditto's copy of the item or the calendar's.
+func (s *CalendarSyncService) Resolve(ctx context.Context, account *models.CalendarSyncAccount, state *models.CalendarSyncItem, keepLocal bool) error {
+	client, err := s.Client(account)
+	if err != nil {
+		return err
+	}
+	loc := accountLocation(account)
+
+	items, err := s.localItems(account, loc)
+	if err != nil {
+		return err
+	}
+	var item *caldav.Item
+	for _, candidate := range items {
+		if candidate.Type == state.ItemType && candidate.ID == state.ItemID {
+			item = candidate
+			break
+		}
+	}
+	if item == nil {
+		// Deleted in Ditto since; the next sync removes the calendar copy
+		return s.syncRepo.DeleteItem(state.ID)
+	}
+
+	if !keepLocal {
+		return s.pull(ctx, client, account, loc, item, state)
+	}
+
+	_, etag, err := client.Get(ctx, state.Href)
+	if err != nil && !errors.Is(err, caldav.ErrNotFound) {
+		return err
+	}
+	return s.push(ctx, client, account, item, etag)
+}
+
+// push writes Ditto's copy of an item. An empty etag creates the event; if
+// one turns out to exist already, for example after sync was switched off
+// and on again, Ditto's copy replaces it.
+func (s *CalendarSyncService) push(ctx context.Context, client *caldav.Client, account *models.CalendarSyncAccount, item *caldav.Item, etag string) error {
+	href := client.Href(item.UID())
+	data := item.Render(time.Now())
+
+	newETag, err := client.Put(ctx, href, data, etag)
+	if errors.Is(err, caldav.ErrPreconditionFailed) && etag == "" {
+		_, existing, getErr := client.Get(ctx, href)
+		if getErr != nil {
+			return getErr
+		}
+		newETag, err = client.Put(ctx, href, data, existing)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Not every server returns the new ETag from PUT
+	if newETag == "" {
+		if _, newETag, err = client.Get(ctx, href); err != nil {
+			return err
+		}
+	}
+
+	return s.saveSynced(account, item, href, newETag)
+}
+
+// pull copies the calendar's time for an item into Ditto. Events that are
+// gone, or were cancelled in the calendar, are treated as deleted.
+func (s *CalendarSyncService) pull(ctx context.Context, client *caldav.Client, account *models.CalendarSyncAccount, loc *time.Location, item *caldav.Item, state *models.CalendarSyncItem) error {
+	data, etag, err := client.Get(ctx, state.Href)
+	if errors.Is(err, caldav.ErrNotFound) {
+		return s.acceptRemoteDeletion(account, item, state)
+	}
+	if err != nil {
+		return err
+	}
+
+	event, err := caldav.FindEvent(data, item.UID(), loc)
+	if errors.Is(err, caldav.ErrEventMissing) || (err == nil && event.Cancelled()) {
+		return s.acceptRemoteDeletion(account, item, state)
+	}
+	if err != nil {
+		return err
+	}
+
+	updated := *item
+	switch item.Type {
+	case models.CalendarItemInterview:
+		updates := interviewUpdatesFromEvent(event, loc)
+		interview, _, err := s.interviewRepo.UpdateInterviewWithChange(item.ID, account.UserID, updates, calendarChange())
+		if err != nil {
+			return err
+		}
+		updated.Start, updated.End, updated.AllDay = interviewCalendarTimes(interview.ScheduledDate, interview.ScheduledTime, interview.DurationMinutes, loc)
+
+	case models.CalendarItemAssessment:
+		dueDate := eventDeadline(event, loc)
+		assessment, err := s.assessmentRepo.UpdateAssessment(item.ID, account.UserID, map[string]any{"due_date": dueDate})
+		if err != nil {
+			return err
+		}
+		updated.Start, updated.End = assessmentCalendarTimes(assessment.DueDate)
+	}
+
+	return s.saveSynced(account, &updated, state.Href, etag)
+}
+
+// acceptRemoteDeletion applies a deletion or cancellation in the calendar to
+// Ditto. Interviews are cancelled, which is easy to undo; only those still
+// scheduled change, and one that already happened just stops being synced.
+// Assessments are never deleted from the calendar: the deletion is flagged
+// and deleting the assessment is left to the user.
+func (s *CalendarSyncService) acceptRemoteDeletion(account *models.CalendarSyncAccount, item *caldav.Item, state *models.CalendarSyncItem) error {
+	if item.Type == models.CalendarItemAssessment {
+		return s.flagRemoteDeletion(state)
+	}
+
+	interview, err := s.interviewRepo.GetInterviewByID(item.ID, account.UserID)
+	if err != nil {
+		return err
+	}
+	if interview.Status == models.InterviewStatusScheduled {
+		updates := map[string]any{"status": models.InterviewStatusCancelled}
+		if _, _, err := s.interviewRepo.UpdateInterviewWithChange(item.ID, account.UserID, updates, calendarChange()); err != nil {
+			return err
+		}
+	}
+	return s.syncRepo.DeleteItem(state.ID)
+}
+
+// flagRemoteDeletion marks an item as deleted in the calendar and unlinks it
+// from the event's last known version. Flagged items are not synced until the
+// user keeps Ditto's copy or deletes the item.
+func (s *CalendarSyncService) flagRemoteDeletion(state *models.CalendarSyncItem) error {
+	detail := "Deleted from the calendar"
+	state.Status = models.CalendarSyncStatusRemoteDeleted
+	state.ConflictDetail = &detail
+	state.ETag = nil
+	_, err := s.syncRepo.SaveItem(state)
+	return err
+}
+
+func (s *CalendarSyncService) markConflict(state *models.CalendarSyncItem) error {
+	detail := "Changed in both Ditto and the calendar since the last sync"
+	state.Status = models.CalendarSyncStatusConflict
+	state.ConflictDetail = &detail
+	_, err := s.syncRepo.SaveItem(state)
+	return err
+}
+
+func (s *CalendarSyncService) saveSynced(account *models.CalendarSyncAccount, item *caldav.Item, href, etag string) error {
+	state := &models.CalendarSyncItem{
+		UserID:    account.UserID,
+		ItemType:  item.Type,
+		ItemID:    item.ID,
+		Href:      href,
+		LocalHash: item.Hash(),
+		Status:    models.CalendarSyncStatusSynced,
+	}
+	if etag != "" {
+		state.ETag = &etag
+	}
+	_, err := s.syncRepo.SaveItem(state)
+	return err
+}
+
+// localItems builds the calendar events Ditto wants in the collection.
+func (s *CalendarSyncService) localItems(account *models.CalendarSyncAccount, loc *time.Location) ([]*caldav.Item, error) {
+	interviews, err := s.interviewRepo.ListCalendarInterviews(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	assessments, err := s.assessmentRepo.ListByUserID(account.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*caldav.Item, 0, len(interviews)+len(assessments))
+	for _, interview := range interviews {
+		item := &caldav.Item{
+			Type:        models.CalendarItemInterview,
+			ID:          interview.ID,
+			Title:       fmt.Sprintf("%s %s interview (round %d)", interview.CompanyName, strings.ReplaceAll(interview.InterviewType, "_", " "), interview.RoundNumber),
+			Description: interview.JobTitle + " at " + interview.CompanyName,
+		}
+		if interview.Location != nil {
+			item.Location = *interview.Location
+		}
+		if interview.MeetingLink != nil {
+			item.URL = *interview.MeetingLink
+		}
+		item.Start, item.End, item.AllDay = interviewCalendarTimes(interview.ScheduledDate, interview.ScheduledTime, interview.DurationMinutes, loc)
+		items = append(items, item)
+	}
+
+	for _, assessment := range assessments {
+		start, end := assessmentCalendarTimes(assessment.DueDate)
+		items = append(items, &caldav.Item{
+			Type:        models.CalendarItemAssessment,
+			ID:          assessment.ID,
+			Title:       fmt.Sprintf("%s: %s due", assessment.CompanyName, assessment.Title),
+			Description: assessment.JobTitle + " at " + assessment.CompanyName,
+			Start:       start,
+			End:         end,
+		})
+	}
+
+	return items, nil
+}
+
+// interviewCalendarTimes places an interview's wall-clock time in loc.
+// Interviews without a start time become all-day events.
+func interviewCalendarTimes(date time.Time, scheduledTime *string, durationMinutes *int, loc *time.Location) (start, end time.Time, allDay bool) {
+	start, end, ok := schedule.InterviewWindow(date, scheduledTime, durationMinutes, loc)
+	if !ok {
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		return day, day.AddDate(0, 0, 1), true
+	}
+	return start, end, false
+}
+
+// assessmentCalendarTimes places a deadline in the calendar as a short
+// event that ends when the assessment is due.
+func assessmentCalendarTimes(dueDate time.Time) (start, end time.Time) {
+	return dueDate.Add(-assessmentEventLength), dueDate
+}
+
+// interviewUpdatesFromEvent turns a calendar event's time into interview
+// fields, read in the account's time zone.
+func interviewUpdatesFromEvent(event *ics.Event, loc *time.Location) map[string]any {
+	if event.AllDay {
+		day := event.Start
+		return map[string]any{
+			"scheduled_date": time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC),
+			"scheduled_time": nil,
+		}
+	}
+
+	start := event.Start.In(loc)
+	updates := map[string]any{
+		"scheduled_date": time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		"scheduled_time": start.Format("15:04:05"),
+	}
+	if minutes := int(event.Duration().Minutes()); minutes > 0 {
+		updates["duration_minutes"] = minutes
+	}
+	return updates
+}
+
+// eventDeadline is the deadline an assessment event was moved to: the end
+// of a timed event, or the end of the day in loc for an all-day one.
+func eventDeadline(event *ics.Event, loc *time.Location) time.Time {
+	if event.AllDay {
+		day := event.Start
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc).Add(schedule.EndOfDayClock)
+	}
+	if event.End.IsZero() {
+		return event.Start
+	}
+	return event.End
+}
+
+func accountLocation(account *models.CalendarSyncAccount) *time.Location {
+	if loc, err := time.LoadLocation(account.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+func calendarChange() *repository.InterviewChange {
+	reason := calendarChangeReason
+	return &repository.InterviewChange{Reason: &reason}
+}
+
+func syncedHref(action caldav.Action) string {
+	if action.Item != nil {
+		return action.Item.UID()
+	}
+	return action.Synced.Href
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/caldav"
+	"ditto-backend/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCalendarSyncRemoteAssessmentDeletion(t *testing.T) {
+	db := testutil.NewTestDatabase(t)
+	defer db.Close(t)
+	db.RunMigrations(t)
+
+	ctx := context.Background()
+	server := testutil.NewCalDAVServer(t)
+	sealer, err := caldav.NewSealer("test-secret")
+	require.NoError(t, err)
+
+	userRepo := repository.NewUserRepository(db.Database)
+	companyRepo := repository.NewCompanyRepository(db.Database)
+	jobRepo := repository.NewJobRepository(db.Database)
+	applicationRepo := repository.NewApplicationRepository(db.Database)
+	assessmentRepo := repository.NewAssessmentRepository(db.Database)
+	syncRepo := repository.NewCalendarSyncRepository(db.Database)
+	syncService := NewCalendarSyncService(db.Database, sealer)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	testUser, err := userRepo.CreateUser("calendarsync@example.com", "Calendar Sync User", string(hashedPassword))
+	require.NoError(t, err)
+
+	createdCompany, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Sync Co", "syncco.com"))
+	require.NoError(t, err)
+	createdJob, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(createdCompany.ID, "Software Engineer", "Build things"))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	require.NoError(t, db.Get(&statusID, "SELECT id FROM application_status LIMIT 1"))
+	createdApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	deleted, err := assessmentRepo.CreateAssessment(testutil.CreateTestAssessment(testUser.ID, createdApp.ID, "2030-01-10", models.AssessmentStatusNotStarted))
+	require.NoError(t, err)
+	cancelled, err := assessmentRepo.CreateAssessment(testutil.CreateTestAssessment(testUser.ID, createdApp.ID, "2030-01-12", models.AssessmentStatusNotStarted))
+	require.NoError(t, err)
+
+	password, err := sealer.Seal(server.Password)
+	require.NoError(t, err)
+	account, err := syncRepo.UpsertAccount(&models.CalendarSyncAccount{
+		UserID:            testUser.ID,
+		CollectionURL:     server.CollectionURL(),
+		Username:          server.Username,
+		PasswordEncrypted: password,
+		Timezone:          "UTC",
+		Enabled:           true,
+	})
+	require.NoError(t, err)
+
+	result, err := syncService.Sync(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Created)
+
+	href := func(id uuid.UUID) string {
+		item := &caldav.Item{Type: models.CalendarItemAssessment, ID: id}
+		return server.Collection + item.UID() + ".ics"
+	}
+
+	server.DeleteResource(href(deleted.ID))
+	data, ok := server.Resource(href(cancelled.ID))
+	require.True(t, ok)
+	server.SetResource(href(cancelled.ID), bytes.Replace(data, []byte("END:VEVENT"), []byte("STATUS:CANCELLED\r\nEND:VEVENT"), 1))
+
+	_, err = syncService.Sync(ctx, account)
+	require.NoError(t, err)
+
+	for name, id := range map[string]uuid.UUID{"deleted": deleted.ID, "cancelled": cancelled.ID} {
+		t.Run(name, func(t *testing.T) {
+			_, err := assessmentRepo.GetAssessmentByID(id, testUser.ID)
+			assert.NoError(t, err, "assessment must not be deleted by sync")
+
+			items, err := syncRepo.ListItems(testUser.ID)
+			require.NoError(t, err)
+			var state *models.CalendarSyncItem
+			for _, item := range items {
+				if item.ItemID == id {
+					state = item
+				}
+			}
+			require.NotNil(t, state)
+			assert.Equal(t, models.CalendarSyncStatusRemoteDeleted, state.Status)
+			assert.Nil(t, state.ETag)
+		})
+	}
+
+	t.Run("flagged assessments are left alone by later syncs", func(t *testing.T) {
+		result, err := syncService.Sync(ctx, account)
+		require.NoError(t, err)
+		assert.Equal(t, CalendarSyncResult{}, *result)
+	})
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/caldav"
+	"ditto-backend/pkg/database"
+	"log"
+	"time"
+)
+
+// calendarSyncTimeout bounds one account's sync so a slow server can't stall
+// everyone else's.
+const calendarSyncTimeout = 2 * time.Minute
+
+type CalendarSyncScheduler struct {
+	syncRepo    *repository.CalendarSyncRepository
+	syncService *CalendarSyncService
+	ticker      *time.Ticker
+	done        chan bool
+}
+
+func NewCalendarSyncScheduler(database *database.Database, sealer *caldav.Sealer) *CalendarSyncScheduler {
+	return &CalendarSyncScheduler{
+		syncRepo:    repository.NewCalendarSyncRepository(database),
+		syncService: NewCalendarSyncService(database, sealer),
+		done:        make(chan bool),
+	}
+}
+
+func (s *CalendarSyncScheduler) Start(interval time.Duration) {
+	s.ticker = time.NewTicker(interval)
+	go func() {
+		s.syncAccounts()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-s.ticker.C:
+				s.syncAccounts()
+			}
+		}
+	}()
+	log.Printf("Calendar sync scheduler started with %v interval", interval)
+}
+
+func (s *CalendarSyncScheduler) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Calendar sync scheduler stopped")
+}
+
+func (s *CalendarSyncScheduler) syncAccounts() {
+	accounts, err := s.syncRepo.ListEnabledAccounts()
+	if err != nil {
+		log.Printf("Error fetching calendar sync accounts: %v", err)
+		return
+	}
+
+	for _, account := range accounts {
+		ctx, cancel := context.WithTimeout(context.Background(), calendarSyncTimeout)
+		_, err := s.syncService.Sync(ctx, account)
+		cancel()
+		if err != nil {
+			log.Printf("Error syncing calendar for user %s: %v", account.UserID, err)
+		}
+	}
+}
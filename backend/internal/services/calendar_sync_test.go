@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"ditto-backend/internal/services/ics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterviewCalendarTimes(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("wall-clock time is read in the account's zone", func(t *testing.T) {
+		scheduled := "14:30:00"
+		duration := 45
+
+		start, end, allDay := interviewCalendarTimes(date, &scheduled, &duration, berlin)
+		assert.False(t, allDay)
+		assert.Equal(t, time.Date(2025, 3, 10, 13, 30, 0, 0, time.UTC), start.UTC())
+		assert.Equal(t, 45*time.Minute, end.Sub(start))
+	})
+
+	t.Run("interviews without a time are all-day", func(t *testing.T) {
+		start, end, allDay := interviewCalendarTimes(date, nil, nil, berlin)
+		assert.True(t, allDay)
+		assert.Equal(t, "2025-03-10", start.Format("2006-01-02"))
+		assert.Equal(t, "2025-03-11", end.Format("2006-01-02"))
+	})
+}
+
+func TestInterviewUpdatesFromEvent(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("moved event in another zone", func(t *testing.T) {
+		start := time.Date(2025, 3, 11, 23, 30, 0, 0, time.UTC)
+		event := &ics.Event{Start: start, End: start.Add(90 * time.Minute)}
+
+		updates := interviewUpdatesFromEvent(event, berlin)
+		assert.Equal(t, time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC), updates["scheduled_date"])
+		assert.Equal(t, "00:30:00", updates["scheduled_time"])
+		assert.Equal(t, 90, updates["duration_minutes"])
+	})
+
+	t.Run("all-day event clears the time", func(t *testing.T) {
+		event := &ics.Event{
+			Start:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+			AllDay: true,
+		}
+
+		updates := interviewUpdatesFromEvent(event, berlin)
+		assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), updates["scheduled_date"])
+		assert.Nil(t, updates["scheduled_time"])
+		assert.NotContains(t, updates, "duration_minutes")
+	})
+
+	t.Run("assessment is due when its event ends", func(t *testing.T) {
+		start := time.Date(2025, 3, 11, 23, 30, 0, 0, time.UTC)
+		event := &ics.Event{Start: start, End: start.Add(30 * time.Minute)}
+		assert.Equal(t, start.Add(30*time.Minute), eventDeadline(event, berlin))
+	})
+
+	t.Run("all-day assessment event is due at the end of the day in the account's zone", func(t *testing.T) {
+		event := &ics.Event{Start: time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC), AllDay: true}
+		assert.Equal(t, time.Date(2025, 3, 12, 23, 59, 0, 0, berlin), eventDeadline(event, berlin))
+	})
+}
@@ -0,0 +1,178 @@
+// Package clamav scans file contents for malware with a clamd daemon.
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// chunkSize is how much of the stream goes in each INSTREAM chunk. clamd
+// accepts any size up to its StreamMaxLength.
+const chunkSize = 32 * 1024
+
+// ErrSizeLimit means the file is larger than clamd's StreamMaxLength, so it
+// couldn't be scanned.
+var ErrSizeLimit = errors.New("file exceeds clamd's stream size limit")
+
+// Result is the verdict on one file. Signature names the malware found.
+type Result struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner inspects a stream for malware.
+type Scanner interface {
+	Scan(ctx context.Context, body io.Reader) (*Result, error)
+}
+
+// Client talks to clamd over its TCP protocol.
+type Client struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClient returns a client for the clamd listening on address, e.g.
+// localhost:3310. timeout bounds a whole scan.
+func NewClient(address string, timeout time.Duration) *Client {
+	return &Client{address: address, timeout: timeout}
+}
+
+// Ping checks that clamd is up.
+func (c *Client) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("clamd ping: %w", err)
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("clamd ping: unexpected reply %q", reply)
+	}
+
+	return nil
+}
+
+// Scan streams body to clamd with the INSTREAM command.
+func (c *Client) Scan(ctx context.Context, body io.Reader) (*Result, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := writeStream(conn, body); err != nil {
+		// clamd stops reading and replies as soon as the stream is too
+		// long, so a failed write may still have an answer waiting.
+		if reply, replyErr := readReply(conn); replyErr == nil {
+			return parseReply(reply)
+		}
+		return nil, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReply(reply)
+}
+
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, fmt.Errorf("connect to clamd: %w", err)
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// writeStream sends the INSTREAM command, the body as length-prefixed chunks
+// and the zero-length chunk that ends it.
+func writeStream(w io.Writer, body io.Reader) error {
+	buffered := bufio.NewWriterSize(w, chunkSize+4)
+
+	if _, err := buffered.WriteString("zINSTREAM\x00"); err != nil {
+		return fmt.Errorf("clamd instream: %w", err)
+	}
+
+	chunk := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := io.ReadFull(body, chunk)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := buffered.Write(size); err != nil {
+				return fmt.Errorf("clamd instream: %w", err)
+			}
+			if _, err := buffered.Write(chunk[:n]); err != nil {
+				return fmt.Errorf("clamd instream: %w", err)
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("read file for scanning: %w", readErr)
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := buffered.Write(size); err != nil {
+		return fmt.Errorf("clamd instream: %w", err)
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("clamd instream: %w", err)
+	}
+	return nil
+}
+
+// readReply reads one null-terminated reply, as sent for z-prefixed commands.
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && (err != io.EOF || reply == "") {
+		return "", fmt.Errorf("read clamd reply: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+// parseReply turns "stream: OK" or "stream: <signature> FOUND" into a result.
+func parseReply(reply string) (*Result, error) {
+	verdict := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	case strings.Contains(verdict, "size limit exceeded"):
+		return nil, ErrSizeLimit
+	default:
+		return nil, fmt.Errorf("clamd: %s", verdict)
+	}
+}
@@ -0,0 +1,151 @@
+package clamav
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd accepts INSTREAM and PING the way clamd does, reporting streams
+// that contain EICAR as infected and rejecting ones over maxStream bytes.
+func fakeClamd(t *testing.T, maxStream int) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxStream)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveClamd(conn net.Conn, maxStream int) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	command, err := reader.ReadString(0)
+	if err != nil {
+		return
+	}
+
+	switch command {
+	case "zPING\x00":
+		_, _ = conn.Write([]byte("PONG\x00"))
+	case "zINSTREAM\x00":
+		var stream bytes.Buffer
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(reader, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			if stream.Len()+int(n) > maxStream {
+				_, _ = conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+				return
+			}
+			if _, err := io.CopyN(&stream, reader, int64(n)); err != nil {
+				return
+			}
+		}
+
+		if strings.Contains(stream.String(), EICAR) {
+			_, _ = conn.Write([]byte("stream: " + EICARSignature + " FOUND\x00"))
+			return
+		}
+		_, _ = conn.Write([]byte("stream: OK\x00"))
+	default:
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(fakeClamd(t, 100*1024), 5*time.Second)
+
+	t.Run("Ping", func(t *testing.T) {
+		assert.NoError(t, client.Ping(ctx))
+	})
+
+	t.Run("clean file", func(t *testing.T) {
+		result, err := client.Scan(ctx, strings.NewReader("%PDF-1.7 resume"))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+	})
+
+	t.Run("infected file spanning chunks", func(t *testing.T) {
+		body := strings.Repeat("a", chunkSize-10) + EICAR
+		result, err := client.Scan(ctx, strings.NewReader(body))
+		require.NoError(t, err)
+		assert.True(t, result.Infected)
+		assert.Equal(t, EICARSignature, result.Signature)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		result, err := client.Scan(ctx, strings.NewReader(""))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+	})
+
+	t.Run("over the size limit", func(t *testing.T) {
+		_, err := client.Scan(ctx, bytes.NewReader(make([]byte, 200*1024)))
+		assert.ErrorIs(t, err, ErrSizeLimit)
+	})
+
+	t.Run("clamd unreachable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		_, err = NewClient(address, time.Second).Scan(ctx, strings.NewReader("data"))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseReply(t *testing.T) {
+	result, err := parseReply("stream: OK")
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = parseReply("stream: Win.Trojan.Agent-123 FOUND")
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Infected: true, Signature: "Win.Trojan.Agent-123"}, result)
+
+	_, err = parseReply("INSTREAM size limit exceeded. ERROR")
+	assert.ErrorIs(t, err, ErrSizeLimit)
+
+	_, err = parseReply("stream: Can't allocate memory ERROR")
+	assert.EqualError(t, err, "clamd: Can't allocate memory ERROR")
+}
+
+func TestFake(t *testing.T) {
+	fake := NewFake()
+
+	result, err := fake.Scan(context.Background(), strings.NewReader("prefix "+EICAR))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+
+	result, err = fake.Scan(context.Background(), strings.NewReader("clean"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+	assert.Equal(t, 2, fake.Scans())
+}
@@ -0,0 +1,55 @@
+package clamav
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// EICAR is the standard antivirus test file. Every scanner, including Fake,
+// reports it as infected.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// EICARSignature is the name clamd gives the EICAR test file.
+const EICARSignature = "Win.Test.EICAR_HDB-1"
+
+// Fake is an in-memory Scanner for tests. It flags content containing the
+// EICAR string and fails every scan while Err is set.
+type Fake struct {
+	Err error
+
+	mu    sync.Mutex
+	scans int
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Scan(ctx context.Context, body io.Reader) (*Result, error) {
+	f.mu.Lock()
+	f.scans++
+	f.mu.Unlock()
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Contains(data, []byte(EICAR)) {
+		return &Result{Infected: true, Signature: EICARSignature}, nil
+	}
+	return &Result{}, nil
+}
+
+// Scans returns how many scans have been requested.
+func (f *Fake) Scans() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.scans
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"ditto-backend/internal/repository"
+	"ditto-backend/pkg/database"
+	"log"
+	"time"
+)
+
+const (
+	// companyReenrichAfter is how long enrichment data is trusted before it is refreshed
+	companyReenrichAfter = 30 * 24 * time.Hour
+	// companyEnrichBatchSize caps the number of companies enriched per run
+	companyEnrichBatchSize = 50
+)
+
+type CompanyEnrichmentScheduler struct {
+	companyRepo *repository.CompanyRepository
+	ticker      *time.Ticker
+	done        chan bool
+}
+
+func NewCompanyEnrichmentScheduler(database *database.Database) *CompanyEnrichmentScheduler {
+	return &CompanyEnrichmentScheduler{
+		companyRepo: repository.NewCompanyRepository(database),
+		done:        make(chan bool),
+	}
+}
+
+func (s *CompanyEnrichmentScheduler) Start(interval time.Duration) {
+	s.ticker = time.NewTicker(interval)
+	go func() {
+		s.processStaleCompanies()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-s.ticker.C:
+				s.processStaleCompanies()
+			}
+		}
+	}()
+	log.Printf("Company enrichment scheduler started with %v interval", interval)
+}
+
+func (s *CompanyEnrichmentScheduler) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Company enrichment scheduler stopped")
+}
+
+func (s *CompanyEnrichmentScheduler) processStaleCompanies() {
+	companies, err := s.companyRepo.GetCompaniesDueForEnrichment(time.Now().Add(-companyReenrichAfter), companyEnrichBatchSize)
+	if err != nil {
+		log.Printf("Error fetching companies due for enrichment: %v", err)
+		return
+	}
+
+	for _, company := range companies {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := s.companyRepo.EnrichCompany(ctx, company)
+		cancel()
+		if err != nil {
+			log.Printf("Error enriching company %s: %v", company.Name, err)
+		}
+	}
+
+	if len(companies) > 0 {
+		log.Printf("Re-enriched %d companies", len(companies))
+	}
+}
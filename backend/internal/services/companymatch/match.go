@@ -0,0 +1,284 @@
+// Package companymatch detects companies that are probably the same
+// organisation entered under different names ("Google", "Google LLC",
+// "google inc.").
+package companymatch
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DefaultThreshold is the minimum similarity for two companies to be
+// suggested as duplicates.
+const DefaultThreshold = 0.9
+
+// legalSuffixes are dropped from the end of a name before comparing.
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "lp": true,
+	"ltd": true, "limited": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "plc": true, "gmbh": true, "ag": true,
+	"sa": true, "sas": true, "bv": true, "nv": true, "pty": true,
+	"pvt": true, "oy": true, "ab": true, "as": true, "srl": true, "spa": true,
+	"holdings": true, "group": true,
+}
+
+// NormalizeName lower-cases a company name, strips punctuation, a leading
+// "the" and trailing legal suffixes: "The Google, Inc." becomes "google".
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '&':
+			b.WriteString(" and ")
+		case r == '.' || r == '\'':
+			// "inc." -> "inc", "mcdonald's" -> "mcdonalds"
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	if len(tokens) > 1 && tokens[0] == "the" {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 1 && legalSuffixes[tokens[len(tokens)-1]] {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// NormalizeDomain reduces a domain or URL to its bare host without "www.".
+func NormalizeDomain(value string) string {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return ""
+	}
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || !strings.Contains(parsed.Hostname(), ".") {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// Candidate is a company considered for de-duplication.
+type Candidate struct {
+	ID       uuid.UUID `json:"id" db:"id"`
+	Name     string    `json:"name" db:"name"`
+	Domain   *string   `json:"domain,omitempty" db:"domain"`
+	JobCount int       `json:"job_count" db:"job_count"`
+}
+
+// Similarity scores how likely two companies are the same, from 0 to 1.
+// A shared domain is treated as certain; otherwise the normalized names are
+// compared with Jaro-Winkler.
+func Similarity(a, b Candidate) float64 {
+	if a.Domain != nil && b.Domain != nil {
+		domainA, domainB := NormalizeDomain(*a.Domain), NormalizeDomain(*b.Domain)
+		if domainA != "" && domainA == domainB {
+			return 1
+		}
+	}
+
+	nameA, nameB := NormalizeName(a.Name), NormalizeName(b.Name)
+	if nameA == "" || nameB == "" {
+		return 0
+	}
+	if nameA == nameB {
+		return 0.99
+	}
+	return jaroWinkler(nameA, nameB)
+}
+
+// Cluster is a group of companies that look like duplicates of each other.
+type Cluster struct {
+	Companies []Candidate `json:"companies"`
+	// Score is the lowest similarity among the pairs that joined the cluster
+	Score float64 `json:"score"`
+	// SuggestedSurvivorID is the company with the most jobs (then the
+	// shortest name), which is the least disruptive to keep.
+	SuggestedSurvivorID uuid.UUID `json:"suggested_survivor_id"`
+}
+
+// FindClusters groups candidates whose pairwise similarity is at least
+// threshold. Clusters are returned most-confident first.
+func FindClusters(candidates []Candidate, threshold float64) []Cluster {
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	minScore := make(map[int]float64)
+	link := func(i, j int) {
+		score := Similarity(candidates[i], candidates[j])
+		if score < threshold {
+			return
+		}
+
+		rootI, rootJ := find(i), find(j)
+		merged := score
+		if s, ok := minScore[rootI]; ok && s < merged {
+			merged = s
+		}
+		if s, ok := minScore[rootJ]; ok && s < merged {
+			merged = s
+		}
+		if rootI != rootJ {
+			parent[rootJ] = rootI
+			delete(minScore, rootJ)
+		}
+		minScore[rootI] = merged
+	}
+
+	// Only compare companies that share a block (name prefix or domain) so
+	// this stays far below n² on a large companies table.
+	for _, block := range blocks(candidates) {
+		for a := 0; a < len(block); a++ {
+			for b := a + 1; b < len(block); b++ {
+				link(block[a], block[b])
+			}
+		}
+	}
+
+	groups := make(map[int][]Candidate)
+	for i, candidate := range candidates {
+		root := find(i)
+		groups[root] = append(groups[root], candidate)
+	}
+
+	clusters := make([]Cluster, 0)
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool {
+			if members[i].JobCount != members[j].JobCount {
+				return members[i].JobCount > members[j].JobCount
+			}
+			if len(members[i].Name) != len(members[j].Name) {
+				return len(members[i].Name) < len(members[j].Name)
+			}
+			return members[i].Name < members[j].Name
+		})
+		clusters = append(clusters, Cluster{
+			Companies:           members,
+			Score:               minScore[root],
+			SuggestedSurvivorID: members[0].ID,
+		})
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return clusters[i].Companies[0].Name < clusters[j].Companies[0].Name
+	})
+
+	return clusters
+}
+
+// blockPrefixLength is how many leading runes of the normalized name two
+// companies must share to be compared by name
+const blockPrefixLength = 2
+
+// blocks groups candidate indexes by normalized-name prefix and by domain.
+func blocks(candidates []Candidate) [][]int {
+	keyed := make(map[string][]int)
+	for i, candidate := range candidates {
+		name := []rune(NormalizeName(candidate.Name))
+		if len(name) > 0 {
+			key := "name:" + string(name[:min(blockPrefixLength, len(name))])
+			keyed[key] = append(keyed[key], i)
+		}
+		if candidate.Domain != nil {
+			if domain := NormalizeDomain(*candidate.Domain); domain != "" {
+				keyed["domain:"+domain] = append(keyed["domain:"+domain], i)
+			}
+		}
+	}
+
+	result := make([][]int, 0, len(keyed))
+	for _, indexes := range keyed {
+		if len(indexes) > 1 {
+			result = append(result, indexes)
+		}
+	}
+	return result
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings.
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	matchDistance := max(len(ra), len(rb))/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		start := max(0, i-matchDistance)
+		end := min(len(rb), i+matchDistance+1)
+		for j := start; j < end; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for i := 0; i < min(4, len(ra), len(rb)); i++ {
+		if ra[i] != rb[i] {
+			break
+		}
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,75 @@
+package companymatch
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"Google":               "google",
+		"Google LLC":           "google",
+		"google inc.":          "google",
+		"The Google, Inc.":     "google",
+		"Johnson & Johnson":    "johnson and johnson",
+		"McDonald's Corp":      "mcdonalds",
+		"Acme Holdings Co Ltd": "acme",
+		"  Stripe   ":          "stripe",
+		"Inc":                  "inc",
+		"Deutsche Bank AG":     "deutsche bank",
+		"Meta Platforms, Inc.": "meta platforms",
+	}
+
+	for input, want := range cases {
+		assert.Equal(t, want, NormalizeName(input), input)
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	assert.Equal(t, "google.com", NormalizeDomain("https://www.Google.com/about"))
+	assert.Equal(t, "google.com", NormalizeDomain("google.com"))
+	assert.Equal(t, "", NormalizeDomain(""))
+	assert.Equal(t, "", NormalizeDomain("localhost"))
+}
+
+func TestSimilarity(t *testing.T) {
+	domain := func(d string) *string { return &d }
+
+	assert.Equal(t, 1.0, Similarity(
+		Candidate{Name: "Alphabet", Domain: domain("google.com")},
+		Candidate{Name: "Google", Domain: domain("www.google.com")},
+	))
+	assert.Equal(t, 0.99, Similarity(Candidate{Name: "Google"}, Candidate{Name: "Google LLC"}))
+	assert.GreaterOrEqual(t, Similarity(Candidate{Name: "Gogle"}, Candidate{Name: "Google"}), DefaultThreshold)
+	assert.Less(t, Similarity(Candidate{Name: "Stripe"}, Candidate{Name: "Shopify"}), DefaultThreshold)
+}
+
+func TestFindClusters(t *testing.T) {
+	google := Candidate{ID: uuid.New(), Name: "Google", JobCount: 5}
+	googleLLC := Candidate{ID: uuid.New(), Name: "Google LLC", JobCount: 1}
+	googleInc := Candidate{ID: uuid.New(), Name: "google inc.", JobCount: 0}
+	stripe := Candidate{ID: uuid.New(), Name: "Stripe", JobCount: 2}
+	shopify := Candidate{ID: uuid.New(), Name: "Shopify", JobCount: 2}
+
+	clusters := FindClusters([]Candidate{googleInc, stripe, googleLLC, shopify, google}, DefaultThreshold)
+
+	require.Len(t, clusters, 1)
+	cluster := clusters[0]
+	require.Len(t, cluster.Companies, 3)
+	assert.Equal(t, google.ID, cluster.SuggestedSurvivorID)
+	assert.Equal(t, 0.99, cluster.Score)
+
+	assert.Empty(t, FindClusters([]Candidate{stripe, shopify}, DefaultThreshold))
+
+	domain := "google.com"
+	alphabet := Candidate{ID: uuid.New(), Name: "Alphabet", Domain: &domain}
+	googleWithDomain := Candidate{ID: uuid.New(), Name: "Google", Domain: &domain, JobCount: 3}
+	clusters = FindClusters([]Candidate{alphabet, googleWithDomain}, DefaultThreshold)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, 1.0, clusters[0].Score)
+	assert.Equal(t, googleWithDomain.ID, clusters[0].SuggestedSurvivorID)
+	assert.Empty(t, FindClusters(nil, DefaultThreshold))
+}
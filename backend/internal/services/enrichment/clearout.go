@@ -0,0 +1,122 @@
+package enrichment
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const clearoutAutocompleteURL = "https://api.clearout.io/public/companies/autocomplete"
+
+// ClearoutEnricher uses clearout's public company autocomplete API.
+type ClearoutEnricher struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewClearoutEnricher(client *http.Client) *ClearoutEnricher {
+	return &ClearoutEnricher{
+		client:  client,
+		baseURL: clearoutAutocompleteURL,
+	}
+}
+
+type clearoutCompany struct {
+	Name    string `json:"name"`
+	Domain  string `json:"domain"`
+	LogoURL string `json:"logo_url"`
+	Website string `json:"website"`
+}
+
+func (e *ClearoutEnricher) Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error) {
+	companies, err := e.autocomplete(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(companies) == 0 {
+		return nil, ErrNoData
+	}
+
+	trimmedName := strings.TrimSpace(name)
+	for _, item := range companies {
+		if strings.EqualFold(strings.TrimSpace(item.Name), trimmedName) {
+			return &models.CompanyEnrichmentData{
+				Domain:   item.Domain,
+				LogoURL:  item.LogoURL,
+				Website:  item.Website,
+				Verified: true,
+			}, nil
+		}
+	}
+
+	item := companies[0]
+	return &models.CompanyEnrichmentData{
+		Domain:   item.Domain,
+		LogoURL:  item.LogoURL,
+		Website:  item.Website,
+		Verified: false,
+	}, nil
+}
+
+func (e *ClearoutEnricher) Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error) {
+	companies, err := e.autocomplete(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*models.CompanySuggestion, 0, len(companies))
+	for i, item := range companies {
+		if i >= limit {
+			break
+		}
+
+		suggestion := &models.CompanySuggestion{
+			Name:   item.Name,
+			Source: "suggestion",
+		}
+		if item.Domain != "" {
+			suggestion.Domain = &item.Domain
+		}
+		if item.LogoURL != "" {
+			suggestion.LogoURL = &item.LogoURL
+		}
+		if item.Website != "" {
+			suggestion.Website = &item.Website
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+func (e *ClearoutEnricher) autocomplete(ctx context.Context, query string) ([]clearoutCompany, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.baseURL+"?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("clearout returned HTTP %d", resp.StatusCode)
+	}
+
+	var clearoutResponse struct {
+		Data []clearoutCompany `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&clearoutResponse); err != nil {
+		return nil, err
+	}
+
+	return clearoutResponse.Data, nil
+}
@@ -0,0 +1,302 @@
+// Package enrichment looks up public company metadata (domain, website, logo)
+// from a prioritized list of providers.
+package enrichment
+
+import (
+	"container/list"
+	"context"
+	"ditto-backend/internal/models"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoData is returned when no provider knows anything about a company.
+var ErrNoData = errors.New("no enrichment data found")
+
+const (
+	// maxCacheEntries bounds the chain's cache; the least recently used entry
+	// is evicted once it is full
+	maxCacheEntries = 10000
+	// cacheSweepInterval is how often expired entries are swept from the cache
+	cacheSweepInterval = 10 * time.Minute
+)
+
+// CompanyEnricher looks up public metadata for a company.
+type CompanyEnricher interface {
+	// Enrich returns what the enricher knows about the named company. known
+	// carries fields already found by higher-priority enrichers and may be nil.
+	Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error)
+	// Suggest returns autocomplete suggestions for a partial company name.
+	Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error)
+}
+
+// Chain tries each enricher in priority order. Fields found by an earlier
+// enricher are never overwritten by a later one, and later enrichers see what
+// has been found so far (so the favicon provider can use a domain found by
+// clearout). Results, including misses, are cached for the chain's TTL in an
+// LRU cache of at most maxEntries entries. Lookups where a provider failed
+// aren't cached, so they are retried on the next call.
+type Chain struct {
+	enrichers  []CompanyEnricher
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu        sync.Mutex
+	cache     map[string]*list.Element
+	order     *list.List // most recently used first
+	lastSweep time.Time
+}
+
+type cacheEntry struct {
+	key         string
+	data        *models.CompanyEnrichmentData
+	suggestions []*models.CompanySuggestion
+	err         error
+	expiresAt   time.Time
+}
+
+func NewChain(ttl time.Duration, enrichers ...CompanyEnricher) *Chain {
+	return &Chain{
+		enrichers:  enrichers,
+		ttl:        ttl,
+		maxEntries: maxCacheEntries,
+		now:        time.Now,
+		cache:      make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+var (
+	defaultChain     *Chain
+	defaultChainOnce sync.Once
+)
+
+// Default returns the process-wide enrichment chain: the bundled static
+// dataset, then clearout, then favicon-derived logos. It is shared so every
+// repository instance reuses one HTTP client and one cache.
+func Default() *Chain {
+	defaultChainOnce.Do(func() {
+		client := &http.Client{Timeout: 5 * time.Second}
+
+		static, err := NewBundledStaticEnricher()
+		if err != nil {
+			log.Printf("Failed to load bundled company dataset: %v", err)
+			static = NewStaticEnricher(nil)
+		}
+
+		defaultChain = NewChain(24*time.Hour,
+			static,
+			NewClearoutEnricher(client),
+			NewFaviconEnricher(),
+		)
+	})
+	return defaultChain
+}
+
+func (c *Chain) Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error) {
+	key := "enrich:" + normalizeKey(name)
+	if known != nil {
+		key += "|" + strings.ToLower(known.Domain)
+	}
+
+	if entry, ok := c.lookup(key); ok {
+		return copyData(entry.data), entry.err
+	}
+
+	result := copyData(known)
+	if result == nil {
+		result = &models.CompanyEnrichmentData{}
+	}
+	found, failed := false, false
+
+	for _, enricher := range c.enrichers {
+		if isComplete(result) {
+			break
+		}
+
+		data, err := enricher.Enrich(ctx, name, copyData(result))
+		if err != nil {
+			if !errors.Is(err, ErrNoData) {
+				log.Printf("Company enricher %T failed for %q: %v", enricher, name, err)
+				failed = true
+			}
+			continue
+		}
+
+		if mergeData(result, data) {
+			found = true
+		}
+	}
+
+	if !found {
+		if !failed {
+			c.store(key, cacheEntry{err: ErrNoData})
+		}
+		return nil, ErrNoData
+	}
+
+	if !failed {
+		c.store(key, cacheEntry{data: copyData(result)})
+	}
+	return result, nil
+}
+
+func (c *Chain) Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error) {
+	if limit <= 0 {
+		return []*models.CompanySuggestion{}, nil
+	}
+
+	// Providers truncate to limit, so results for one limit can't answer a
+	// larger one
+	key := "suggest:" + strconv.Itoa(limit) + ":" + normalizeKey(query)
+	if entry, ok := c.lookup(key); ok {
+		return truncateSuggestions(entry.suggestions, limit), nil
+	}
+
+	var suggestions []*models.CompanySuggestion
+	seen := make(map[string]bool)
+	failed := false
+
+	for _, enricher := range c.enrichers {
+		results, err := enricher.Suggest(ctx, query, limit)
+		if err != nil {
+			if !errors.Is(err, ErrNoData) {
+				log.Printf("Company enricher %T failed to suggest %q: %v", enricher, query, err)
+				failed = true
+			}
+			continue
+		}
+
+		for _, s := range results {
+			nameKey := normalizeKey(s.Name)
+			if nameKey == "" || seen[nameKey] {
+				continue
+			}
+			seen[nameKey] = true
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	if suggestions == nil {
+		suggestions = []*models.CompanySuggestion{}
+	}
+
+	if !failed {
+		c.store(key, cacheEntry{suggestions: suggestions})
+	}
+	return truncateSuggestions(suggestions, limit), nil
+}
+
+func (c *Chain) lookup(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.cache[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if c.now().After(entry.expiresAt) {
+		c.remove(elem)
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return *entry, true
+}
+
+func (c *Chain) store(key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= cacheSweepInterval {
+		c.sweep(now)
+		c.lastSweep = now
+	}
+
+	entry.key = key
+	entry.expiresAt = now.Add(c.ttl)
+
+	if elem, ok := c.cache[key]; ok {
+		elem.Value = &entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.cache[key] = c.order.PushFront(&entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// sweep drops expired entries, which would otherwise only be removed when
+// their key is looked up again. Callers must hold c.mu.
+func (c *Chain) sweep(now time.Time) {
+	for elem := c.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		if now.After(elem.Value.(*cacheEntry).expiresAt) {
+			c.remove(elem)
+		}
+		elem = prev
+	}
+}
+
+func (c *Chain) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.cache, elem.Value.(*cacheEntry).key)
+}
+
+// mergeData fills empty fields in dst from src and reports whether anything
+// new was added.
+func mergeData(dst, src *models.CompanyEnrichmentData) bool {
+	if src == nil {
+		return false
+	}
+
+	changed := false
+	if dst.Domain == "" && src.Domain != "" {
+		dst.Domain = src.Domain
+		changed = true
+	}
+	if dst.Website == "" && src.Website != "" {
+		dst.Website = src.Website
+		changed = true
+	}
+	if dst.LogoURL == "" && src.LogoURL != "" {
+		dst.LogoURL = src.LogoURL
+		changed = true
+	}
+	if src.Verified && !dst.Verified {
+		dst.Verified = true
+	}
+	return changed
+}
+
+func isComplete(data *models.CompanyEnrichmentData) bool {
+	return data.Domain != "" && data.Website != "" && data.LogoURL != ""
+}
+
+func copyData(data *models.CompanyEnrichmentData) *models.CompanyEnrichmentData {
+	if data == nil {
+		return nil
+	}
+	c := *data
+	return &c
+}
+
+func truncateSuggestions(suggestions []*models.CompanySuggestion, limit int) []*models.CompanySuggestion {
+	if len(suggestions) > limit {
+		return suggestions[:limit]
+	}
+	return suggestions
+}
+
+func normalizeKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
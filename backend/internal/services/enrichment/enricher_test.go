@@ -0,0 +1,282 @@
+package enrichment
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainEnrich(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("earlier providers win and later ones fill gaps", func(t *testing.T) {
+		first := NewFake()
+		first.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.com", Verified: true}
+
+		second := NewFake()
+		second.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.io", Website: "https://acme.io"}
+
+		chain := NewChain(time.Hour, first, second, NewFaviconEnricher())
+
+		data, err := chain.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "acme.com", data.Domain)
+		assert.Equal(t, "https://acme.io", data.Website)
+		assert.Contains(t, data.LogoURL, "domain=acme.com")
+		assert.True(t, data.Verified)
+	})
+
+	t.Run("stops once every field is known", func(t *testing.T) {
+		first := NewFake()
+		first.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.com", Website: "https://acme.com", LogoURL: "https://acme.com/logo.png"}
+		second := NewFake()
+
+		chain := NewChain(time.Hour, first, second)
+
+		_, err := chain.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, second.EnrichCalls())
+	})
+
+	t.Run("provider errors fall through to the next provider", func(t *testing.T) {
+		broken := NewFake()
+		broken.Err = errors.New("boom")
+		working := NewFake()
+		working.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.com"}
+
+		data, err := NewChain(time.Hour, broken, working).Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "acme.com", data.Domain)
+	})
+
+	t.Run("returns ErrNoData when nothing is found", func(t *testing.T) {
+		_, err := NewChain(time.Hour, NewFake(), NewFaviconEnricher()).Enrich(ctx, "Unknown", nil)
+		assert.ErrorIs(t, err, ErrNoData)
+	})
+
+	t.Run("caches results until the TTL expires", func(t *testing.T) {
+		fake := NewFake()
+		fake.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.com"}
+
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		chain := NewChain(time.Hour, fake)
+		chain.now = func() time.Time { return now }
+
+		_, err := chain.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		_, err = chain.Enrich(ctx, " acme ", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.EnrichCalls())
+
+		now = now.Add(2 * time.Hour)
+		_, err = chain.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, fake.EnrichCalls())
+	})
+
+	t.Run("caches misses", func(t *testing.T) {
+		fake := NewFake()
+		chain := NewChain(time.Hour, fake)
+
+		_, err := chain.Enrich(ctx, "Unknown", nil)
+		assert.ErrorIs(t, err, ErrNoData)
+		_, err = chain.Enrich(ctx, "Unknown", nil)
+		assert.ErrorIs(t, err, ErrNoData)
+		assert.Equal(t, 1, fake.EnrichCalls())
+	})
+
+	t.Run("doesn't cache lookups where a provider failed", func(t *testing.T) {
+		broken := NewFake()
+		broken.Err = errors.New("timeout")
+		chain := NewChain(time.Hour, broken)
+
+		_, err := chain.Enrich(ctx, "Acme", nil)
+		assert.ErrorIs(t, err, ErrNoData)
+
+		broken.Err = nil
+		broken.Data["acme"] = &models.CompanyEnrichmentData{Domain: "acme.com"}
+		data, err := chain.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "acme.com", data.Domain)
+		assert.Equal(t, 2, broken.EnrichCalls())
+	})
+
+	t.Run("evicts the least recently used entry when full", func(t *testing.T) {
+		fake := NewFake()
+		chain := NewChain(time.Hour, fake)
+		chain.maxEntries = 2
+
+		for _, name := range []string{"a", "b", "a", "c", "a"} {
+			_, _ = chain.Enrich(ctx, name, nil)
+		}
+		assert.Equal(t, 3, fake.EnrichCalls(), "a stays cached while b is evicted")
+		assert.Len(t, chain.cache, 2)
+
+		_, _ = chain.Enrich(ctx, "b", nil)
+		assert.Equal(t, 4, fake.EnrichCalls())
+	})
+
+	t.Run("sweeps expired entries", func(t *testing.T) {
+		fake := NewFake()
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		chain := NewChain(time.Hour, fake)
+		chain.now = func() time.Time { return now }
+
+		for i := 0; i < 5; i++ {
+			_, _ = chain.Enrich(ctx, fmt.Sprintf("company %d", i), nil)
+		}
+		require.Len(t, chain.cache, 5)
+
+		now = now.Add(2 * time.Hour)
+		_, _ = chain.Enrich(ctx, "other", nil)
+		assert.Len(t, chain.cache, 1)
+	})
+}
+
+func TestChainSuggest(t *testing.T) {
+	ctx := context.Background()
+
+	first := NewFake()
+	first.Suggestions["str"] = []*models.CompanySuggestion{{Name: "Stripe", Source: "suggestion"}}
+	second := NewFake()
+	second.Suggestions["str"] = []*models.CompanySuggestion{
+		{Name: "stripe", Source: "suggestion"},
+		{Name: "Strava", Source: "suggestion"},
+		{Name: "Streamlit", Source: "suggestion"},
+	}
+
+	chain := NewChain(time.Hour, first, second)
+
+	suggestions, err := chain.Suggest(ctx, "Str", 2)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "Stripe", suggestions[0].Name)
+	assert.Equal(t, "Strava", suggestions[1].Name)
+
+	_, err = chain.Suggest(ctx, "str", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, second.SuggestCalls())
+
+	suggestions, err = chain.Suggest(ctx, "str", 3)
+	require.NoError(t, err)
+	assert.Len(t, suggestions, 3, "a larger limit isn't served the shorter cached list")
+	assert.Equal(t, 2, second.SuggestCalls())
+
+	t.Run("doesn't cache results when a provider failed", func(t *testing.T) {
+		broken := NewFake()
+		broken.Err = errors.New("timeout")
+		chain := NewChain(time.Hour, broken)
+
+		suggestions, err := chain.Suggest(ctx, "str", 2)
+		require.NoError(t, err)
+		assert.Empty(t, suggestions)
+
+		broken.Err = nil
+		broken.Suggestions["str"] = []*models.CompanySuggestion{{Name: "Stripe", Source: "suggestion"}}
+		suggestions, err = chain.Suggest(ctx, "str", 2)
+		require.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+	})
+}
+
+func TestStaticEnricher(t *testing.T) {
+	ctx := context.Background()
+
+	enricher, err := NewBundledStaticEnricher()
+	require.NoError(t, err)
+
+	data, err := enricher.Enrich(ctx, "  stripe ", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "stripe.com", data.Domain)
+	assert.True(t, data.Verified)
+
+	_, err = enricher.Enrich(ctx, "Definitely Not A Company", nil)
+	assert.ErrorIs(t, err, ErrNoData)
+
+	suggestions, err := enricher.Suggest(ctx, "git", 10)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "GitHub", suggestions[0].Name)
+	assert.Equal(t, "GitLab", suggestions[1].Name)
+}
+
+func TestFaviconEnricher(t *testing.T) {
+	ctx := context.Background()
+	enricher := NewFaviconEnricher()
+
+	data, err := enricher.Enrich(ctx, "Acme", &models.CompanyEnrichmentData{Website: "https://www.Acme.com/careers"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme.com", data.Domain)
+	assert.Equal(t, "https://acme.com", data.Website)
+	assert.Equal(t, "https://www.google.com/s2/favicons?sz=128&domain=acme.com", data.LogoURL)
+
+	_, err = enricher.Enrich(ctx, "Acme", nil)
+	assert.ErrorIs(t, err, ErrNoData)
+
+	_, err = enricher.Enrich(ctx, "Acme", &models.CompanyEnrichmentData{Domain: "localhost"})
+	assert.ErrorIs(t, err, ErrNoData)
+}
+
+func TestClearoutEnricher(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "Acme":
+			fmt.Fprint(w, `{"data": [
+				{"name": "Acme Labs", "domain": "acmelabs.com"},
+				{"name": "acme", "domain": "acme.com", "logo_url": "https://logo/acme.png", "website": "https://acme.com"}
+			]}`)
+		case "Fuzzy":
+			fmt.Fprint(w, `{"data": [{"name": "Fuzzy Inc", "domain": "fuzzy.com"}]}`)
+		case "Down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"data": []}`)
+		}
+	}))
+	defer server.Close()
+
+	enricher := NewClearoutEnricher(server.Client())
+	enricher.baseURL = server.URL
+
+	t.Run("prefers an exact name match", func(t *testing.T) {
+		data, err := enricher.Enrich(ctx, "Acme", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "acme.com", data.Domain)
+		assert.True(t, data.Verified)
+	})
+
+	t.Run("falls back to the first result unverified", func(t *testing.T) {
+		data, err := enricher.Enrich(ctx, "Fuzzy", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "fuzzy.com", data.Domain)
+		assert.False(t, data.Verified)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		_, err := enricher.Enrich(ctx, "Nothing", nil)
+		assert.ErrorIs(t, err, ErrNoData)
+	})
+
+	t.Run("HTTP errors are reported", func(t *testing.T) {
+		_, err := enricher.Enrich(ctx, "Down", nil)
+		assert.ErrorContains(t, err, "503")
+	})
+
+	t.Run("suggestions respect the limit", func(t *testing.T) {
+		suggestions, err := enricher.Suggest(ctx, "Acme", 1)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "Acme Labs", suggestions[0].Name)
+		assert.Nil(t, suggestions[0].LogoURL)
+	})
+}
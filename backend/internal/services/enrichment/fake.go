@@ -0,0 +1,70 @@
+package enrichment
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"strings"
+	"sync"
+)
+
+// Fake is an in-memory CompanyEnricher for tests that must not touch the
+// network. Results are keyed by lower-cased company name or query.
+type Fake struct {
+	Data        map[string]*models.CompanyEnrichmentData
+	Suggestions map[string][]*models.CompanySuggestion
+	// Err, when set, is returned from every call.
+	Err error
+
+	mu           sync.Mutex
+	enrichCalls  int
+	suggestCalls int
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		Data:        make(map[string]*models.CompanyEnrichmentData),
+		Suggestions: make(map[string][]*models.CompanySuggestion),
+	}
+}
+
+func (f *Fake) Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.enrichCalls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	data, ok := f.Data[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, ErrNoData
+	}
+	return copyData(data), nil
+}
+
+func (f *Fake) Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.suggestCalls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	return truncateSuggestions(f.Suggestions[strings.ToLower(strings.TrimSpace(query))], limit), nil
+}
+
+// EnrichCalls reports how many times Enrich has been called.
+func (f *Fake) EnrichCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enrichCalls
+}
+
+// SuggestCalls reports how many times Suggest has been called.
+func (f *Fake) SuggestCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.suggestCalls
+}
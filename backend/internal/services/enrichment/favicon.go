@@ -0,0 +1,67 @@
+package enrichment
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const faviconServiceURL = "https://www.google.com/s2/favicons?sz=128&domain=%s"
+
+// FaviconEnricher derives a logo URL (and website, if missing) from a domain
+// that a higher-priority enricher already found. It makes no network calls
+// itself; the favicon service URL is resolved by the client.
+type FaviconEnricher struct {
+	urlTemplate string
+}
+
+func NewFaviconEnricher() *FaviconEnricher {
+	return &FaviconEnricher{urlTemplate: faviconServiceURL}
+}
+
+func (e *FaviconEnricher) Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error) {
+	if known == nil {
+		return nil, ErrNoData
+	}
+
+	domain := normalizeDomain(known.Domain)
+	if domain == "" {
+		domain = normalizeDomain(known.Website)
+	}
+	if domain == "" {
+		return nil, ErrNoData
+	}
+
+	return &models.CompanyEnrichmentData{
+		Domain:  domain,
+		Website: "https://" + domain,
+		LogoURL: fmt.Sprintf(e.urlTemplate, url.QueryEscape(domain)),
+	}, nil
+}
+
+// Suggest is a no-op; a favicon can't be looked up from a partial name.
+func (e *FaviconEnricher) Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error) {
+	return nil, nil
+}
+
+// normalizeDomain turns "https://www.Example.com/careers" or "example.com"
+// into "example.com".
+func normalizeDomain(value string) string {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return ""
+	}
+
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Hostname() == "" || !strings.Contains(parsed.Hostname(), ".") {
+		return ""
+	}
+
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
@@ -0,0 +1,111 @@
+package enrichment
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+//go:embed data/companies.json
+var bundledCompanies []byte
+
+// StaticCompany is one entry in a static company dataset. Website and LogoURL
+// are optional; the favicon enricher fills them in from Domain.
+type StaticCompany struct {
+	Name    string `json:"name"`
+	Domain  string `json:"domain"`
+	Website string `json:"website,omitempty"`
+	LogoURL string `json:"logo_url,omitempty"`
+}
+
+// StaticEnricher answers from an in-memory dataset. It needs no network access
+// and is tried first so well-known companies never hit an external API.
+type StaticEnricher struct {
+	byName    map[string]StaticCompany
+	companies []StaticCompany
+}
+
+func NewStaticEnricher(companies []StaticCompany) *StaticEnricher {
+	e := &StaticEnricher{
+		byName: make(map[string]StaticCompany, len(companies)),
+	}
+
+	for _, company := range companies {
+		key := normalizeKey(company.Name)
+		if key == "" {
+			continue
+		}
+		if _, exists := e.byName[key]; exists {
+			continue
+		}
+		e.byName[key] = company
+		e.companies = append(e.companies, company)
+	}
+
+	sort.Slice(e.companies, func(i, j int) bool {
+		return e.companies[i].Name < e.companies[j].Name
+	})
+
+	return e
+}
+
+// NewBundledStaticEnricher loads the dataset embedded in the binary.
+func NewBundledStaticEnricher() (*StaticEnricher, error) {
+	var companies []StaticCompany
+	if err := json.Unmarshal(bundledCompanies, &companies); err != nil {
+		return nil, err
+	}
+	return NewStaticEnricher(companies), nil
+}
+
+func (e *StaticEnricher) Enrich(ctx context.Context, name string, known *models.CompanyEnrichmentData) (*models.CompanyEnrichmentData, error) {
+	company, ok := e.byName[normalizeKey(name)]
+	if !ok {
+		return nil, ErrNoData
+	}
+
+	return &models.CompanyEnrichmentData{
+		Domain:   company.Domain,
+		Website:  company.Website,
+		LogoURL:  company.LogoURL,
+		Verified: true,
+	}, nil
+}
+
+func (e *StaticEnricher) Suggest(ctx context.Context, query string, limit int) ([]*models.CompanySuggestion, error) {
+	prefix := normalizeKey(query)
+	if prefix == "" {
+		return nil, nil
+	}
+
+	var suggestions []*models.CompanySuggestion
+	for _, company := range e.companies {
+		if len(suggestions) >= limit {
+			break
+		}
+		if !strings.HasPrefix(normalizeKey(company.Name), prefix) {
+			continue
+		}
+
+		company := company
+		suggestion := &models.CompanySuggestion{
+			Name:   company.Name,
+			Source: "suggestion",
+		}
+		if company.Domain != "" {
+			suggestion.Domain = &company.Domain
+		}
+		if company.Website != "" {
+			suggestion.Website = &company.Website
+		}
+		if company.LogoURL != "" {
+			suggestion.LogoURL = &company.LogoURL
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
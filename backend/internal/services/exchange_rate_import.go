@@ -0,0 +1,164 @@
+package services
+
+import (
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/pkg/database"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// exchangeRateRecord is the on-disk shape of a rate in both CSV and JSON files:
+//
+//	currency,rate_to_usd,effective_date,source
+//	EUR,1.08,2025-01-01,ECB
+type exchangeRateRecord struct {
+	Currency      string  `json:"currency"`
+	RateToUSD     float64 `json:"rate_to_usd"`
+	EffectiveDate string  `json:"effective_date"`
+	Source        string  `json:"source"`
+}
+
+// ImportExchangeRatesFile loads a CSV or JSON rates file (picked by extension)
+// into the exchange_rates table. Existing rates for the same currency and
+// effective date are overwritten, so re-importing a file is safe.
+func ImportExchangeRatesFile(database *database.Database, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("opening exchange rates file: %w", err)
+	}
+	defer f.Close()
+
+	var rates []models.ExchangeRate
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rates, err = ParseExchangeRatesCSV(f)
+	case ".json":
+		rates, err = ParseExchangeRatesJSON(f)
+	default:
+		return 0, fmt.Errorf("unsupported exchange rates file type: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := repository.NewExchangeRateRepository(database).UpsertRates(rates)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("Imported %d exchange rates from %s", count, path)
+	return count, nil
+}
+
+func ParseExchangeRatesCSV(r io.Reader) ([]models.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading exchange rates CSV: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("exchange rates CSV is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"currency", "rate_to_usd", "effective_date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("exchange rates CSV missing %q column", required)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	rates := make([]models.ExchangeRate, 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		rate, err := strconv.ParseFloat(field(row, "rate_to_usd"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate_to_usd %q", line+2, field(row, "rate_to_usd"))
+		}
+
+		record := exchangeRateRecord{
+			Currency:      field(row, "currency"),
+			RateToUSD:     rate,
+			EffectiveDate: field(row, "effective_date"),
+			Source:        field(row, "source"),
+		}
+
+		parsed, err := record.toModel()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+		rates = append(rates, *parsed)
+	}
+
+	return rates, nil
+}
+
+func ParseExchangeRatesJSON(r io.Reader) ([]models.ExchangeRate, error) {
+	var records []exchangeRateRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("reading exchange rates JSON: %w", err)
+	}
+
+	rates := make([]models.ExchangeRate, 0, len(records))
+	for i, record := range records {
+		parsed, err := record.toModel()
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		rates = append(rates, *parsed)
+	}
+
+	return rates, nil
+}
+
+func (r exchangeRateRecord) toModel() (*models.ExchangeRate, error) {
+	currency := strings.ToUpper(strings.TrimSpace(r.Currency))
+	if !currencyCodeRegex.MatchString(currency) {
+		return nil, fmt.Errorf("invalid currency code %q", r.Currency)
+	}
+
+	if r.RateToUSD <= 0 {
+		return nil, fmt.Errorf("rate_to_usd for %s must be positive", currency)
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", strings.TrimSpace(r.EffectiveDate))
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective_date %q, expected YYYY-MM-DD", r.EffectiveDate)
+	}
+
+	rate := &models.ExchangeRate{
+		Currency:      currency,
+		RateToUSD:     r.RateToUSD,
+		EffectiveDate: effectiveDate,
+	}
+	if source := strings.TrimSpace(r.Source); source != "" {
+		rate.Source = &source
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExchangeRatesCSV(t *testing.T) {
+	t.Run("parses rates with optional source", func(t *testing.T) {
+		input := "currency,rate_to_usd,effective_date,source\n" +
+			"eur, 1.08, 2025-01-01, ECB\n" +
+			"GBP,1.27,2025-01-01\n"
+
+		rates, err := ParseExchangeRatesCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, "EUR", rates[0].Currency)
+		assert.Equal(t, 1.08, rates[0].RateToUSD)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), rates[0].EffectiveDate)
+		require.NotNil(t, rates[0].Source)
+		assert.Equal(t, "ECB", *rates[0].Source)
+
+		assert.Equal(t, "GBP", rates[1].Currency)
+		assert.Nil(t, rates[1].Source)
+	})
+
+	t.Run("columns can be in any order", func(t *testing.T) {
+		input := "effective_date,currency,rate_to_usd\n2025-02-01,JPY,0.0064\n"
+
+		rates, err := ParseExchangeRatesCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rates, 1)
+		assert.Equal(t, "JPY", rates[0].Currency)
+		assert.Equal(t, 0.0064, rates[0].RateToUSD)
+	})
+
+	t.Run("rejects missing columns", func(t *testing.T) {
+		_, err := ParseExchangeRatesCSV(strings.NewReader("currency,rate\nEUR,1.08\n"))
+		assert.ErrorContains(t, err, "rate_to_usd")
+	})
+
+	t.Run("reports the failing line", func(t *testing.T) {
+		input := "currency,rate_to_usd,effective_date\nEUR,1.08,2025-01-01\nEURO,1.08,2025-01-01\n"
+
+		_, err := ParseExchangeRatesCSV(strings.NewReader(input))
+		assert.ErrorContains(t, err, "line 3")
+	})
+
+	t.Run("rejects non-positive rates", func(t *testing.T) {
+		input := "currency,rate_to_usd,effective_date\nEUR,0,2025-01-01\n"
+
+		_, err := ParseExchangeRatesCSV(strings.NewReader(input))
+		assert.ErrorContains(t, err, "must be positive")
+	})
+}
+
+func TestParseExchangeRatesJSON(t *testing.T) {
+	t.Run("parses rates", func(t *testing.T) {
+		input := `[
+			{"currency": "CAD", "rate_to_usd": 0.74, "effective_date": "2025-03-01", "source": "manual"},
+			{"currency": "AUD", "rate_to_usd": 0.66, "effective_date": "2025-03-01"}
+		]`
+
+		rates, err := ParseExchangeRatesJSON(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+		assert.Equal(t, "CAD", rates[0].Currency)
+		assert.Equal(t, 0.74, rates[0].RateToUSD)
+		assert.Equal(t, "AUD", rates[1].Currency)
+	})
+
+	t.Run("rejects bad dates", func(t *testing.T) {
+		input := `[{"currency": "CAD", "rate_to_usd": 0.74, "effective_date": "03/01/2025"}]`
+
+		_, err := ParseExchangeRatesJSON(strings.NewReader(input))
+		assert.ErrorContains(t, err, "effective_date")
+	})
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/clamav"
+	s3service "ditto-backend/internal/services/s3"
+	"ditto-backend/pkg/database"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// fileScanTimeout bounds downloading and scanning one file
+	fileScanTimeout = 2 * time.Minute
+	// fileScanBatchSize caps the number of pending files retried per run
+	fileScanBatchSize = 50
+	// maxFileScanAttempts is how many failed scans a file gets before it is
+	// marked failed
+	maxFileScanAttempts = 10
+)
+
+// errObjectMissing means a pending file's object is no longer in storage.
+var errObjectMissing = errors.New("object not found in storage")
+
+// FileScanner runs uploaded files past a malware scanner. Files start out
+// pending; a clean verdict makes them downloadable, an infected one deletes
+// the object and tells the user. Files whose scan failed, e.g. because clamd
+// was down, stay pending and are retried by the background sweep until they
+// have failed maxFileScanAttempts times. Files that can never be scanned,
+// because they are too large for clamd or gone from storage, are marked
+// failed straight away.
+type FileScanner struct {
+	fileRepo        *repository.FileRepository
+	notificationSvc *NotificationService
+	storage         s3service.S3ServiceInterface
+	scanner         clamav.Scanner
+	inFlight        sync.WaitGroup
+	ticker          *time.Ticker
+	done            chan bool
+}
+
+func NewFileScanner(database *database.Database, storage s3service.S3ServiceInterface, scanner clamav.Scanner) *FileScanner {
+	return &FileScanner{
+		fileRepo:        repository.NewFileRepository(database),
+		notificationSvc: NewNotificationService(database),
+		storage:         storage,
+		scanner:         scanner,
+		done:            make(chan bool),
+	}
+}
+
+// Submit scans a newly confirmed file in the background.
+func (s *FileScanner) Submit(file *models.File) {
+	s.inFlight.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), fileScanTimeout)
+		defer cancel()
+
+		s.scanAndRecord(ctx, file)
+	}()
+}
+
+// Wait blocks until every submitted scan has finished.
+func (s *FileScanner) Wait() {
+	s.inFlight.Wait()
+}
+
+// Scan streams a file from storage to the scanner and records the verdict.
+func (s *FileScanner) Scan(ctx context.Context, file *models.File) error {
+	body, err := s.storage.GetObject(ctx, file.S3Key)
+	if err != nil {
+		if exists, headErr := s.storage.HeadObject(ctx, file.S3Key); headErr == nil && !exists {
+			return errObjectMissing
+		}
+		return fmt.Errorf("fetching file: %w", err)
+	}
+	defer body.Close()
+
+	result, err := s.scanner.Scan(ctx, body)
+	if err != nil {
+		return fmt.Errorf("scanning file: %w", err)
+	}
+
+	if !result.Infected {
+		return s.fileRepo.UpdateScanResult(file.ID, models.FileScanClean, nil)
+	}
+
+	log.Printf("Malware %s found in file %s, deleting it", result.Signature, file.ID)
+
+	if err := s.storage.DeleteObject(ctx, file.S3Key); err != nil {
+		return fmt.Errorf("deleting infected file: %w", err)
+	}
+
+	if err := s.fileRepo.UpdateScanResult(file.ID, models.FileScanInfected, &result.Signature); err != nil {
+		return err
+	}
+
+	if _, err := s.notificationSvc.CreateFileInfectedAlert(file, result.Signature); err != nil {
+		return fmt.Errorf("notifying user: %w", err)
+	}
+
+	return nil
+}
+
+// Start retries scans of files left pending for longer than interval.
+func (s *FileScanner) Start(interval time.Duration) {
+	s.ticker = time.NewTicker(interval)
+	go func() {
+		s.processPendingScans(interval)
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-s.ticker.C:
+				s.processPendingScans(interval)
+			}
+		}
+	}()
+	log.Printf("File scanner started with %v interval", interval)
+}
+
+func (s *FileScanner) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("File scanner stopped")
+}
+
+func (s *FileScanner) processPendingScans(olderThan time.Duration) {
+	files, err := s.fileRepo.GetPendingScans(time.Now().Add(-olderThan), fileScanBatchSize)
+	if err != nil {
+		log.Printf("Error fetching files pending a scan: %v", err)
+		return
+	}
+
+	for _, file := range files {
+		ctx, cancel := context.WithTimeout(context.Background(), fileScanTimeout)
+		s.scanAndRecord(ctx, file)
+		cancel()
+	}
+}
+
+// scanAndRecord scans a file and, when that fails, records the failure so
+// that files which can't be scanned stop being retried.
+func (s *FileScanner) scanAndRecord(ctx context.Context, file *models.File) {
+	err := s.Scan(ctx, file)
+	if err == nil {
+		return
+	}
+	log.Printf("Error scanning file %s: %v", file.ID, err)
+
+	if errors.Is(err, errObjectMissing) || errors.Is(err, clamav.ErrSizeLimit) {
+		if err := s.fileRepo.UpdateScanResult(file.ID, models.FileScanFailed, nil); err != nil {
+			log.Printf("Error marking file %s unscannable: %v", file.ID, err)
+		}
+		return
+	}
+
+	status, err := s.fileRepo.RecordScanFailure(file.ID, maxFileScanAttempts)
+	if err != nil {
+		log.Printf("Error recording failed scan of file %s: %v", file.ID, err)
+		return
+	}
+	if status == models.FileScanFailed {
+		log.Printf("Giving up scanning file %s after %d attempts", file.ID, maxFileScanAttempts)
+	}
+}
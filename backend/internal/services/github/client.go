@@ -0,0 +1,172 @@
+// Package github checks repositories submitted for take-home assessments
+// through the GitHub REST API: that the repository exists, whether it is
+// private, and which commit was current when it was submitted.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const apiBaseURL = "https://api.github.com"
+
+var (
+	// ErrNotFound is returned for repositories and refs that don't exist or
+	// that the configured token can't see. GitHub answers 404 for both.
+	ErrNotFound = errors.New("repository not found or not visible")
+	// ErrRateLimited is returned once the API rate limit is used up.
+	ErrRateLimited = errors.New("GitHub API rate limit reached")
+)
+
+// Repository is what GitHub reports about a repository.
+type Repository struct {
+	Owner         string `json:"owner"`
+	Name          string `json:"name"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+}
+
+// Client reads repositories from GitHub.
+type Client interface {
+	// GetRepository returns the named repository.
+	GetRepository(ctx context.Context, owner, name string) (*Repository, error)
+	// CommitSHA resolves a branch, tag or commit to a full commit SHA.
+	CommitSHA(ctx context.Context, owner, name, ref string) (string, error)
+	// Tarball streams a gzipped tarball of the repository at a commit. The
+	// caller closes it.
+	Tarball(ctx context.Context, owner, name, sha string) (io.ReadCloser, error)
+}
+
+// APIClient is a Client backed by the GitHub REST API. Without a token it
+// only sees public repositories, at 60 requests an hour.
+type APIClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+func NewAPIClient(client *http.Client, token string) *APIClient {
+	return &APIClient{
+		client:  client,
+		baseURL: apiBaseURL,
+		token:   token,
+	}
+}
+
+type apiRepository struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+}
+
+func (c *APIClient) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
+	resp, err := c.get(ctx, repoPath(owner, name), "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var repo apiRepository
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return nil, fmt.Errorf("decode repository: %w", err)
+	}
+
+	return &Repository{
+		Owner:         repo.Owner.Login,
+		Name:          repo.Name,
+		Private:       repo.Private,
+		DefaultBranch: repo.DefaultBranch,
+		HTMLURL:       repo.HTMLURL,
+	}, nil
+}
+
+func (c *APIClient) CommitSHA(ctx context.Context, owner, name, ref string) (string, error) {
+	// The sha media type answers with just the SHA as plain text
+	resp, err := c.get(ctx, repoPath(owner, name)+"/commits/"+url.PathEscape(ref), "application/vnd.github.sha")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 128))
+	if err != nil {
+		return "", fmt.Errorf("read commit: %w", err)
+	}
+
+	sha := strings.TrimSpace(string(body))
+	if !isSHA(sha) {
+		return "", fmt.Errorf("unexpected commit response %q", sha)
+	}
+	return sha, nil
+}
+
+func (c *APIClient) Tarball(ctx context.Context, owner, name, sha string) (io.ReadCloser, error) {
+	// GitHub redirects to codeload.github.com, which the http.Client follows
+	resp, err := c.get(ctx, repoPath(owner, name)+"/tarball/"+url.PathEscape(sha), "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// get sends an authenticated GET and turns error statuses into errors. On
+// success the caller closes the body.
+func (c *APIClient) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "ditto-backend")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github request: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return resp, nil
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusUnprocessableEntity:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		resp.Body.Close()
+		return nil, ErrRateLimited
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("github returned %s for %s", resp.Status, path)
+	}
+}
+
+func repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+func isSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSHA = "3f786850e387550fdab836ed7e6dc881de23001b"
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *APIClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewAPIClient(server.Client(), "secret-token")
+	client.baseURL = server.URL
+	return client
+}
+
+func TestAPIClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetRepository", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/octo/take-home", r.URL.Path)
+			assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+			_, _ = io.WriteString(w, `{"name":"take-home","owner":{"login":"octo"},"private":true,"default_branch":"main","html_url":"https://github.com/octo/take-home"}`)
+		})
+
+		repo, err := client.GetRepository(ctx, "octo", "take-home")
+		require.NoError(t, err)
+		assert.Equal(t, &Repository{Owner: "octo", Name: "take-home", Private: true, DefaultBranch: "main", HTMLURL: "https://github.com/octo/take-home"}, repo)
+	})
+
+	t.Run("CommitSHA", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/octo/take-home/commits/main", r.URL.Path)
+			assert.Equal(t, "application/vnd.github.sha", r.Header.Get("Accept"))
+			_, _ = io.WriteString(w, testSHA)
+		})
+
+		sha, err := client.CommitSHA(ctx, "octo", "take-home", "main")
+		require.NoError(t, err)
+		assert.Equal(t, testSHA, sha)
+	})
+
+	t.Run("Tarball", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/octo/take-home/tarball/"+testSHA, r.URL.Path)
+			_, _ = w.Write([]byte{0x1f, 0x8b})
+		})
+
+		body, err := client.Tarball(ctx, "octo", "take-home", testSHA)
+		require.NoError(t, err)
+		defer body.Close()
+
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x1f, 0x8b}, data)
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		})
+
+		_, err := client.GetRepository(ctx, "octo", "gone")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+		})
+
+		_, err := client.GetRepository(ctx, "octo", "take-home")
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := client.GetRepository(ctx, "octo", "take-home")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+}
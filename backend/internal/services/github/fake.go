@@ -0,0 +1,111 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Fake is an in-memory Client for tests that must not touch the network.
+// Repositories are keyed by lower-cased "owner/name".
+type Fake struct {
+	Repositories map[string]*Repository
+	// Refs maps "owner/name@ref" to a commit SHA.
+	Refs map[string]string
+	// Tarballs maps a commit SHA to the archive returned for it.
+	Tarballs map[string][]byte
+	// Err, when set, is returned from every call.
+	Err error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		Repositories: make(map[string]*Repository),
+		Refs:         make(map[string]string),
+		Tarballs:     make(map[string][]byte),
+	}
+}
+
+// AddRepository registers a repository with its default branch at sha.
+func (f *Fake) AddRepository(repo *Repository, sha string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Repositories[repoKey(repo.Owner, repo.Name)] = repo
+	f.Refs[repoKey(repo.Owner, repo.Name)+"@"+repo.DefaultBranch] = sha
+}
+
+// SetRef points a branch or tag at sha, as a push would.
+func (f *Fake) SetRef(owner, name, ref, sha string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Refs[repoKey(owner, name)+"@"+ref] = sha
+}
+
+func (f *Fake) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	repo, ok := f.Repositories[repoKey(owner, name)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *repo
+	return &copied, nil
+}
+
+func (f *Fake) CommitSHA(ctx context.Context, owner, name, ref string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.Err != nil {
+		return "", f.Err
+	}
+
+	if sha, ok := f.Refs[repoKey(owner, name)+"@"+ref]; ok {
+		return sha, nil
+	}
+	if isSHA(ref) {
+		return ref, nil
+	}
+	return "", ErrNotFound
+}
+
+func (f *Fake) Tarball(ctx context.Context, owner, name, sha string) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	data, ok := f.Tarballs[sha]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Calls reports how many API calls have been made.
+func (f *Fake) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func repoKey(owner, name string) string {
+	return strings.ToLower(owner + "/" + name)
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidURL is returned for URLs that don't point at a GitHub repository.
+var ErrInvalidURL = errors.New("not a GitHub repository URL")
+
+// RepoRef names a repository and, optionally, the branch, tag or commit the
+// URL pointed at.
+type RepoRef struct {
+	Owner string
+	Name  string
+	Ref   string
+}
+
+// ParseURL accepts the forms people paste into a submission form:
+// https://github.com/owner/repo, with or without .git or a trailing slash,
+// and https://github.com/owner/repo/tree/<ref>.
+func ParseURL(raw string) (*RepoRef, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "github.com" && host != "www.github.com" {
+		return nil, ErrInvalidURL
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, ErrInvalidURL
+	}
+
+	ref := &RepoRef{
+		Owner: parts[0],
+		Name:  strings.TrimSuffix(parts[1], ".git"),
+	}
+	if ref.Name == "" {
+		return nil, ErrInvalidURL
+	}
+
+	switch {
+	case len(parts) == 2:
+	case len(parts) >= 4 && (parts[2] == "tree" || parts[2] == "commit"):
+		// Branch names can contain slashes
+		ref.Ref = strings.Join(parts[3:], "/")
+	default:
+		return nil, ErrInvalidURL
+	}
+
+	return ref, nil
+}
+
+// Snapshot is the state of a submitted repository when it was checked.
+type Snapshot struct {
+	Repository *Repository
+	// Ref is the branch, tag or commit the SHA was resolved from: the one in
+	// the URL, or the default branch.
+	Ref        string
+	CommitSHA  string
+	VerifiedAt time.Time
+}
+
+// Verify checks that the repository behind rawURL exists and records the
+// commit it is at. It returns ErrInvalidURL for URLs that aren't GitHub
+// repositories and ErrNotFound when the repository or ref can't be seen.
+func Verify(ctx context.Context, client Client, rawURL string) (*Snapshot, error) {
+	ref, err := ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := client.GetRepository(ctx, ref.Owner, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Repository: repo,
+		Ref:        ref.Ref,
+	}
+	if snapshot.Ref == "" {
+		snapshot.Ref = repo.DefaultBranch
+	}
+
+	snapshot.CommitSHA, err = client.CommitSHA(ctx, repo.Owner, repo.Name, snapshot.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", snapshot.Ref, err)
+	}
+	snapshot.VerifiedAt = time.Now()
+
+	return snapshot, nil
+}
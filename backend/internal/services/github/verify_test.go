@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want *RepoRef
+	}{
+		{"https://github.com/octo/take-home", &RepoRef{Owner: "octo", Name: "take-home"}},
+		{"https://github.com/octo/take-home.git", &RepoRef{Owner: "octo", Name: "take-home"}},
+		{"github.com/octo/take-home/", &RepoRef{Owner: "octo", Name: "take-home"}},
+		{"https://www.github.com/octo/take-home/tree/feature/api", &RepoRef{Owner: "octo", Name: "take-home", Ref: "feature/api"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseURL(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, raw := range []string{
+		"https://gitlab.com/octo/take-home",
+		"https://github.com/octo",
+		"https://github.com/octo/take-home/issues/1",
+		"not a url",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			_, err := ParseURL(raw)
+			assert.ErrorIs(t, err, ErrInvalidURL)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+	fake.AddRepository(&Repository{Owner: "octo", Name: "take-home", DefaultBranch: "main", Private: true}, testSHA)
+
+	t.Run("resolves the default branch", func(t *testing.T) {
+		snapshot, err := Verify(ctx, fake, "https://github.com/octo/take-home")
+		require.NoError(t, err)
+		assert.Equal(t, "main", snapshot.Ref)
+		assert.Equal(t, testSHA, snapshot.CommitSHA)
+		assert.True(t, snapshot.Repository.Private)
+		assert.False(t, snapshot.VerifiedAt.IsZero())
+	})
+
+	t.Run("resolves the ref in the URL", func(t *testing.T) {
+		fake.SetRef("octo", "take-home", "solution", "0000000000000000000000000000000000000001")
+
+		snapshot, err := Verify(ctx, fake, "https://github.com/octo/take-home/tree/solution")
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000000000000000000000000000001", snapshot.CommitSHA)
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		_, err := Verify(ctx, fake, "https://github.com/octo/nothing")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("missing branch", func(t *testing.T) {
+		_, err := Verify(ctx, fake, "https://github.com/octo/take-home/tree/nope")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("API errors are passed through", func(t *testing.T) {
+		broken := NewFake()
+		broken.Err = errors.New("boom")
+
+		_, err := Verify(ctx, broken, "https://github.com/octo/take-home")
+		assert.EqualError(t, err, "boom")
+	})
+}
@@ -0,0 +1,483 @@
+// Package ics reads calendar invitations (RFC 5545 iCalendar files) well
+// enough to turn an interview invite into an interview: when it is, who
+// organised it, who is attending and where or how to join.
+package ics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotCalendar = errors.New("not an iCalendar file")
+	ErrNoEvents    = errors.New("calendar has no events")
+)
+
+// Calendar is a parsed .ics file. Method is e.g. REQUEST or CANCEL.
+type Calendar struct {
+	Method string
+	Events []Event
+}
+
+// Event is one VEVENT. Start and End are in the event's own time zone,
+// named by TimeZone; all-day events start at midnight UTC.
+type Event struct {
+	UID           string
+	Summary       string
+	Description   string
+	Location      string
+	ConferenceURL string
+	Status        string
+	Start         time.Time
+	End           time.Time
+	TimeZone      string
+	AllDay        bool
+	Organizer     *Person
+	Attendees     []Person
+
+	// duration holds DURATION until End can be worked out from DTSTART
+	duration time.Duration
+}
+
+// Duration is how long the event lasts.
+func (e *Event) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Cancelled reports whether the invite withdraws the event.
+func (e *Event) Cancelled() bool {
+	return strings.EqualFold(e.Status, "CANCELLED")
+}
+
+// Person is an organizer or attendee. Resource is set for rooms and
+// equipment booked as attendees.
+type Person struct {
+	Name     string
+	Email    string
+	Role     string
+	Resource bool
+}
+
+// DisplayName is the person's name, or the local part of their email when
+// the invite doesn't carry one.
+func (p Person) DisplayName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	if at := strings.Index(p.Email, "@"); at > 0 {
+		return p.Email[:at]
+	}
+	return p.Email
+}
+
+// Domain is the host part of the person's email address.
+func (p Person) Domain() string {
+	if at := strings.LastIndex(p.Email, "@"); at >= 0 {
+		return strings.ToLower(p.Email[at+1:])
+	}
+	return ""
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads an iCalendar file. Times without a zone ("floating" times)
+// are read in floatingLoc.
+func Parse(data []byte, floatingLoc *time.Location) (*Calendar, error) {
+	lines := unfold(data)
+	if len(lines) == 0 || !strings.EqualFold(strings.TrimSpace(lines[0]), "BEGIN:VCALENDAR") {
+		return nil, ErrNotCalendar
+	}
+
+	cal := &Calendar{}
+	var stack []string
+	var event *Event
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			if component == "VEVENT" && len(stack) == 2 {
+				event = &Event{}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("unexpected END:%s", prop.value)
+			}
+			if stack[len(stack)-1] == "VEVENT" && event != nil && len(stack) == 2 {
+				if err := finishEvent(event); err != nil {
+					return nil, err
+				}
+				cal.Events = append(cal.Events, *event)
+				event = nil
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		switch {
+		case len(stack) == 1 && prop.name == "METHOD":
+			cal.Method = strings.ToUpper(prop.value)
+		case len(stack) == 2 && event != nil:
+			if err := applyProperty(event, prop, floatingLoc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(cal.Events) == 0 {
+		return nil, ErrNoEvents
+	}
+	return cal, nil
+}
+
+// unfold joins continuation lines (those starting with a space or tab) onto
+// the line before them.
+func unfold(data []byte) []string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseLine splits a content line into name, parameters and value. The
+// value starts at the first colon outside a quoted parameter value.
+func parseLine(line string) (property, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("malformed line %q", truncate(line, 40))
+	}
+
+	prop := property{params: map[string]string{}, value: line[colon+1:]}
+	head := splitUnquoted(line[:colon], ';')
+	prop.name = strings.ToUpper(head[0])
+	for _, param := range head[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func applyProperty(event *Event, prop property, floatingLoc *time.Location) error {
+	switch prop.name {
+	case "UID":
+		event.UID = prop.value
+	case "SUMMARY":
+		event.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		event.Description = unescapeText(prop.value)
+	case "LOCATION":
+		event.Location = unescapeText(prop.value)
+	case "STATUS":
+		event.Status = strings.ToUpper(prop.value)
+	case "DTSTART":
+		start, allDay, zone, err := parseDateTime(prop, floatingLoc)
+		if err != nil {
+			return err
+		}
+		event.Start, event.AllDay, event.TimeZone = start, allDay, zone
+	case "DTEND":
+		end, _, _, err := parseDateTime(prop, floatingLoc)
+		if err != nil {
+			return err
+		}
+		event.End = end
+	case "DURATION":
+		d, err := parseDuration(prop.value)
+		if err != nil {
+			return err
+		}
+		event.duration = d
+	case "ORGANIZER":
+		person := parsePerson(prop)
+		event.Organizer = &person
+	case "ATTENDEE":
+		event.Attendees = append(event.Attendees, parsePerson(prop))
+	case "X-GOOGLE-CONFERENCE", "X-MICROSOFT-ONLINEMEETINGCONFLINK", "X-MICROSOFT-SKYPETEAMSMEETINGURL", "CONFERENCE":
+		if event.ConferenceURL == "" && strings.HasPrefix(strings.ToLower(prop.value), "http") {
+			event.ConferenceURL = prop.value
+		}
+	case "URL":
+		if event.ConferenceURL == "" && isConferenceURL(prop.value) {
+			event.ConferenceURL = prop.value
+		}
+	}
+	return nil
+}
+
+func finishEvent(event *Event) error {
+	if event.Start.IsZero() {
+		return fmt.Errorf("event %q has no start time", event.Summary)
+	}
+	if event.End.IsZero() {
+		switch {
+		case event.duration != 0:
+			event.End = event.Start.Add(event.duration)
+		case event.AllDay:
+			event.End = event.Start.AddDate(0, 0, 1)
+		default:
+			event.End = event.Start
+		}
+	}
+	if event.End.Before(event.Start) {
+		return fmt.Errorf("event %q ends before it starts", event.Summary)
+	}
+	if event.ConferenceURL == "" {
+		event.ConferenceURL = findConferenceURL(event.Location + "\n" + event.Description)
+	}
+	return nil
+}
+
+func parsePerson(prop property) Person {
+	person := Person{
+		Name: strings.TrimSpace(prop.params["CN"]),
+		Role: strings.ToUpper(prop.params["ROLE"]),
+	}
+	value := strings.TrimSpace(prop.value)
+	if len(value) >= len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		value = value[len("mailto:"):]
+	}
+	person.Email = strings.ToLower(value)
+
+	switch strings.ToUpper(prop.params["CUTYPE"]) {
+	case "ROOM", "RESOURCE":
+		person.Resource = true
+	}
+	return person
+}
+
+// parseDateTime reads DATE and DATE-TIME values. It returns the zone name
+// the time is expressed in.
+func parseDateTime(prop property, floatingLoc *time.Location) (time.Time, bool, string, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false, "", fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, "UTC", nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, "", fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, "UTC", nil
+	}
+
+	loc := floatingLoc
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if resolved, ok := LoadLocation(tzid); ok {
+			loc = resolved
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, "", fmt.Errorf("invalid date-time %q", value)
+	}
+	return t, false, loc.String(), nil
+}
+
+// windowsZones maps the Windows time zone names Outlook writes into TZID to
+// IANA names.
+var windowsZones = map[string]string{
+	"Dateline Standard Time":          "Etc/GMT+12",
+	"Hawaiian Standard Time":          "Pacific/Honolulu",
+	"Alaskan Standard Time":           "America/Anchorage",
+	"Pacific Standard Time":           "America/Los_Angeles",
+	"Mountain Standard Time":          "America/Denver",
+	"US Mountain Standard Time":       "America/Phoenix",
+	"Central Standard Time":           "America/Chicago",
+	"Eastern Standard Time":           "America/New_York",
+	"Atlantic Standard Time":          "America/Halifax",
+	"E. South America Standard Time":  "America/Sao_Paulo",
+	"UTC":                             "UTC",
+	"GMT Standard Time":               "Europe/London",
+	"W. Europe Standard Time":         "Europe/Berlin",
+	"Romance Standard Time":           "Europe/Paris",
+	"Central Europe Standard Time":    "Europe/Budapest",
+	"Central European Standard Time":  "Europe/Warsaw",
+	"E. Europe Standard Time":         "Europe/Chisinau",
+	"FLE Standard Time":               "Europe/Kiev",
+	"GTB Standard Time":               "Europe/Bucharest",
+	"Israel Standard Time":            "Asia/Jerusalem",
+	"Russian Standard Time":           "Europe/Moscow",
+	"Arabian Standard Time":           "Asia/Dubai",
+	"India Standard Time":             "Asia/Kolkata",
+	"China Standard Time":             "Asia/Shanghai",
+	"Singapore Standard Time":         "Asia/Singapore",
+	"Tokyo Standard Time":             "Asia/Tokyo",
+	"Korea Standard Time":             "Asia/Seoul",
+	"AUS Eastern Standard Time":       "Australia/Sydney",
+	"New Zealand Standard Time":       "Pacific/Auckland",
+	"Canada Central Standard Time":    "America/Regina",
+	"Pacific Standard Time (Mexico)":  "America/Tijuana",
+	"Central Standard Time (Mexico)":  "America/Mexico_City",
+	"South Africa Standard Time":      "Africa/Johannesburg",
+	"W. Central Africa Standard Time": "Africa/Lagos",
+}
+
+// LoadLocation resolves a TZID: an IANA name, a Windows zone name, or an
+// IANA name behind a vendor prefix such as "/mozilla.org/20050126_1/".
+func LoadLocation(tzid string) (*time.Location, bool) {
+	tzid = strings.Trim(strings.TrimSpace(tzid), `"`)
+	if iana, ok := windowsZones[tzid]; ok {
+		tzid = iana
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc, true
+	}
+	parts := strings.Split(tzid, "/")
+	for i := 1; i < len(parts)-1; i++ {
+		if loc, err := time.LoadLocation(strings.Join(parts[i:], "/")); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration reads an RFC 5545 DURATION such as PT1H30M or P1D.
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if m == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+func unescapeText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+var (
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+
+	// conferenceHosts are video call providers whose links are worth
+	// pulling out of a location or description.
+	conferenceHosts = []string{
+		"zoom.us", "meet.google.com", "teams.microsoft.com", "teams.live.com",
+		"webex.com", "whereby.com", "chime.aws", "gotomeeting.com", "bluejeans.com",
+		"meet.jit.si", "around.co",
+	}
+)
+
+func isConferenceURL(value string) bool {
+	lower := strings.ToLower(value)
+	if !strings.HasPrefix(lower, "http") {
+		return false
+	}
+	host := lower[strings.Index(lower, "//")+2:]
+	if slash := strings.IndexAny(host, "/?#"); slash >= 0 {
+		host = host[:slash]
+	}
+	for _, known := range conferenceHosts {
+		if host == known || strings.HasSuffix(host, "."+known) {
+			return true
+		}
+	}
+	return false
+}
+
+func findConferenceURL(text string) string {
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		match = strings.TrimRight(match, ".,;>")
+		if isConferenceURL(match) {
+			return match
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
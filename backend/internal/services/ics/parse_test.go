@@ -0,0 +1,136 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const googleInvite = "BEGIN:VCALENDAR\r\n" +
+	"PRODID:-//Google Inc//Google Calendar 70.9054//EN\r\n" +
+	"VERSION:2.0\r\n" +
+	"METHOD:REQUEST\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=America/New_York:20260310T140000\r\n" +
+	"DTEND;TZID=America/New_York:20260310T144500\r\n" +
+	"UID:abc123@google.com\r\n" +
+	"ORGANIZER;CN=Jane Recruiter:mailto:jane@talent.acme.com\r\n" +
+	"ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;CN=Jane Re\r\n" +
+	" cruiter:mailto:jane@talent.acme.com\r\n" +
+	"ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;CN=\"Smith, Bob\":mailto:Bob@acme.com\r\n" +
+	"ATTENDEE;CUTYPE=ROOM;CN=Board Room:mailto:room@resource.acme.com\r\n" +
+	"ATTENDEE;CUTYPE=INDIVIDUAL;CN=me@example.com:mailto:me@example.com\r\n" +
+	"X-GOOGLE-CONFERENCE:https://meet.google.com/abc-defg-hij\r\n" +
+	"SUMMARY:Technical Interview - Acme\r\n" +
+	"DESCRIPTION:Looking forward to it\\, see you then.\\nBring questions.\r\n" +
+	"LOCATION:\r\n" +
+	"STATUS:CONFIRMED\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	t.Run("google invite", func(t *testing.T) {
+		cal, err := Parse([]byte(googleInvite), time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, "REQUEST", cal.Method)
+		require.Len(t, cal.Events, 1)
+
+		event := cal.Events[0]
+		assert.Equal(t, "abc123@google.com", event.UID)
+		assert.Equal(t, "Technical Interview - Acme", event.Summary)
+		assert.Equal(t, "Looking forward to it, see you then.\nBring questions.", event.Description)
+		assert.Equal(t, "America/New_York", event.TimeZone)
+		assert.Equal(t, time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC), event.Start.UTC())
+		assert.Equal(t, 45*time.Minute, event.Duration())
+		assert.Equal(t, "https://meet.google.com/abc-defg-hij", event.ConferenceURL)
+		assert.False(t, event.Cancelled())
+
+		require.NotNil(t, event.Organizer)
+		assert.Equal(t, "talent.acme.com", event.Organizer.Domain())
+
+		require.Len(t, event.Attendees, 4)
+		assert.Equal(t, "Jane Recruiter", event.Attendees[0].Name)
+		assert.Equal(t, "Smith, Bob", event.Attendees[1].Name)
+		assert.Equal(t, "bob@acme.com", event.Attendees[1].Email)
+		assert.True(t, event.Attendees[2].Resource)
+	})
+
+	t.Run("outlook windows zone and duration", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"DURATION:PT1H30M",
+			"DTSTART;TZID=\"Pacific Standard Time\":20260311T090000",
+			"SUMMARY:Onsite",
+			"LOCATION:Join: https://acme.zoom.us/j/123456?pwd=xyz.",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		cal, err := Parse([]byte(data), time.UTC)
+		require.NoError(t, err)
+
+		event := cal.Events[0]
+		assert.Equal(t, "America/Los_Angeles", event.TimeZone)
+		assert.Equal(t, 90*time.Minute, event.Duration())
+		assert.Equal(t, "https://acme.zoom.us/j/123456?pwd=xyz", event.ConferenceURL)
+	})
+
+	t.Run("utc and all-day", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"DTSTART:20260312T150000Z",
+			"DTEND:20260312T160000Z",
+			"STATUS:CANCELLED",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20260313",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		cal, err := Parse([]byte(data), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, cal.Events, 2)
+		assert.True(t, cal.Events[0].Cancelled())
+		assert.Equal(t, "UTC", cal.Events[0].TimeZone)
+		assert.True(t, cal.Events[1].AllDay)
+		assert.Equal(t, 24*time.Hour, cal.Events[1].Duration())
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		_, err := Parse([]byte("hello"), time.UTC)
+		assert.ErrorIs(t, err, ErrNotCalendar)
+
+		_, err = Parse([]byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n"), time.UTC)
+		assert.ErrorIs(t, err, ErrNoEvents)
+	})
+}
+
+func TestLoadLocation(t *testing.T) {
+	for _, tzid := range []string{"Europe/Berlin", "W. Europe Standard Time", "/mozilla.org/20050126_1/Europe/Berlin"} {
+		loc, ok := LoadLocation(tzid)
+		require.True(t, ok, tzid)
+		assert.Equal(t, "Europe/Berlin", loc.String(), tzid)
+	}
+
+	_, ok := LoadLocation("Nowhere Standard Time")
+	assert.False(t, ok)
+}
+
+func TestParseDuration(t *testing.T) {
+	d, err := parseDuration("P1DT2H")
+	require.NoError(t, err)
+	assert.Equal(t, 26*time.Hour, d)
+
+	_, err = parseDuration("1 hour")
+	assert.Error(t, err)
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/services/urlextractor"
+	"ditto-backend/pkg/database"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// expiryRecheckAfter is how long a job goes between expiry checks
+	expiryRecheckAfter = 24 * time.Hour
+	// expiryBatchSize caps the number of jobs checked per run
+	expiryBatchSize = 100
+	// expiryHostInterval is the minimum spacing between requests to one host
+	expiryHostInterval = 5 * time.Second
+	// expiryMaxPerHost caps requests to a single host per run; the rest wait for the next run
+	expiryMaxPerHost = 10
+)
+
+type JobExpiryChecker struct {
+	db               *sqlx.DB
+	jobRepo          *repository.JobRepository
+	notificationRepo *repository.NotificationRepository
+	notificationSvc  *NotificationService
+	checker          urlextractor.ExpiryChecker
+	ticker           *time.Ticker
+	done             chan bool
+}
+
+func NewJobExpiryChecker(database *database.Database) *JobExpiryChecker {
+	return &JobExpiryChecker{
+		db:               database.DB,
+		jobRepo:          repository.NewJobRepository(database),
+		notificationRepo: repository.NewNotificationRepository(database),
+		notificationSvc:  NewNotificationService(database),
+		checker:          urlextractor.NewExpiryChecker(log.Default()),
+		done:             make(chan bool),
+	}
+}
+
+func (s *JobExpiryChecker) Start(interval time.Duration) {
+	s.ticker = time.NewTicker(interval)
+	go func() {
+		s.processExpiryChecks()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-s.ticker.C:
+				s.processExpiryChecks()
+			}
+		}
+	}()
+	log.Printf("Job expiry checker started with %v interval", interval)
+}
+
+func (s *JobExpiryChecker) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	s.done <- true
+	log.Println("Job expiry checker stopped")
+}
+
+func (s *JobExpiryChecker) processExpiryChecks() {
+	ctx := context.Background()
+
+	jobs, err := s.jobRepo.GetJobsDueForExpiryCheck(time.Now().Add(-expiryRecheckAfter), expiryBatchSize)
+	if err != nil {
+		log.Printf("Error fetching jobs for expiry check: %v", err)
+		return
+	}
+
+	jobIDs := make([]uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID
+	}
+	if err := s.jobRepo.MarkExpiryAttempted(jobIDs); err != nil {
+		log.Printf("Error recording expiry check attempts: %v", err)
+		return
+	}
+
+	limiter := newHostRateLimiter(expiryHostInterval, expiryMaxPerHost)
+	expiredCount := 0
+
+	for _, job := range jobs {
+		host := hostOf(*job.SourceURL)
+		if !limiter.Wait(ctx, host) {
+			continue
+		}
+
+		expired, err := s.checkJob(ctx, job)
+		if err != nil {
+			log.Printf("Error checking expiry for job %s: %v", job.ID, err)
+			continue
+		}
+		if expired {
+			expiredCount++
+		}
+	}
+
+	if len(jobs) > 0 {
+		log.Printf("Job expiry check complete: %d checked, %d newly expired", len(jobs), expiredCount)
+	}
+}
+
+func (s *JobExpiryChecker) checkJob(ctx context.Context, job *models.Job) (bool, error) {
+	status, err := s.checker.CheckExpiry(ctx, *job.SourceURL)
+	if err != nil {
+		// Transient failures leave expiry_checked_at untouched so the job is retried
+		// next run, behind the jobs that haven't been attempted as recently
+		return false, err
+	}
+
+	if err := s.jobRepo.MarkExpiryChecked(job.ID, status.Expired); err != nil {
+		return false, err
+	}
+
+	if !status.Expired {
+		return false, nil
+	}
+
+	if err := s.alertSavedApplications(job.ID); err != nil {
+		log.Printf("Error creating expiry alerts for job %s: %v", job.ID, err)
+	}
+
+	return true, nil
+}
+
+type savedApplication struct {
+	ID          uuid.UUID `db:"id"`
+	UserID      uuid.UUID `db:"user_id"`
+	CompanyName string    `db:"company_name"`
+	JobTitle    string    `db:"job_title"`
+}
+
+// alertSavedApplications notifies users who saved the job but never applied.
+// Users who already applied don't need to act, so they aren't alerted.
+func (s *JobExpiryChecker) alertSavedApplications(jobID uuid.UUID) error {
+	query := `
+		SELECT a.id, a.user_id, c.name as company_name, j.title as job_title
+		FROM applications a
+		JOIN application_status st ON a.application_status_id = st.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE a.job_id = $1
+			AND a.deleted_at IS NULL
+			AND st.name = 'Saved'
+	`
+
+	var applications []savedApplication
+	if err := s.db.Select(&applications, query, jobID); err != nil {
+		return err
+	}
+
+	for _, app := range applications {
+		link := fmt.Sprintf("/applications/%s#expired", app.ID.String())
+
+		exists, err := s.notificationRepo.ExistsByLink(app.UserID, link)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		title := "Saved job posting expired"
+		message := fmt.Sprintf("The %s posting at %s is no longer accepting applications", app.JobTitle, app.CompanyName)
+		if _, err := s.notificationSvc.CreateSystemAlert(app.UserID, title, message, &link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+}
+
+// hostRateLimiter spaces out requests to the same host and caps how many
+// requests a single host receives in one run.
+type hostRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	maxHits  int
+	last     map[string]time.Time
+	hits     map[string]int
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) bool
+}
+
+func newHostRateLimiter(interval time.Duration, maxHits int) *hostRateLimiter {
+	return &hostRateLimiter{
+		interval: interval,
+		maxHits:  maxHits,
+		last:     make(map[string]time.Time),
+		hits:     make(map[string]int),
+		now:      time.Now,
+		sleep: func(ctx context.Context, d time.Duration) bool {
+			select {
+			case <-time.After(d):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		},
+	}
+}
+
+// Wait blocks until a request to host is allowed. It returns false when the
+// host has used up its budget for this run or the context is cancelled.
+func (l *hostRateLimiter) Wait(ctx context.Context, host string) bool {
+	l.mu.Lock()
+	if l.hits[host] >= l.maxHits {
+		l.mu.Unlock()
+		return false
+	}
+
+	var delay time.Duration
+	if last, ok := l.last[host]; ok {
+		delay = l.interval - l.now().Sub(last)
+	}
+	l.hits[host]++
+	l.mu.Unlock()
+
+	if delay > 0 && !l.sleep(ctx, delay) {
+		return false
+	}
+
+	l.mu.Lock()
+	l.last[host] = l.now()
+	l.mu.Unlock()
+
+	return true
+}
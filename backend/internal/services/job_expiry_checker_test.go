@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHostRateLimiter(interval time.Duration, maxHits int) (*hostRateLimiter, *time.Time, *[]time.Duration) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+
+	limiter := newHostRateLimiter(interval, maxHits)
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(ctx context.Context, d time.Duration) bool {
+		slept = append(slept, d)
+		now = now.Add(d)
+		return true
+	}
+
+	return limiter, &now, &slept
+}
+
+func TestHostRateLimiter(t *testing.T) {
+	t.Run("first request to a host is not delayed", func(t *testing.T) {
+		limiter, _, slept := newTestHostRateLimiter(5*time.Second, 3)
+
+		assert.True(t, limiter.Wait(context.Background(), "example.com"))
+		assert.Empty(t, *slept)
+	})
+
+	t.Run("back-to-back requests to the same host are spaced out", func(t *testing.T) {
+		limiter, now, slept := newTestHostRateLimiter(5*time.Second, 3)
+
+		assert.True(t, limiter.Wait(context.Background(), "example.com"))
+		*now = now.Add(2 * time.Second)
+		assert.True(t, limiter.Wait(context.Background(), "example.com"))
+
+		assert.Equal(t, []time.Duration{3 * time.Second}, *slept)
+	})
+
+	t.Run("different hosts don't delay each other", func(t *testing.T) {
+		limiter, _, slept := newTestHostRateLimiter(5*time.Second, 3)
+
+		assert.True(t, limiter.Wait(context.Background(), "a.example.com"))
+		assert.True(t, limiter.Wait(context.Background(), "b.example.com"))
+		assert.Empty(t, *slept)
+	})
+
+	t.Run("host budget is capped per run", func(t *testing.T) {
+		limiter, _, _ := newTestHostRateLimiter(time.Second, 2)
+
+		assert.True(t, limiter.Wait(context.Background(), "example.com"))
+		assert.True(t, limiter.Wait(context.Background(), "example.com"))
+		assert.False(t, limiter.Wait(context.Background(), "example.com"))
+		assert.True(t, limiter.Wait(context.Background(), "other.com"))
+	})
+}
+
+func TestHostOf(t *testing.T) {
+	assert.Equal(t, "linkedin.com", hostOf("https://www.LinkedIn.com/jobs/view/123"))
+	assert.Equal(t, "boards.greenhouse.io", hostOf("https://boards.greenhouse.io/acme/jobs/1"))
+	assert.Equal(t, "", hostOf("://bad"))
+}
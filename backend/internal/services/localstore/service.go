@@ -0,0 +1,241 @@
+// Package localstore keeps uploaded files on the local filesystem for
+// self-hosted installs without S3. It implements s3.S3ServiceInterface, so the
+// file handlers don't know which backend they use: presigned URLs point back
+// at this server and are signed with an HMAC over the method, key and expiry.
+package localstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RoutePrefix is where the server mounts the signed upload and download routes.
+const RoutePrefix = "/api/storage"
+
+var (
+	ErrInvalidKey       = errors.New("invalid storage key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrURLExpired       = errors.New("signed URL has expired")
+	ErrTooLarge         = errors.New("object exceeds the maximum size")
+)
+
+type Service struct {
+	root      string
+	secret    []byte
+	baseURL   string
+	urlExpiry time.Duration
+	now       func() time.Time
+}
+
+type Config struct {
+	// Root is the directory objects are stored under. It is created if missing.
+	Root string
+	// Secret signs upload and download URLs.
+	Secret string
+	// BaseURL is the server's public address, e.g. https://ditto.example.com.
+	BaseURL   string
+	URLExpiry time.Duration
+}
+
+func NewService(cfg Config) (*Service, error) {
+	if cfg.Root == "" {
+		return nil, fmt.Errorf("local storage root is not set")
+	}
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("local storage secret is not set")
+	}
+
+	root, err := filepath.Abs(cfg.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage root: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create local storage root: %w", err)
+	}
+
+	return &Service{
+		root:      root,
+		secret:    []byte(cfg.Secret),
+		baseURL:   strings.TrimRight(cfg.BaseURL, "/"),
+		urlExpiry: cfg.URLExpiry,
+		now:       time.Now,
+	}, nil
+}
+
+func (s *Service) GeneratePresignedPutURL(ctx context.Context, s3Key, contentType string) (string, error) {
+	return s.signedURL("PUT", s3Key)
+}
+
+func (s *Service) GeneratePresignedGetURL(ctx context.Context, s3Key string) (string, error) {
+	return s.signedURL("GET", s3Key)
+}
+
+func (s *Service) HeadObject(ctx context.Context, s3Key string) (bool, error) {
+	path, err := s.path(s3Key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check object existence: %w", err)
+	}
+
+	return true, nil
+}
+
+// DeleteObject removes an object. Like S3, deleting a missing object succeeds.
+func (s *Service) DeleteObject(ctx context.Context, s3Key string) error {
+	path, err := s.path(s3Key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) PutObject(ctx context.Context, s3Key, contentType string, body io.Reader, size int64) error {
+	if _, err := s.write(s3Key, body, -1); err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) GetObject(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+	file, err := s.Open(s3Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	return file, nil
+}
+
+// Upload stores the body of a signed PUT. It reads at most maxSize bytes and
+// returns ErrTooLarge, storing nothing, if there is more.
+func (s *Service) Upload(s3Key string, body io.Reader, maxSize int64) (int64, error) {
+	return s.write(s3Key, body, maxSize)
+}
+
+// Open opens an object for a signed GET.
+func (s *Service) Open(s3Key string) (*os.File, error) {
+	path, err := s.path(s3Key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Verify checks the expires and signature query parameters of a signed URL.
+func (s *Service) Verify(method, s3Key, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(given, s.sign(method, s3Key, expiresAt)) {
+		return ErrInvalidSignature
+	}
+
+	if s.now().Unix() > expiresAt {
+		return ErrURLExpired
+	}
+
+	return nil
+}
+
+func (s *Service) signedURL(method, s3Key string) (string, error) {
+	if _, err := s.path(s3Key); err != nil {
+		return "", err
+	}
+
+	expiresAt := s.now().Add(s.urlExpiry).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", hex.EncodeToString(s.sign(method, s3Key, expiresAt)))
+
+	segments := strings.Split(s3Key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf("%s%s/%s?%s", s.baseURL, RoutePrefix, strings.Join(segments, "/"), query.Encode()), nil
+}
+
+func (s *Service) sign(method, s3Key string, expiresAt int64) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, s3Key, expiresAt)
+	return mac.Sum(nil)
+}
+
+// path maps a key to a file under the root, rejecting keys that would
+// escape it.
+func (s *Service) path(s3Key string) (string, error) {
+	if s3Key == "" || !filepath.IsLocal(filepath.FromSlash(s3Key)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(s3Key)), nil
+}
+
+// write streams body into a temporary file next to the object and renames
+// it into place, so readers never see a partial upload. A limit below zero
+// means no limit.
+func (s *Service) write(s3Key string, body io.Reader, limit int64) (int64, error) {
+	path, err := s.path(s3Key)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if limit >= 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	written, err := io.Copy(tmp, body)
+	if err != nil {
+		return 0, err
+	}
+	if limit >= 0 && written > limit {
+		return 0, ErrTooLarge
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
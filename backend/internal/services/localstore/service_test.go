@@ -0,0 +1,149 @@
+package localstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "123e4567-e89b-12d3-a456-426614174000/6f1c0c1e-4a55-4d4b-9f1e-0b8f3c1a2d3e.pdf"
+
+func newTestService(t *testing.T) *Service {
+	service, err := NewService(Config{
+		Root:      t.TempDir(),
+		Secret:    "test-secret",
+		BaseURL:   "http://localhost:8081/",
+		URLExpiry: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+	return service
+}
+
+// signedParams returns the key, expires and signature of a signed URL.
+func signedParams(t *testing.T, signedURL string) (string, string, string) {
+	parsed, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	return strings.TrimPrefix(parsed.Path, RoutePrefix+"/"), parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+func TestNewService(t *testing.T) {
+	_, err := NewService(Config{Root: t.TempDir()})
+	assert.Error(t, err, "a secret is required")
+
+	_, err = NewService(Config{Secret: "secret"})
+	assert.Error(t, err, "a root is required")
+}
+
+func TestSignedURLs(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t)
+
+	putURL, err := service.GeneratePresignedPutURL(ctx, testKey, "application/pdf")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(putURL, "http://localhost:8081/api/storage/"+testKey+"?"))
+
+	key, expires, signature := signedParams(t, putURL)
+	assert.Equal(t, testKey, key)
+	assert.NoError(t, service.Verify("PUT", key, expires, signature))
+
+	t.Run("signature is tied to the method", func(t *testing.T) {
+		assert.ErrorIs(t, service.Verify("GET", key, expires, signature), ErrInvalidSignature)
+	})
+
+	t.Run("signature is tied to the key", func(t *testing.T) {
+		other := strings.Replace(key, ".pdf", ".txt", 1)
+		assert.ErrorIs(t, service.Verify("PUT", other, expires, signature), ErrInvalidSignature)
+	})
+
+	t.Run("signature is tied to the expiry", func(t *testing.T) {
+		assert.ErrorIs(t, service.Verify("PUT", key, "9999999999", signature), ErrInvalidSignature)
+	})
+
+	t.Run("expired URL", func(t *testing.T) {
+		service.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { service.now = time.Now }()
+
+		assert.ErrorIs(t, service.Verify("PUT", key, expires, signature), ErrURLExpired)
+	})
+
+	t.Run("keys outside the root are rejected", func(t *testing.T) {
+		_, err := service.GeneratePresignedGetURL(ctx, "../etc/passwd")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+
+		_, err = service.GeneratePresignedGetURL(ctx, "/etc/passwd")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("key segments are escaped", func(t *testing.T) {
+		getURL, err := service.GeneratePresignedGetURL(ctx, "user/my file#1.pdf")
+		require.NoError(t, err)
+		assert.Contains(t, getURL, "/api/storage/user/my%20file%231.pdf?")
+	})
+}
+
+func TestObjects(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t)
+
+	exists, err := service.HeadObject(ctx, testKey)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	written, err := service.Upload(testKey, strings.NewReader("%PDF-1.7"), 16)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), written)
+
+	exists, err = service.HeadObject(ctx, testKey)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	file, err := service.Open(testKey)
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "%PDF-1.7", string(data))
+
+	t.Run("uploads over the limit are discarded", func(t *testing.T) {
+		_, err := service.Upload(testKey, strings.NewReader(strings.Repeat("x", 17)), 16)
+		assert.ErrorIs(t, err, ErrTooLarge)
+
+		file, err := service.Open(testKey)
+		require.NoError(t, err)
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "%PDF-1.7", string(data), "the existing object is untouched")
+
+		entries, err := os.ReadDir(filepath.Dir(filepath.Join(service.root, testKey)))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temporary files are left behind")
+	})
+
+	t.Run("PutObject has no limit", func(t *testing.T) {
+		body := bytes.Repeat([]byte("x"), 64)
+		require.NoError(t, service.PutObject(ctx, "archives/repo.tar.gz", "application/gzip", bytes.NewReader(body), int64(len(body))))
+
+		exists, err := service.HeadObject(ctx, "archives/repo.tar.gz")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("DeleteObject", func(t *testing.T) {
+		require.NoError(t, service.DeleteObject(ctx, testKey))
+
+		exists, err := service.HeadObject(ctx, testKey)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		assert.NoError(t, service.DeleteObject(ctx, testKey), "deleting a missing object succeeds")
+	})
+}
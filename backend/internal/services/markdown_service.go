@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// FileReferencePrefix marks a link or image destination that points at one of
+// the user's uploaded files, e.g. ![diagram](file:<file id>).
+const FileReferencePrefix = "file:"
+
+// MarkdownService renders Markdown notes to HTML. Raw HTML in the source is
+// dropped by the renderer and the output is sanitized with the same policy as
+// HTML notes.
+type MarkdownService struct {
+	markdown  goldmark.Markdown
+	sanitizer *SanitizerService
+}
+
+func NewMarkdownService(sanitizer *SanitizerService) *MarkdownService {
+	return &MarkdownService{
+		markdown:  goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		sanitizer: sanitizer,
+	}
+}
+
+// FileReferences returns the IDs of files referenced by links and images in
+// the source, in order of first appearance. References in code are ignored.
+func (s *MarkdownService) FileReferences(source string) []uuid.UUID {
+	src := []byte(source)
+	doc := s.markdown.Parser().Parse(text.NewReader(src))
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, node := range fileLinks(doc) {
+		if id, ok := parseFileReference(destination(node)); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Render converts Markdown to sanitized HTML. File references are replaced by
+// the URLs in fileURLs; a reference with no URL (unknown file, or one the user
+// does not own) is rendered as its plain text.
+func (s *MarkdownService) Render(source string, fileURLs map[uuid.UUID]string) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+
+	src := []byte(source)
+	doc := s.markdown.Parser().Parse(text.NewReader(src))
+
+	for _, node := range fileLinks(doc) {
+		id, ok := parseFileReference(destination(node))
+		if url, found := fileURLs[id]; ok && found {
+			setDestination(node, url)
+			continue
+		}
+		unwrap(node)
+	}
+
+	var buf bytes.Buffer
+	if err := s.markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return "", err
+	}
+
+	return s.sanitizer.SanitizeHTML(buf.String()), nil
+}
+
+// fileLinks collects links and images that point at files. They are gathered
+// before any are modified, since the tree cannot be changed while it is
+// walked.
+func fileLinks(doc ast.Node) []ast.Node {
+	var nodes []ast.Node
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && strings.HasPrefix(destination(node), FileReferencePrefix) {
+			nodes = append(nodes, node)
+		}
+		return ast.WalkContinue, nil
+	})
+	return nodes
+}
+
+// destination returns the URL of a link or image node, or "" for other nodes.
+func destination(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.Link:
+		return string(n.Destination)
+	case *ast.Image:
+		return string(n.Destination)
+	}
+	return ""
+}
+
+func setDestination(node ast.Node, url string) {
+	switch n := node.(type) {
+	case *ast.Link:
+		n.Destination = []byte(url)
+	case *ast.Image:
+		n.Destination = []byte(url)
+	}
+}
+
+func parseFileReference(destination string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(strings.TrimPrefix(destination, FileReferencePrefix))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// unwrap replaces a node with its children, keeping a link's text or an
+// image's alt text.
+func unwrap(node ast.Node) {
+	parent := node.Parent()
+	if parent == nil {
+		return
+	}
+	for child := node.FirstChild(); child != nil; {
+		next := child.NextSibling()
+		parent.InsertBefore(parent, node, child)
+		child = next
+	}
+	parent.RemoveChild(parent, node)
+}
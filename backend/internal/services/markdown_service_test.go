@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownService_Render(t *testing.T) {
+	s := NewMarkdownService(NewSanitizerService())
+
+	html, err := s.Render("# Prep\n\n- **ask** about on-call\n- ~~salary~~", nil)
+	require.NoError(t, err)
+	assert.Contains(t, html, "<h1")
+	assert.Contains(t, html, "<strong>ask</strong>")
+	assert.Contains(t, html, "<del>salary</del>")
+
+	html, err = s.Render("", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", html)
+}
+
+func TestMarkdownService_Render_StripsUnsafeContent(t *testing.T) {
+	s := NewMarkdownService(NewSanitizerService())
+
+	html, err := s.Render("<script>alert('xss')</script>\n\n[click](javascript:alert(1)) <img src=x onerror=alert(1)>", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "javascript:")
+	assert.NotContains(t, html, "onerror")
+}
+
+func TestMarkdownService_FileReferences(t *testing.T) {
+	s := NewMarkdownService(NewSanitizerService())
+	diagram := uuid.New()
+	resume := uuid.New()
+
+	source := "![diagram](file:" + diagram.String() + ")\n\n" +
+		"See [my resume](file:" + resume.String() + ") and [again](file:" + diagram.String() + ").\n\n" +
+		"`[code](file:" + uuid.New().String() + ")` and [bad](file:not-a-uuid)"
+
+	assert.Equal(t, []uuid.UUID{diagram, resume}, s.FileReferences(source))
+}
+
+func TestMarkdownService_Render_FileReferences(t *testing.T) {
+	s := NewMarkdownService(NewSanitizerService())
+	diagram := uuid.New()
+	missing := uuid.New()
+
+	source := "![system diagram](file:" + diagram.String() + ")\n\n[old resume](file:" + missing.String() + ")"
+	html, err := s.Render(source, map[uuid.UUID]string{
+		diagram: "https://files.example.com/diagram.png?sig=abc",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, html, `<img src="https://files.example.com/diagram.png?sig=abc"`)
+	assert.Contains(t, html, `alt="system diagram"`)
+	assert.Contains(t, html, "old resume")
+	assert.NotContains(t, html, "file:")
+	assert.NotContains(t, html, "<a ")
+}
@@ -0,0 +1,153 @@
+// Package notediff computes line-based differences between two versions of an
+// interview note.
+package notediff
+
+import "strings"
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// maxCells bounds the size of the LCS table. Notes are capped at 50KB, but two
+// long notes that differ everywhere would still need a table of billions of
+// cells; past this size the changed block is reported as replaced outright.
+const maxCells = 4_000_000
+
+// Line is one line of a diff. OldLine and NewLine are 1-based line numbers in
+// the old and new text; the side a line does not appear on is zero.
+type Line struct {
+	Op      string `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+// Result is the diff between two texts and how many lines changed.
+type Result struct {
+	Lines   []Line `json:"lines"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+}
+
+// Diff compares two versions of a text line by line.
+func Diff(oldText, newText string) *Result {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// Lines shared at both ends are common in note edits and keep the table
+	// small.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := &Result{Lines: []Line{}}
+	oldLine, newLine := 0, 0
+
+	emit := func(op, text string) {
+		line := Line{Op: op, Text: text}
+		switch op {
+		case OpEqual:
+			oldLine++
+			newLine++
+			line.OldLine, line.NewLine = oldLine, newLine
+		case OpDelete:
+			oldLine++
+			line.OldLine = oldLine
+			result.Removed++
+		case OpInsert:
+			newLine++
+			line.NewLine = newLine
+			result.Added++
+		}
+		result.Lines = append(result.Lines, line)
+	}
+
+	for _, text := range a[:prefix] {
+		emit(OpEqual, text)
+	}
+	for _, op := range diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		emit(op.op, op.text)
+	}
+	for _, text := range a[len(a)-suffix:] {
+		emit(OpEqual, text)
+	}
+
+	return result
+}
+
+type edit struct {
+	op   string
+	text string
+}
+
+// diffMiddle diffs the lines between the common prefix and suffix using a
+// longest common subsequence table.
+func diffMiddle(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+
+	if len(a)*len(b) > maxCells {
+		for _, text := range a {
+			edits = append(edits, edit{OpDelete, text})
+		}
+		for _, text := range b {
+			edits = append(edits, edit{OpInsert, text})
+		}
+		return edits
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{OpEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{OpDelete, a[i]})
+			i++
+		default:
+			edits = append(edits, edit{OpInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{OpDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{OpInsert, b[j]})
+	}
+
+	return edits
+}
+
+// splitLines splits text on newlines, treating CRLF as LF. Empty text has no
+// lines, and a trailing newline does not start another one.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
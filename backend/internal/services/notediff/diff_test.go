@@ -0,0 +1,57 @@
+package notediff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	result := Diff("intro\nask about team\nsalary\n", "intro\nask about on-call\nsalary\nfollow up\n")
+
+	assert.Equal(t, []Line{
+		{Op: OpEqual, Text: "intro", OldLine: 1, NewLine: 1},
+		{Op: OpDelete, Text: "ask about team", OldLine: 2},
+		{Op: OpInsert, Text: "ask about on-call", NewLine: 2},
+		{Op: OpEqual, Text: "salary", OldLine: 3, NewLine: 3},
+		{Op: OpInsert, Text: "follow up", NewLine: 4},
+	}, result.Lines)
+	assert.Equal(t, 2, result.Added)
+	assert.Equal(t, 1, result.Removed)
+}
+
+func TestDiff_Identical(t *testing.T) {
+	result := Diff("a\r\nb", "a\nb\n")
+	assert.Equal(t, 0, result.Added)
+	assert.Equal(t, 0, result.Removed)
+	assert.Len(t, result.Lines, 2)
+}
+
+func TestDiff_Empty(t *testing.T) {
+	result := Diff("", "")
+	assert.Empty(t, result.Lines)
+
+	result = Diff("", "first line")
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, OpInsert, result.Lines[0].Op)
+
+	result = Diff("gone", "")
+	require.Len(t, result.Lines, 1)
+	assert.Equal(t, OpDelete, result.Lines[0].Op)
+}
+
+func TestDiff_LargeChangeFallsBackToReplace(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 2500; i++ {
+		oldLines = append(oldLines, "old "+strings.Repeat("x", i%7))
+		newLines = append(newLines, "new "+strings.Repeat("y", i%5))
+	}
+
+	result := Diff(strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"))
+	assert.Equal(t, 2500, result.Added)
+	assert.Equal(t, 2500, result.Removed)
+	assert.Equal(t, OpDelete, result.Lines[0].Op)
+	assert.Equal(t, OpInsert, result.Lines[len(result.Lines)-1].Op)
+}
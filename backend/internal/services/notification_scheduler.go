@@ -13,19 +13,44 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// reflectionPromptMaxAge stops interviews that ended long ago, e.g. ones
+// never marked completed before auto-completion existed, from all prompting
+// for a reflection at once. They are still marked completed.
+const reflectionPromptMaxAge = 48 * time.Hour
+
+// thankYouReminderMaxAge is how long after the reminder window a missing
+// thank-you note is still worth a reminder.
+const thankYouReminderMaxAge = 7 * 24 * time.Hour
+
+// interviewZoneSQL is the time zone an interview's date and time are wall
+// clock readings in: the user's calendar sync time zone, or UTC, as when
+// checking interviews for conflicts.
+const interviewZoneSQL = `COALESCE((SELECT csa.timezone FROM calendar_sync_accounts csa WHERE csa.user_id = i.user_id), 'UTC')`
+
+// interviewEndSQL is the instant an interview finishes. Interviews without a
+// start time are taken to last the whole day.
+const interviewEndSQL = `(CASE
+	WHEN i.scheduled_time IS NULL THEN i.scheduled_date + INTERVAL '1 day'
+	ELSE i.scheduled_date + i.scheduled_time + make_interval(mins => COALESCE(i.duration_minutes, 60))
+END) AT TIME ZONE ` + interviewZoneSQL
+
 type NotificationScheduler struct {
 	db               *sqlx.DB
 	notificationRepo *repository.NotificationRepository
 	notificationSvc  *NotificationService
+	thankYouWindow   time.Duration
 	ticker           *time.Ticker
 	done             chan bool
 }
 
-func NewNotificationScheduler(database *database.Database) *NotificationScheduler {
+// NewNotificationScheduler creates a scheduler that reminds users about a
+// missing thank-you note once thankYouWindow has passed since an interview.
+func NewNotificationScheduler(database *database.Database, thankYouWindow time.Duration) *NotificationScheduler {
 	return &NotificationScheduler{
 		db:               database.DB,
 		notificationRepo: repository.NewNotificationRepository(database),
 		notificationSvc:  NewNotificationService(database),
+		thankYouWindow:   thankYouWindow,
 		done:             make(chan bool),
 	}
 }
@@ -64,6 +89,14 @@ func (s *NotificationScheduler) processReminders() {
 	if err := s.processAssessmentReminders(ctx); err != nil {
 		log.Printf("Error processing assessment reminders: %v", err)
 	}
+
+	if err := s.processEndedInterviews(ctx); err != nil {
+		log.Printf("Error processing ended interviews: %v", err)
+	}
+
+	if err := s.processThankYouReminders(ctx); err != nil {
+		log.Printf("Error processing thank-you reminders: %v", err)
+	}
 }
 
 type upcomingInterview struct {
@@ -142,18 +175,7 @@ func (s *NotificationScheduler) createInterviewReminderIfNeeded(interview *upcom
 		return nil
 	}
 
-	info := &InterviewInfo{
-		ID:            interview.ID,
-		UserID:        interview.UserID,
-		ApplicationID: interview.ApplicationID,
-		InterviewType: interview.InterviewType,
-		RoundNumber:   interview.RoundNumber,
-		ScheduledDate: interview.ScheduledDate,
-		CompanyName:   interview.CompanyName,
-		JobTitle:      interview.JobTitle,
-	}
-
-	_, err = s.notificationSvc.CreateInterviewReminder(info, reminderType)
+	_, err = s.notificationSvc.CreateInterviewReminder(interview.info(), reminderType)
 	return err
 }
 
@@ -169,9 +191,8 @@ type upcomingAssessment struct {
 
 func (s *NotificationScheduler) processAssessmentReminders(ctx context.Context) error {
 	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
-	assessments3d, err := s.getAssessmentsDueOn(today.AddDate(0, 0, 3))
+	assessments3d, err := s.getAssessmentsDueBetween(now.Add(71*time.Hour), now.Add(73*time.Hour))
 	if err != nil {
 		return fmt.Errorf("fetching 3d assessments: %w", err)
 	}
@@ -182,7 +203,7 @@ func (s *NotificationScheduler) processAssessmentReminders(ctx context.Context)
 		}
 	}
 
-	assessments1d, err := s.getAssessmentsDueOn(today.AddDate(0, 0, 1))
+	assessments1d, err := s.getAssessmentsDueBetween(now.Add(23*time.Hour), now.Add(25*time.Hour))
 	if err != nil {
 		return fmt.Errorf("fetching 1d assessments: %w", err)
 	}
@@ -193,7 +214,7 @@ func (s *NotificationScheduler) processAssessmentReminders(ctx context.Context)
 		}
 	}
 
-	assessments1h, err := s.getAssessmentsDueOn(today)
+	assessments1h, err := s.getAssessmentsDueBetween(now.Add(30*time.Minute), now.Add(90*time.Minute))
 	if err != nil {
 		return fmt.Errorf("fetching 1h assessments: %w", err)
 	}
@@ -207,7 +228,10 @@ func (s *NotificationScheduler) processAssessmentReminders(ctx context.Context)
 	return nil
 }
 
-func (s *NotificationScheduler) getAssessmentsDueOn(date time.Time) ([]upcomingAssessment, error) {
+// getAssessmentsDueBetween returns open assessments whose deadline falls in
+// the window. Deadlines are exact instants, so the windows line up with the
+// reminder text in every timezone.
+func (s *NotificationScheduler) getAssessmentsDueBetween(from, to time.Time) ([]upcomingAssessment, error) {
 	query := `
 		SELECT
 			ass.id, ass.user_id, ass.application_id, ass.title, ass.due_date,
@@ -219,11 +243,11 @@ func (s *NotificationScheduler) getAssessmentsDueOn(date time.Time) ([]upcomingA
 		WHERE ass.deleted_at IS NULL
 			AND a.deleted_at IS NULL
 			AND ass.status != $1
-			AND ass.due_date = $2::date
+			AND ass.due_date BETWEEN $2 AND $3
 	`
 
 	var assessments []upcomingAssessment
-	err := s.db.Select(&assessments, query, models.AssessmentStatusSubmitted, date)
+	err := s.db.Select(&assessments, query, models.AssessmentStatusSubmitted, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -256,3 +280,140 @@ func (s *NotificationScheduler) createAssessmentReminderIfNeeded(assessment *upc
 	_, err = s.notificationSvc.CreateAssessmentReminder(info, reminderType)
 	return err
 }
+
+type endedInterview struct {
+	upcomingInterview
+	EndedAt   time.Time `db:"ended_at"`
+	Reflected bool      `db:"reflected"`
+}
+
+// processEndedInterviews marks scheduled interviews whose end time has passed
+// as completed and prompts for a reflection on the recent ones.
+func (s *NotificationScheduler) processEndedInterviews(ctx context.Context) error {
+	now := time.Now()
+
+	query := `
+		UPDATE interviews i
+		SET status = $1, updated_at = $2
+		FROM applications a, jobs j, companies c
+		WHERE i.application_id = a.id AND a.job_id = j.id AND j.company_id = c.id
+			AND i.deleted_at IS NULL
+			AND a.deleted_at IS NULL
+			AND i.status = $3
+			AND ` + interviewEndSQL + ` <= $2
+		RETURNING
+			i.id, i.user_id, i.application_id, i.interview_type, i.round_number,
+			i.scheduled_date, i.scheduled_time,
+			c.name as company_name, j.title as job_title,
+			` + interviewEndSQL + ` as ended_at,
+			(i.went_well IS NOT NULL OR i.could_improve IS NOT NULL OR i.confidence_level IS NOT NULL) as reflected
+	`
+
+	var interviews []endedInterview
+	err := s.db.Select(&interviews, query, models.InterviewStatusCompleted, now, models.InterviewStatusScheduled)
+	if err != nil {
+		return fmt.Errorf("completing ended interviews: %w", err)
+	}
+
+	for _, interview := range interviews {
+		if interview.Reflected || interview.EndedAt.Before(now.Add(-reflectionPromptMaxAge)) {
+			continue
+		}
+		if err := s.createReflectionPromptIfNeeded(&interview.upcomingInterview); err != nil {
+			log.Printf("Error creating reflection prompt for interview %s: %v", interview.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationScheduler) createReflectionPromptIfNeeded(interview *upcomingInterview) error {
+	link := fmt.Sprintf("/interviews/%s#%s", interview.ID.String(), ReminderTypeReflection)
+
+	exists, err := s.notificationRepo.ExistsByLink(interview.UserID, link)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	_, err = s.notificationSvc.CreateReflectionPrompt(interview.info())
+	return err
+}
+
+type thankYouPending struct {
+	upcomingInterview
+	InterviewerNames string `db:"interviewer_names"`
+}
+
+// processThankYouReminders reminds users about interviewers who haven't had a
+// thank-you note once the configured window since the interview has passed.
+func (s *NotificationScheduler) processThankYouReminders(ctx context.Context) error {
+	now := time.Now()
+	dueBy := now.Add(-s.thankYouWindow)
+
+	query := `
+		SELECT
+			i.id, i.user_id, i.application_id, i.interview_type, i.round_number,
+			i.scheduled_date, i.scheduled_time,
+			c.name as company_name, j.title as job_title,
+			string_agg(iv.name, ', ' ORDER BY iv.created_at) as interviewer_names
+		FROM interviews i
+		JOIN interviewers iv ON iv.interview_id = i.id
+		JOIN applications a ON i.application_id = a.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE i.deleted_at IS NULL
+			AND a.deleted_at IS NULL
+			AND iv.deleted_at IS NULL
+			AND NOT iv.thank_you_sent
+			AND i.status = $1
+			AND ` + interviewEndSQL + ` BETWEEN $2 AND $3
+		GROUP BY i.id, c.name, j.title
+	`
+
+	var pending []thankYouPending
+	err := s.db.Select(&pending, query, models.InterviewStatusCompleted, dueBy.Add(-thankYouReminderMaxAge), dueBy)
+	if err != nil {
+		return fmt.Errorf("fetching missing thank-you notes: %w", err)
+	}
+
+	for _, interview := range pending {
+		if err := s.createThankYouReminderIfNeeded(&interview); err != nil {
+			log.Printf("Error creating thank-you reminder for interview %s: %v", interview.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationScheduler) createThankYouReminderIfNeeded(interview *thankYouPending) error {
+	link := fmt.Sprintf("/interviews/%s#%s", interview.ID.String(), ReminderTypeThankYou)
+
+	exists, err := s.notificationRepo.ExistsByLink(interview.UserID, link)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	_, err = s.notificationSvc.CreateThankYouReminder(interview.info(), interview.InterviewerNames)
+	return err
+}
+
+func (i *upcomingInterview) info() *InterviewInfo {
+	return &InterviewInfo{
+		ID:            i.ID,
+		UserID:        i.UserID,
+		ApplicationID: i.ApplicationID,
+		InterviewType: i.InterviewType,
+		RoundNumber:   i.RoundNumber,
+		ScheduledDate: i.ScheduledDate,
+		CompanyName:   i.CompanyName,
+		JobTitle:      i.JobTitle,
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/internal/repository"
+	"ditto-backend/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// schedulerFixture is a user in Los Angeles with one application, so interview
+// times read as UTC would be seven or eight hours off.
+type schedulerFixture struct {
+	db            *testutil.TestDatabase
+	scheduler     *NotificationScheduler
+	interviewRepo *repository.InterviewRepository
+	userID        uuid.UUID
+	applicationID uuid.UUID
+	loc           *time.Location
+}
+
+func newSchedulerFixture(t *testing.T) *schedulerFixture {
+	db := testutil.NewTestDatabase(t)
+	t.Cleanup(func() {
+		db.Close(t)
+	})
+	db.RunMigrations(t)
+
+	userRepo := repository.NewUserRepository(db.Database)
+	companyRepo := repository.NewCompanyRepository(db.Database)
+	jobRepo := repository.NewJobRepository(db.Database)
+	applicationRepo := repository.NewApplicationRepository(db.Database)
+	syncRepo := repository.NewCalendarSyncRepository(db.Database)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	testUser, err := userRepo.CreateUser("scheduler@example.com", "Scheduler User", string(hashedPassword))
+	require.NoError(t, err)
+
+	createdCompany, err := companyRepo.CreateCompany(testutil.CreateTestCompany("Schedule Co", "scheduleco.com"))
+	require.NoError(t, err)
+	createdJob, err := jobRepo.CreateJob(testUser.ID, testutil.CreateTestJob(createdCompany.ID, "Software Engineer", "Build things"))
+	require.NoError(t, err)
+
+	var statusID uuid.UUID
+	require.NoError(t, db.Get(&statusID, "SELECT id FROM application_status LIMIT 1"))
+	createdApp, err := applicationRepo.CreateApplication(testUser.ID, testutil.CreateTestApplication(testUser.ID, createdJob.ID, statusID))
+	require.NoError(t, err)
+
+	_, err = syncRepo.UpsertAccount(&models.CalendarSyncAccount{
+		UserID:            testUser.ID,
+		CollectionURL:     "https://calendar.example.com/user/calendar/",
+		Username:          "user",
+		PasswordEncrypted: "sealed",
+		Timezone:          "America/Los_Angeles",
+		Enabled:           true,
+	})
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	return &schedulerFixture{
+		db:            db,
+		scheduler:     NewNotificationScheduler(db.Database, 24*time.Hour),
+		interviewRepo: repository.NewInterviewRepository(db.Database),
+		userID:        testUser.ID,
+		applicationID: createdApp.ID,
+		loc:           loc,
+	}
+}
+
+// createInterview saves an hour-long interview starting at the user's local
+// wall clock time for start.
+func (f *schedulerFixture) createInterview(t *testing.T, start time.Time, status string, interviewerNames ...string) *models.Interview {
+	local := start.In(f.loc)
+	clock := local.Format("15:04")
+	duration := 60
+
+	interview := testutil.CreateTestInterview(f.userID, f.applicationID, time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), models.InterviewTypeTechnical)
+	interview.ScheduledTime = &clock
+	interview.DurationMinutes = &duration
+	interview.Status = status
+
+	var interviewers []*models.Interviewer
+	for _, name := range interviewerNames {
+		interviewers = append(interviewers, &models.Interviewer{Name: name})
+	}
+
+	created, _, err := f.interviewRepo.CreateInterviewWithInterviewers(interview, interviewers)
+	require.NoError(t, err)
+	return created
+}
+
+func (f *schedulerFixture) status(t *testing.T, interviewID uuid.UUID) string {
+	var status string
+	require.NoError(t, f.db.Get(&status, "SELECT status FROM interviews WHERE id = $1", interviewID))
+	return status
+}
+
+func (f *schedulerFixture) notified(t *testing.T, interviewID uuid.UUID, reminderType string) bool {
+	exists, err := f.scheduler.notificationRepo.ExistsByLink(f.userID, fmt.Sprintf("/interviews/%s#%s", interviewID, reminderType))
+	require.NoError(t, err)
+	return exists
+}
+
+func TestNotificationScheduler_ProcessEndedInterviews(t *testing.T) {
+	f := newSchedulerFixture(t)
+	now := time.Now()
+
+	inProgress := f.createInterview(t, now.Add(-30*time.Minute), models.InterviewStatusScheduled)
+	upcoming := f.createInterview(t, now.Add(3*time.Hour), models.InterviewStatusScheduled)
+	ended := f.createInterview(t, now.Add(-3*time.Hour), models.InterviewStatusScheduled)
+
+	require.NoError(t, f.scheduler.processEndedInterviews(context.Background()))
+
+	assert.Equal(t, models.InterviewStatusScheduled, f.status(t, inProgress.ID), "still running in the user's time zone")
+	assert.Equal(t, models.InterviewStatusScheduled, f.status(t, upcoming.ID))
+	assert.False(t, f.notified(t, inProgress.ID, ReminderTypeReflection))
+
+	assert.Equal(t, models.InterviewStatusCompleted, f.status(t, ended.ID))
+	assert.True(t, f.notified(t, ended.ID, ReminderTypeReflection))
+}
+
+func TestNotificationScheduler_ProcessThankYouReminders(t *testing.T) {
+	f := newSchedulerFixture(t)
+	now := time.Now()
+
+	// Ended 20 hours ago locally, which read as UTC would be past the window
+	recent := f.createInterview(t, now.Add(-21*time.Hour), models.InterviewStatusCompleted, "Alex")
+	due := f.createInterview(t, now.Add(-27*time.Hour), models.InterviewStatusCompleted, "Sam")
+	noInterviewers := f.createInterview(t, now.Add(-27*time.Hour), models.InterviewStatusCompleted)
+
+	require.NoError(t, f.scheduler.processThankYouReminders(context.Background()))
+
+	assert.False(t, f.notified(t, recent.ID, ReminderTypeThankYou), "the window hasn't passed in the user's time zone")
+	assert.True(t, f.notified(t, due.ID, ReminderTypeThankYou))
+	assert.False(t, f.notified(t, noInterviewers.ID, ReminderTypeThankYou))
+
+	require.NoError(t, f.scheduler.processThankYouReminders(context.Background()))
+
+	var count int
+	require.NoError(t, f.db.Get(&count, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND link LIKE '%#' || $2", f.userID, ReminderTypeThankYou))
+	assert.Equal(t, 1, count, "reminded once")
+}
@@ -48,6 +48,9 @@ const (
 	ReminderType1h  = "1h"
 	ReminderType3d  = "3d"
 	ReminderType1d  = "1d"
+
+	ReminderTypeReflection = "reflection"
+	ReminderTypeThankYou   = "thank-you"
 )
 
 func (s *NotificationService) CreateInterviewReminder(interview *InterviewInfo, reminderType string) (*models.Notification, error) {
@@ -120,7 +123,7 @@ func (s *NotificationService) CreateAssessmentReminder(assessment *AssessmentInf
 	case ReminderType3d:
 		timeText = "due in 3 days"
 	case ReminderType1d:
-		timeText = "due tomorrow"
+		timeText = "due in 24 hours"
 	case ReminderType1h:
 		timeText = "due in 1 hour"
 	}
@@ -141,6 +144,64 @@ func (s *NotificationService) CreateAssessmentReminder(assessment *AssessmentInf
 	return s.notificationRepo.Create(notification)
 }
 
+// CreateReflectionPrompt asks the user to reflect on an interview that has
+// just finished.
+func (s *NotificationService) CreateReflectionPrompt(interview *InterviewInfo) (*models.Notification, error) {
+	title := "How did your interview go?"
+	message := fmt.Sprintf("Note what went well, what could improve and how confident you feel about your %s interview at %s for %s while it's fresh",
+		interview.InterviewType, interview.CompanyName, interview.JobTitle)
+	link := fmt.Sprintf("/interviews/%s#%s", interview.ID.String(), ReminderTypeReflection)
+
+	notification := &models.Notification{
+		UserID:  interview.UserID,
+		Type:    models.NotificationTypeReflectionPrompt,
+		Title:   title,
+		Message: message,
+		Link:    &link,
+		Read:    false,
+	}
+
+	return s.notificationRepo.Create(notification)
+}
+
+// CreateThankYouReminder reminds the user to thank the named interviewers.
+func (s *NotificationService) CreateThankYouReminder(interview *InterviewInfo, interviewerNames string) (*models.Notification, error) {
+	title := "Send thank-you notes"
+	message := fmt.Sprintf("No thank-you note recorded for %s after your %s interview at %s",
+		interviewerNames, interview.InterviewType, interview.CompanyName)
+	link := fmt.Sprintf("/interviews/%s#%s", interview.ID.String(), ReminderTypeThankYou)
+
+	notification := &models.Notification{
+		UserID:  interview.UserID,
+		Type:    models.NotificationTypeThankYouReminder,
+		Title:   title,
+		Message: message,
+		Link:    &link,
+		Read:    false,
+	}
+
+	return s.notificationRepo.Create(notification)
+}
+
+// CreateFileInfectedAlert tells a user that an upload was deleted because the
+// malware scan flagged it.
+func (s *NotificationService) CreateFileInfectedAlert(file *models.File, signature string) (*models.Notification, error) {
+	title := "Upload removed"
+	message := fmt.Sprintf("%s was deleted because a malware scan found %s", file.FileName, signature)
+	link := fmt.Sprintf("/applications/%s", file.ApplicationID.String())
+
+	notification := &models.Notification{
+		UserID:  file.UserID,
+		Type:    models.NotificationTypeFileInfected,
+		Title:   title,
+		Message: message,
+		Link:    &link,
+		Read:    false,
+	}
+
+	return s.notificationRepo.Create(notification)
+}
+
 func (s *NotificationService) CreateSystemAlert(userID uuid.UUID, title, message string, link *string) (*models.Notification, error) {
 	notification := &models.Notification{
 		UserID:  userID,
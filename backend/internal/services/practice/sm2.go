@@ -0,0 +1,73 @@
+// Package practice schedules interview question reviews with the SM-2
+// spaced-repetition algorithm: questions answered well come back after
+// increasingly long gaps, questions answered badly come back tomorrow.
+package practice
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// MinGrade and MaxGrade bound the self-assessed grade of a review. Grades
+	// below PassingGrade count as a lapse.
+	MinGrade     = 0
+	MaxGrade     = 5
+	PassingGrade = 3
+
+	// DefaultEaseFactor is the ease of a card that has never been reviewed.
+	DefaultEaseFactor = 2.5
+	// MinEaseFactor stops hard cards from being scheduled ever more often.
+	MinEaseFactor = 1.3
+)
+
+// State is the scheduling state of one card.
+type State struct {
+	EaseFactor   float64
+	IntervalDays int
+	Repetitions  int
+}
+
+// NewState is the state of a card that has never been reviewed.
+func NewState() State {
+	return State{EaseFactor: DefaultEaseFactor}
+}
+
+// Review applies a grade to a card reviewed at reviewedAt and returns its
+// new state and when it is next due.
+func Review(state State, grade int, reviewedAt time.Time) (State, time.Time) {
+	if grade < MinGrade {
+		grade = MinGrade
+	}
+	if grade > MaxGrade {
+		grade = MaxGrade
+	}
+	if state.EaseFactor < MinEaseFactor {
+		state.EaseFactor = DefaultEaseFactor
+	}
+
+	next := state
+	if grade < PassingGrade {
+		next.Repetitions = 0
+		next.IntervalDays = 1
+	} else {
+		switch state.Repetitions {
+		case 0:
+			next.IntervalDays = 1
+		case 1:
+			next.IntervalDays = 6
+		default:
+			next.IntervalDays = int(math.Round(float64(state.IntervalDays) * state.EaseFactor))
+		}
+		next.Repetitions = state.Repetitions + 1
+	}
+
+	missed := float64(MaxGrade - grade)
+	next.EaseFactor = state.EaseFactor + (0.1 - missed*(0.08+missed*0.02))
+	if next.EaseFactor < MinEaseFactor {
+		next.EaseFactor = MinEaseFactor
+	}
+	next.EaseFactor = math.Round(next.EaseFactor*100) / 100
+
+	return next, reviewedAt.AddDate(0, 0, next.IntervalDays)
+}
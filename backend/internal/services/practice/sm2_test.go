@@ -0,0 +1,53 @@
+package practice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReview(t *testing.T) {
+	reviewedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("intervals grow with successful reviews", func(t *testing.T) {
+		state := NewState()
+
+		state, due := Review(state, 5, reviewedAt)
+		assert.Equal(t, 1, state.IntervalDays)
+		assert.Equal(t, 1, state.Repetitions)
+		assert.Equal(t, 2.6, state.EaseFactor)
+		assert.Equal(t, reviewedAt.AddDate(0, 0, 1), due)
+
+		state, _ = Review(state, 4, reviewedAt)
+		assert.Equal(t, 6, state.IntervalDays)
+		assert.Equal(t, 2.6, state.EaseFactor)
+
+		state, due = Review(state, 4, reviewedAt)
+		assert.Equal(t, 16, state.IntervalDays)
+		assert.Equal(t, 3, state.Repetitions)
+		assert.Equal(t, reviewedAt.AddDate(0, 0, 16), due)
+	})
+
+	t.Run("a lapse resets the interval and lowers ease", func(t *testing.T) {
+		state := State{EaseFactor: 2.5, IntervalDays: 15, Repetitions: 4}
+
+		state, due := Review(state, 1, reviewedAt)
+		assert.Equal(t, 1, state.IntervalDays)
+		assert.Equal(t, 0, state.Repetitions)
+		assert.Equal(t, 1.96, state.EaseFactor)
+		assert.Equal(t, reviewedAt.AddDate(0, 0, 1), due)
+	})
+
+	t.Run("ease never drops below the minimum", func(t *testing.T) {
+		state := State{EaseFactor: MinEaseFactor}
+		state, _ = Review(state, 0, reviewedAt)
+		assert.Equal(t, MinEaseFactor, state.EaseFactor)
+	})
+
+	t.Run("grades are clamped", func(t *testing.T) {
+		high, _ := Review(NewState(), 9, reviewedAt)
+		five, _ := Review(NewState(), 5, reviewedAt)
+		assert.Equal(t, five, high)
+	})
+}
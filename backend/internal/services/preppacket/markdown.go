@@ -0,0 +1,27 @@
+package preppacket
+
+import "strings"
+
+// Markdown renders the packet as a Markdown document.
+func (p *Packet) Markdown() string {
+	var sb strings.Builder
+
+	for _, b := range p.blocks() {
+		switch b.kind {
+		case blockTitle:
+			sb.WriteString("# " + b.text + "\n\n")
+		case blockHeading:
+			sb.WriteString("## " + b.text + "\n\n")
+		case blockSubheading:
+			sb.WriteString("### " + b.text + "\n\n")
+		case blockParagraph:
+			sb.WriteString(b.text + "\n\n")
+		case blockBullet:
+			sb.WriteString("- " + b.text + "\n\n")
+		case blockField:
+			sb.WriteString("**" + b.label + ":** " + b.text + "\n\n")
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
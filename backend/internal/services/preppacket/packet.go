@@ -0,0 +1,259 @@
+// Package preppacket builds the prep packet for an interview: the role, the
+// company research and preparation notes, who is on the panel, what happened
+// in earlier rounds and which files are attached. The packet renders to
+// Markdown or PDF with the same content.
+package preppacket
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"ditto-backend/internal/models"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatPDF      = "pdf"
+)
+
+type Packet struct {
+	CompanyName    string
+	JobTitle       string
+	JobDescription string
+	Interview      Round
+	CompanyNotes   string
+	Notes          []Note
+	PreviousRounds []Round
+	Files          []File
+	GeneratedAt    time.Time
+}
+
+// Round is one interview of the application. Interviewers, Questions and
+// Feedback are only filled in for earlier rounds and the interview itself.
+type Round struct {
+	Number          int
+	Type            string
+	Date            time.Time
+	Time            string
+	DurationMinutes int
+	Status          string
+	Outcome         string
+	Interviewers    []Person
+	Questions       []Question
+	Feedback        string
+}
+
+type Person struct {
+	Name string
+	Role string
+}
+
+type Question struct {
+	Text   string
+	Answer string
+}
+
+// Note is an interview note already converted to text with NoteText.
+type Note struct {
+	Type string
+	Text string
+}
+
+type File struct {
+	Name string
+	Type string
+	Size int64
+}
+
+// block is one piece of packet content. Both renderers walk the same blocks,
+// so the Markdown and PDF packets never drift apart.
+type block struct {
+	kind  blockKind
+	text  string
+	label string
+}
+
+type blockKind int
+
+const (
+	blockTitle blockKind = iota
+	blockHeading
+	blockSubheading
+	blockParagraph
+	blockBullet
+	blockField
+)
+
+func (p *Packet) blocks() []block {
+	var b []block
+	add := func(kind blockKind, text string) {
+		b = append(b, block{kind: kind, text: text})
+	}
+	field := func(label, value string) {
+		if value != "" {
+			b = append(b, block{kind: blockField, label: label, text: value})
+		}
+	}
+
+	add(blockTitle, fmt.Sprintf("Prep packet: %s, %s", p.CompanyName, p.JobTitle))
+	field("Interview", fmt.Sprintf("Round %d, %s", p.Interview.Number, humanize(p.Interview.Type)))
+	field("When", roundWhen(p.Interview))
+	if p.Interview.DurationMinutes > 0 {
+		field("Duration", fmt.Sprintf("%d minutes", p.Interview.DurationMinutes))
+	}
+	field("Generated", p.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	add(blockHeading, "Interviewers")
+	if len(p.Interview.Interviewers) == 0 {
+		add(blockParagraph, "No interviewers recorded.")
+	}
+	for _, person := range p.Interview.Interviewers {
+		add(blockBullet, person.String())
+	}
+
+	add(blockHeading, "Job description")
+	add(blockParagraph, orNone(p.JobDescription, "No job description saved."))
+
+	add(blockHeading, "Company research")
+	research := false
+	if p.CompanyNotes != "" {
+		add(blockSubheading, "Company notes")
+		add(blockParagraph, p.CompanyNotes)
+		research = true
+	}
+	for _, note := range p.Notes {
+		if note.Type == models.NoteTypeCompanyResearch && note.Text != "" {
+			add(blockSubheading, "Research for this round")
+			add(blockParagraph, note.Text)
+			research = true
+		}
+	}
+	if !research {
+		add(blockParagraph, "No company research yet.")
+	}
+
+	add(blockHeading, "Preparation notes")
+	preparation := false
+	for _, note := range p.Notes {
+		if note.Type != models.NoteTypeCompanyResearch && note.Text != "" {
+			add(blockSubheading, humanize(note.Type))
+			add(blockParagraph, note.Text)
+			preparation = true
+		}
+	}
+	if !preparation {
+		add(blockParagraph, "No preparation notes yet.")
+	}
+
+	add(blockHeading, "Previous rounds")
+	if len(p.PreviousRounds) == 0 {
+		add(blockParagraph, "This is the first round.")
+	}
+	for _, round := range p.PreviousRounds {
+		add(blockSubheading, fmt.Sprintf("Round %d: %s", round.Number, humanize(round.Type)))
+		field("When", roundWhen(round))
+		field("Status", humanize(round.Status))
+		field("Outcome", humanize(round.Outcome))
+		if len(round.Interviewers) > 0 {
+			names := make([]string, len(round.Interviewers))
+			for i, person := range round.Interviewers {
+				names[i] = person.String()
+			}
+			field("Interviewers", strings.Join(names, "; "))
+		}
+		for i, question := range round.Questions {
+			add(blockBullet, fmt.Sprintf("Q%d. %s", i+1, question.Text))
+			if question.Answer != "" {
+				add(blockParagraph, question.Answer)
+			}
+		}
+		if round.Feedback != "" {
+			field("Feedback", round.Feedback)
+		}
+	}
+
+	add(blockHeading, "Attached files")
+	if len(p.Files) == 0 {
+		add(blockParagraph, "No files attached.")
+	}
+	for _, file := range p.Files {
+		add(blockBullet, fmt.Sprintf("%s (%s, %s)", file.Name, file.Type, formatSize(file.Size)))
+	}
+
+	return b
+}
+
+func (p Person) String() string {
+	if p.Role == "" {
+		return p.Name
+	}
+	return p.Name + ", " + p.Role
+}
+
+func roundWhen(round Round) string {
+	if round.Date.IsZero() {
+		return ""
+	}
+	when := round.Date.Format("Monday, January 2, 2006")
+	if round.Time != "" {
+		when += " at " + round.Time
+	}
+	return when
+}
+
+// humanize turns stored identifiers like "phone_screen" into "Phone screen".
+func humanize(value string) string {
+	if value == "" {
+		return ""
+	}
+	value = strings.ReplaceAll(value, "_", " ")
+	return strings.ToUpper(value[:1]) + value[1:]
+}
+
+func orNone(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
+var (
+	lineBreakTags = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6]|tr|blockquote|pre)>`)
+	listItemTags  = regexp.MustCompile(`(?i)<li[^>]*>`)
+	extraNewlines = regexp.MustCompile(`\n{3,}`)
+	stripAllTags  = bluemonday.StrictPolicy()
+)
+
+// NoteText returns note content as plain text for the packet. HTML notes lose
+// their markup but keep line breaks and list bullets; Markdown is already
+// readable and is kept as written.
+func NoteText(content *string, format string) string {
+	if content == nil {
+		return ""
+	}
+	if format == models.NoteFormatMarkdown {
+		return strings.TrimSpace(*content)
+	}
+
+	text := lineBreakTags.ReplaceAllString(*content, "\n")
+	text = listItemTags.ReplaceAllString(text, "- ")
+	text = html.UnescapeString(stripAllTags.Sanitize(text))
+	text = extraNewlines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,89 @@
+package preppacket
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ditto-backend/internal/models"
+)
+
+func testPacket() *Packet {
+	return &Packet{
+		CompanyName:    "Acme",
+		JobTitle:       "Backend Engineer",
+		JobDescription: "Build payment APIs in Go.",
+		Interview: Round{
+			Number:       3,
+			Type:         models.InterviewTypeOnsite,
+			Date:         time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			Time:         "10:00",
+			Interviewers: []Person{{Name: "Sam Lee", Role: "Staff Engineer"}, {Name: "Kim Park"}},
+		},
+		CompanyNotes: "Series C, ~400 people.",
+		Notes: []Note{
+			{Type: models.NoteTypeCompanyResearch, Text: "Launched in Europe last year."},
+			{Type: models.NoteTypePreparation, Text: "Review idempotency keys."},
+		},
+		PreviousRounds: []Round{{
+			Number:       1,
+			Type:         models.InterviewTypePhoneScreen,
+			Date:         time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC),
+			Outcome:      "passed",
+			Interviewers: []Person{{Name: "Recruiter Rae"}},
+			Questions:    []Question{{Text: "Why Acme?", Answer: "Payments at scale."}},
+			Feedback:     "Strong motivation.",
+		}},
+		Files:       []File{{Name: "resume.pdf", Type: "application/pdf", Size: 245760}},
+		GeneratedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	md := testPacket().Markdown()
+
+	assert.Contains(t, md, "# Prep packet: Acme, Backend Engineer\n")
+	assert.Contains(t, md, "**Interview:** Round 3, Onsite")
+	assert.Contains(t, md, "**When:** Monday, March 2, 2026 at 10:00")
+	assert.Contains(t, md, "- Sam Lee, Staff Engineer")
+	assert.Contains(t, md, "- Kim Park\n")
+	assert.Contains(t, md, "Build payment APIs in Go.")
+	assert.Contains(t, md, "Series C, ~400 people.")
+	assert.Contains(t, md, "Launched in Europe last year.")
+	assert.Contains(t, md, "### Preparation\n\nReview idempotency keys.")
+	assert.Contains(t, md, "### Round 1: Phone screen")
+	assert.Contains(t, md, "- Q1. Why Acme?\n\nPayments at scale.")
+	assert.Contains(t, md, "**Feedback:** Strong motivation.")
+	assert.Contains(t, md, "- resume.pdf (application/pdf, 240.0 KB)")
+}
+
+func TestMarkdown_EmptySections(t *testing.T) {
+	md := (&Packet{CompanyName: "Acme", JobTitle: "Engineer", Interview: Round{Number: 1, Type: "technical"}}).Markdown()
+
+	assert.Contains(t, md, "No interviewers recorded.")
+	assert.Contains(t, md, "No job description saved.")
+	assert.Contains(t, md, "No company research yet.")
+	assert.Contains(t, md, "No preparation notes yet.")
+	assert.Contains(t, md, "This is the first round.")
+	assert.Contains(t, md, "No files attached.")
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testPacket().WritePDF(&buf))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Greater(t, buf.Len(), 1000)
+}
+
+func TestNoteText(t *testing.T) {
+	content := "<p>Ask about <strong>on-call</strong> &amp; pager load</p><ul><li>team size</li><li>roadmap</li></ul>"
+	assert.Equal(t, "Ask about on-call & pager load\n- team size\n- roadmap", NoteText(&content, models.NoteFormatHTML))
+
+	markdown := "  **Ask** about on-call\n"
+	assert.Equal(t, "**Ask** about on-call", NoteText(&markdown, models.NoteFormatMarkdown))
+
+	assert.Equal(t, "", NoteText(nil, models.NoteFormatHTML))
+}
@@ -0,0 +1,69 @@
+package preppacket
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfFont       = "Helvetica"
+	pdfLineHeight = 5.5
+	pdfIndent     = 5.0
+)
+
+// WritePDF renders the packet as an A4 PDF. The built-in fonts only cover
+// Latin-1, so characters outside it are printed as replacements.
+func (p *Packet) WritePDF(w io.Writer) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetTitle(fmt.Sprintf("Prep packet: %s, %s", p.CompanyName, p.JobTitle), true)
+	pdf.SetCreator("Ditto", true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(pdfFont, "", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	left, _, _, _ := pdf.GetMargins()
+
+	for _, b := range p.blocks() {
+		switch b.kind {
+		case blockTitle:
+			pdf.SetFont(pdfFont, "B", 18)
+			pdf.MultiCell(0, 9, tr(b.text), "", "L", false)
+			pdf.Ln(3)
+		case blockHeading:
+			pdf.Ln(4)
+			pdf.SetFont(pdfFont, "B", 14)
+			pdf.MultiCell(0, 7, tr(b.text), "B", "L", false)
+			pdf.Ln(2)
+		case blockSubheading:
+			pdf.Ln(1)
+			pdf.SetFont(pdfFont, "B", 11)
+			pdf.MultiCell(0, 6, tr(b.text), "", "L", false)
+		case blockParagraph:
+			pdf.SetFont(pdfFont, "", 10)
+			pdf.MultiCell(0, pdfLineHeight, tr(b.text), "", "L", false)
+			pdf.Ln(1.5)
+		case blockBullet:
+			pdf.SetFont(pdfFont, "", 10)
+			pdf.SetX(left + pdfIndent)
+			pdf.MultiCell(0, pdfLineHeight, tr("- "+b.text), "", "L", false)
+			pdf.Ln(0.5)
+		case blockField:
+			pdf.SetFont(pdfFont, "B", 10)
+			pdf.Write(pdfLineHeight, tr(b.label+": "))
+			pdf.SetFont(pdfFont, "", 10)
+			pdf.Write(pdfLineHeight, tr(b.text))
+			pdf.Ln(pdfLineHeight + 0.5)
+		}
+	}
+
+	return pdf.Output(w)
+}
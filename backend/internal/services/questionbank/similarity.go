@@ -0,0 +1,114 @@
+// Package questionbank matches free-text interview questions against the
+// canonical questions in a user's question bank, so the same question logged
+// at different companies can be linked to one entry.
+package questionbank
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DefaultThreshold is the minimum similarity for a bank entry to be suggested
+// for a question.
+const DefaultThreshold = 0.5
+
+// stopwords carry no meaning about which question was asked ("how would you
+// design a ..." vs "design a ...").
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "of": true,
+	"to": true, "in": true, "on": true, "for": true, "with": true, "at": true,
+	"by": true, "from": true, "is": true, "are": true, "was": true, "be": true,
+	"it": true, "this": true, "that": true, "what": true, "how": true,
+	"why": true, "when": true, "which": true, "would": true, "could": true,
+	"should": true, "can": true, "do": true, "does": true, "did": true,
+	"you": true, "your": true, "we": true, "i": true, "me": true, "my": true,
+	"about": true, "tell": true, "describe": true, "explain": true,
+}
+
+// Candidate is a bank entry considered for a match.
+type Candidate struct {
+	ID           uuid.UUID `db:"id"`
+	QuestionText string    `db:"question_text"`
+}
+
+// Suggestion is a bank entry that looks like the same question.
+type Suggestion struct {
+	ID           uuid.UUID `json:"id"`
+	QuestionText string    `json:"question_text"`
+	Score        float64   `json:"score"`
+}
+
+// Tokenize lower-cases text, splits it into words, drops stopwords and strips
+// a plural "s" so "Design rate limiters" and "design a rate limiter" agree.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if stopwords[field] {
+			continue
+		}
+		if len(field) > 3 && strings.HasSuffix(field, "s") && !strings.HasSuffix(field, "ss") {
+			field = strings.TrimSuffix(field, "s")
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// Similarity is the Dice coefficient of the two texts' token sets, from 0 (no
+// words in common) to 1 (same words).
+func Similarity(a, b string) float64 {
+	setA := tokenSet(a)
+	setB := tokenSet(b)
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for token := range setA {
+		if setB[token] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(setA)+len(setB))
+}
+
+// Suggest returns up to limit candidates scoring at least threshold against
+// text, best first.
+func Suggest(text string, candidates []Candidate, limit int, threshold float64) []Suggestion {
+	suggestions := []Suggestion{}
+	for _, candidate := range candidates {
+		score := Similarity(text, candidate.QuestionText)
+		if score < threshold {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			ID:           candidate.ID,
+			QuestionText: candidate.QuestionText,
+			Score:        score,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func tokenSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, token := range Tokenize(text) {
+		set[token] = true
+	}
+	return set
+}
@@ -0,0 +1,41 @@
+package questionbank
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"design", "rate", "limiter"}, Tokenize("How would you design a Rate-Limiter?"))
+	assert.Equal(t, []string{"design", "rate", "limiter"}, Tokenize("Design rate limiters"))
+	assert.Equal(t, []string{"access", "queue"}, Tokenize("access queues"))
+	assert.Empty(t, Tokenize("How would you?"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("Design a rate limiter", "How would you design rate limiters?"))
+	assert.Equal(t, 0.0, Similarity("Design a rate limiter", "Reverse a linked list"))
+	assert.Equal(t, 0.0, Similarity("", "Reverse a linked list"))
+	assert.InDelta(t, 6.0/7.0, Similarity("Design a distributed rate limiter", "Design a rate limiter"), 0.001)
+}
+
+func TestSuggest(t *testing.T) {
+	rateLimiter := Candidate{ID: uuid.New(), QuestionText: "Design a rate limiter"}
+	distributed := Candidate{ID: uuid.New(), QuestionText: "Design a distributed rate limiter for an API"}
+	linkedList := Candidate{ID: uuid.New(), QuestionText: "Reverse a linked list"}
+	candidates := []Candidate{linkedList, distributed, rateLimiter}
+
+	suggestions := Suggest("How would you design a rate limiter?", candidates, 5, DefaultThreshold)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, rateLimiter.ID, suggestions[0].ID)
+	assert.Equal(t, distributed.ID, suggestions[1].ID)
+
+	suggestions = Suggest("How would you design a rate limiter?", candidates, 1, DefaultThreshold)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, rateLimiter.ID, suggestions[0].ID)
+
+	assert.Empty(t, Suggest("Tell me about yourself", candidates, 5, DefaultThreshold))
+}
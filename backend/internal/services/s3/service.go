@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,6 +21,8 @@ type S3ServiceInterface interface {
 	GeneratePresignedGetURL(ctx context.Context, s3Key string) (string, error)
 	HeadObject(ctx context.Context, s3Key string) (bool, error)
 	DeleteObject(ctx context.Context, s3Key string) error
+	PutObject(ctx context.Context, s3Key, contentType string, body io.Reader, size int64) error
+	GetObject(ctx context.Context, s3Key string) (io.ReadCloser, error)
 }
 
 type S3Service struct {
@@ -63,7 +67,43 @@ func NewS3Service(cfg Config) (*S3Service, error) {
 	}, nil
 }
 
+// assessmentKeyDir holds uploads presigned for assessments, which may be
+// larger and include ZIP archives.
+const assessmentKeyDir = "assessments/"
+
 func GenerateS3Key(userID uuid.UUID, fileName string) string {
+	return generateKey(userID.String()+"/", fileName)
+}
+
+// GenerateAssessmentS3Key is GenerateS3Key for assessment uploads. The key
+// itself records the upload's purpose, so later steps needn't trust the
+// client to repeat it.
+func GenerateAssessmentS3Key(userID uuid.UUID, fileName string) string {
+	return generateKey(userID.String()+"/"+assessmentKeyDir, fileName)
+}
+
+// IsUserKey reports whether s3Key has the shape of a key generated for
+// userID, so a client can't point an upload step at someone else's object.
+func IsUserKey(s3Key string, userID uuid.UUID) bool {
+	name, ok := strings.CutPrefix(s3Key, userID.String()+"/")
+	if !ok {
+		return false
+	}
+	name = strings.TrimPrefix(name, assessmentKeyDir)
+	if len(name) < 36 || strings.ContainsAny(name, "/\\") {
+		return false
+	}
+	_, err := uuid.Parse(name[:36])
+	return err == nil
+}
+
+// IsAssessmentKey reports whether s3Key was generated for an assessment
+// upload.
+func IsAssessmentKey(s3Key string, userID uuid.UUID) bool {
+	return IsUserKey(s3Key, userID) && strings.HasPrefix(s3Key, userID.String()+"/"+assessmentKeyDir)
+}
+
+func generateKey(prefix, fileName string) string {
 	fileUUID := uuid.New()
 
 	ext := ""
@@ -74,7 +114,7 @@ func GenerateS3Key(userID uuid.UUID, fileName string) string {
 		}
 	}
 
-	return fmt.Sprintf("%s/%s%s", userID.String(), fileUUID.String(), ext)
+	return fmt.Sprintf("%s%s%s", prefix, fileUUID.String(), ext)
 }
 
 func (s *S3Service) GeneratePresignedPutURL(ctx context.Context, s3Key, contentType string) (string, error) {
@@ -130,3 +170,34 @@ func (s *S3Service) DeleteObject(ctx context.Context, s3Key string) error {
 
 	return nil
 }
+
+// PutObject uploads from the server itself, for files the backend fetches
+// rather than ones the browser uploads through a presigned URL.
+func (s *S3Service) PutObject(ctx context.Context, s3Key, contentType string, body io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(s3Key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+		Body:          body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+
+	return nil
+}
+
+// GetObject streams an object to the server, e.g. for malware scanning. The
+// caller closes the body.
+func (s *S3Service) GetObject(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s3Key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	return output.Body, nil
+}
@@ -104,6 +104,33 @@ func TestGenerateS3Key_Uniqueness(t *testing.T) {
 	assert.Equal(t, 100, len(keys), "Should generate 100 unique keys")
 }
 
+func TestUploadKeyOwnership(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	otherID := uuid.MustParse("987fcdeb-51a2-43d7-9876-543210fedcba")
+
+	fileKey := GenerateS3Key(userID, "resume.pdf")
+	assessmentKey := GenerateAssessmentS3Key(userID, "solution.zip")
+
+	assert.True(t, IsUserKey(fileKey, userID))
+	assert.True(t, IsUserKey(assessmentKey, userID))
+	assert.False(t, IsAssessmentKey(fileKey, userID))
+	assert.True(t, IsAssessmentKey(assessmentKey, userID))
+
+	assert.False(t, IsUserKey(fileKey, otherID))
+	assert.False(t, IsAssessmentKey(assessmentKey, otherID))
+
+	for _, key := range []string{
+		"",
+		userID.String() + "/",
+		userID.String() + "/resume.pdf",
+		userID.String() + "/" + uuid.NewString() + "/../" + otherID.String() + "/x.pdf",
+		userID.String() + "/assessments/assessments/" + uuid.NewString() + ".zip",
+		"users/" + userID.String() + "/" + uuid.NewString() + ".pdf",
+	} {
+		assert.False(t, IsUserKey(key, userID), key)
+	}
+}
+
 func TestS3Service_Integration(t *testing.T) {
 	// Skip if not running integration tests
 	if testing.Short() {
@@ -0,0 +1,151 @@
+// Package schedule finds clashes between interviews and assessment deadlines.
+// An interview occupies a block of time from its start until its duration has
+// elapsed; an assessment deadline is a single instant. Two events conflict
+// when they come closer together than the configured buffer.
+package schedule
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindInterview  = "interview"
+	KindAssessment = "assessment"
+)
+
+// DefaultDuration is assumed for interviews booked without a duration.
+const DefaultDuration = 60 * time.Minute
+
+var timeLayouts = []string{"15:04:05", "15:04", "3:04 PM", "3:04PM", "3:04 pm", "3:04pm"}
+
+// Event is an interview or assessment deadline on the calendar. Deadlines have
+// End equal to Start.
+type Event struct {
+	Kind          string    `json:"kind"`
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	Title         string    `json:"title"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+}
+
+// Conflict is a pair of events that are too close together, earliest first.
+type Conflict struct {
+	First  Event `json:"first"`
+	Second Event `json:"second"`
+}
+
+// InterviewWindow returns when an interview starts and ends, reading its
+// wall-clock time in loc. Interviews without a parseable start time cannot be
+// placed and report ok == false.
+func InterviewWindow(date time.Time, scheduledTime *string, durationMinutes *int, loc *time.Location) (start, end time.Time, ok bool) {
+	if scheduledTime == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	clock, ok := parseClock(*scheduledTime)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start = time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+	duration := DefaultDuration
+	if durationMinutes != nil && *durationMinutes > 0 {
+		duration = time.Duration(*durationMinutes) * time.Minute
+	}
+	return start, start.Add(duration), true
+}
+
+// deadlineLayouts are the forms an assessment deadline can be given in,
+// from most to least specific. Layouts without an offset are read in the
+// assessment's own time zone.
+var deadlineLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// EndOfDayClock is when a deadline given as a bare date is due.
+const EndOfDayClock = 23*time.Hour + 59*time.Minute
+
+// ParseDeadline reads an assessment deadline. A timestamp with an offset is
+// taken as is; one without is a wall-clock time in loc. A bare date is due at
+// the end of that day in loc.
+func ParseDeadline(value string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range deadlineLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day.Add(EndOfDayClock), true
+}
+
+func parseClock(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Conflicts returns every pair of events closer together than buffer.
+// Two deadlines never conflict with each other since neither blocks time.
+func Conflicts(events []Event, buffer time.Duration) []Conflict {
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var conflicts []Conflict
+	for i, first := range sorted {
+		for _, second := range sorted[i+1:] {
+			// Everything after this starts later still, so nothing else can
+			// reach back to first.
+			if second.Start.After(first.End.Add(buffer)) {
+				break
+			}
+			if overlaps(first, second, buffer) {
+				conflicts = append(conflicts, Conflict{First: first, Second: second})
+			}
+		}
+	}
+	return conflicts
+}
+
+// Overlapping returns the events in others that conflict with event.
+func Overlapping(event Event, others []Event, buffer time.Duration) []Event {
+	var result []Event
+	for _, other := range others {
+		if other.Kind == event.Kind && other.ID == event.ID {
+			continue
+		}
+		if overlaps(event, other, buffer) {
+			result = append(result, other)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
+
+func overlaps(a, b Event, buffer time.Duration) bool {
+	if a.Kind == KindAssessment && b.Kind == KindAssessment {
+		return false
+	}
+	// A deadline touching the edge of an interview still counts, so compare
+	// inclusively when one side is an instant.
+	if a.Start.Equal(a.End) || b.Start.Equal(b.End) {
+		return !a.Start.After(b.End.Add(buffer)) && !b.Start.After(a.End.Add(buffer))
+	}
+	return a.Start.Before(b.End.Add(buffer)) && b.Start.Before(a.End.Add(buffer))
+}
@@ -0,0 +1,153 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func interviewAt(t *testing.T, clock string, minutes int) Event {
+	t.Helper()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	start, end, ok := InterviewWindow(day, &clock, &minutes, time.UTC)
+	require.True(t, ok)
+	return Event{Kind: KindInterview, ID: uuid.New(), Start: start, End: end}
+}
+
+func TestInterviewWindow(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("parses database and user formats", func(t *testing.T) {
+		for _, clock := range []string{"14:30:00", "14:30", "2:30 PM", "2:30pm"} {
+			start, end, ok := InterviewWindow(day, &clock, nil, time.UTC)
+			require.True(t, ok, clock)
+			assert.Equal(t, time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC), start, clock)
+			assert.Equal(t, start.Add(DefaultDuration), end, clock)
+		}
+	})
+
+	t.Run("uses duration", func(t *testing.T) {
+		clock := "09:00"
+		minutes := 45
+		start, end, ok := InterviewWindow(day, &clock, &minutes, time.UTC)
+		require.True(t, ok)
+		assert.Equal(t, 45*time.Minute, end.Sub(start))
+	})
+
+	t.Run("no time cannot be placed", func(t *testing.T) {
+		_, _, ok := InterviewWindow(day, nil, nil, time.UTC)
+		assert.False(t, ok)
+
+		bad := "sometime"
+		_, _, ok = InterviewWindow(day, &bad, nil, time.UTC)
+		assert.False(t, ok)
+	})
+}
+
+func TestParseDeadline(t *testing.T) {
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	t.Run("bare date is due at the end of the day", func(t *testing.T) {
+		due, ok := ParseDeadline("2026-03-10", pacific)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 10, 23, 59, 0, 0, pacific), due)
+	})
+
+	t.Run("wall-clock time is read in the time zone", func(t *testing.T) {
+		due, ok := ParseDeadline("2026-03-10T17:00", pacific)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), due.UTC())
+	})
+
+	t.Run("an explicit offset wins", func(t *testing.T) {
+		due, ok := ParseDeadline("2026-03-10T17:00:00+01:00", pacific)
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 10, 16, 0, 0, 0, time.UTC), due.UTC())
+	})
+
+	t.Run("unparseable", func(t *testing.T) {
+		_, ok := ParseDeadline("soon", time.UTC)
+		assert.False(t, ok)
+	})
+}
+
+func TestConflicts(t *testing.T) {
+	t.Run("overlapping interviews", func(t *testing.T) {
+		a := interviewAt(t, "10:00", 60)
+		b := interviewAt(t, "10:30", 60)
+		c := interviewAt(t, "14:00", 60)
+
+		conflicts := Conflicts([]Event{c, b, a}, 0)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, a.ID, conflicts[0].First.ID)
+		assert.Equal(t, b.ID, conflicts[0].Second.ID)
+	})
+
+	t.Run("back to back only conflicts within buffer", func(t *testing.T) {
+		a := interviewAt(t, "10:00", 60)
+		b := interviewAt(t, "11:00", 60)
+
+		assert.Empty(t, Conflicts([]Event{a, b}, 0))
+		assert.Len(t, Conflicts([]Event{a, b}, 15*time.Minute), 1)
+	})
+
+	t.Run("long interview overlaps several later ones", func(t *testing.T) {
+		onsite := interviewAt(t, "09:00", 300)
+		a := interviewAt(t, "10:00", 30)
+		b := interviewAt(t, "13:00", 30)
+
+		assert.Len(t, Conflicts([]Event{onsite, a, b}, 0), 2)
+	})
+
+	t.Run("deadline during interview", func(t *testing.T) {
+		late := interviewAt(t, "23:30", 60)
+		due, _ := ParseDeadline("2026-03-10", time.UTC)
+		deadline := Event{Kind: KindAssessment, ID: uuid.New(), Start: due, End: due}
+
+		conflicts := Conflicts([]Event{deadline, late}, 0)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, KindInterview, conflicts[0].First.Kind)
+	})
+
+	t.Run("deadline and interview in another zone", func(t *testing.T) {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		// 10:00 in Berlin is 09:00 UTC, when the assessment is due
+		clock, minutes := "10:00", 30
+		start, end, ok := InterviewWindow(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), &clock, &minutes, berlin)
+		require.True(t, ok)
+		interview := Event{Kind: KindInterview, ID: uuid.New(), Start: start, End: end}
+
+		due, _ := ParseDeadline("2026-03-10T09:00:00Z", time.UTC)
+		deadline := Event{Kind: KindAssessment, ID: uuid.New(), Start: due, End: due}
+
+		assert.Len(t, Conflicts([]Event{interview, deadline}, 0), 1)
+
+		later, _ := ParseDeadline("2026-03-10T10:00:00Z", time.UTC)
+		afterInterview := Event{Kind: KindAssessment, ID: uuid.New(), Start: later, End: later}
+		assert.Empty(t, Conflicts([]Event{interview, afterInterview}, 15*time.Minute))
+	})
+
+	t.Run("deadlines never conflict with each other", func(t *testing.T) {
+		due, _ := ParseDeadline("2026-03-10", time.UTC)
+		a := Event{Kind: KindAssessment, ID: uuid.New(), Start: due, End: due}
+		b := Event{Kind: KindAssessment, ID: uuid.New(), Start: due, End: due}
+
+		assert.Empty(t, Conflicts([]Event{a, b}, time.Hour))
+	})
+}
+
+func TestOverlapping(t *testing.T) {
+	a := interviewAt(t, "10:00", 60)
+	b := interviewAt(t, "10:30", 60)
+	c := interviewAt(t, "16:00", 60)
+
+	result := Overlapping(a, []Event{a, c, b}, 0)
+	require.Len(t, result, 1)
+	assert.Equal(t, b.ID, result[0].ID)
+}
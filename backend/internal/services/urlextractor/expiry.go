@@ -0,0 +1,195 @@
+package urlextractor
+
+import (
+	"bytes"
+	"context"
+	"ditto-backend/pkg/errors"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	ExpiryReasonNotFound     = "not_found"
+	ExpiryReasonClosedMarker = "closed_marker"
+	ExpiryReasonValidThrough = "valid_through"
+)
+
+// closedPostingMarkers are phrases job boards use on postings that are still
+// reachable but no longer open. Matched case-insensitively against page text.
+var closedPostingMarkers = []string{
+	"no longer accepting applications",
+	"this job is no longer available",
+	"this job has expired",
+	"this position has been filled",
+	"this job posting has expired",
+	"job is closed",
+	"position is no longer available",
+}
+
+// ExpiryStatus is the result of re-checking a previously extracted job posting.
+type ExpiryStatus struct {
+	Expired      bool       `json:"expired"`
+	Reason       string     `json:"reason,omitempty"`
+	ValidThrough *time.Time `json:"valid_through,omitempty"`
+}
+
+// ExpiryChecker re-fetches a job posting URL and decides whether the posting
+// has been taken down or closed.
+type ExpiryChecker interface {
+	CheckExpiry(ctx context.Context, urlStr string) (*ExpiryStatus, error)
+}
+
+type expiryChecker struct {
+	fetcher HTTPFetcher
+	logger  *log.Logger
+	now     func() time.Time
+}
+
+func NewExpiryChecker(logger *log.Logger) ExpiryChecker {
+	return newExpiryChecker(logger, newHTTPFetcher(logger))
+}
+
+func newExpiryChecker(logger *log.Logger, fetcher HTTPFetcher) *expiryChecker {
+	return &expiryChecker{
+		fetcher: fetcher,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+func (c *expiryChecker) CheckExpiry(ctx context.Context, urlStr string) (*ExpiryStatus, error) {
+	if err := validateURL(urlStr); err != nil {
+		return nil, err
+	}
+
+	body, err := c.fetcher.FetchURL(ctx, urlStr, nil)
+	if err != nil {
+		// fetchURL maps both 404 and 410 to ErrorNotFound
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrorNotFound {
+			return &ExpiryStatus{Expired: true, Reason: ExpiryReasonNotFound}, nil
+		}
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrorParsingFailed, "Failed to parse HTML response", err)
+	}
+
+	status := &ExpiryStatus{}
+
+	if validThrough := findValidThrough(doc); validThrough != nil {
+		status.ValidThrough = validThrough
+		if validThrough.Before(c.now()) {
+			status.Expired = true
+			status.Reason = ExpiryReasonValidThrough
+			return status, nil
+		}
+	}
+
+	if hasClosedMarker(doc) {
+		status.Expired = true
+		status.Reason = ExpiryReasonClosedMarker
+	}
+
+	return status, nil
+}
+
+// findValidThrough returns the validThrough date of the first JobPosting
+// JSON-LD node on the page, or nil if there is none or it can't be parsed.
+// Nodes may be a single object, a top-level array or listed under @graph.
+func findValidThrough(doc *goquery.Document) *time.Time {
+	var validThrough *time.Time
+
+	doc.Find("script[type='application/ld+json']").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		for _, schema := range jsonLDNodes([]byte(s.Text())) {
+			if schema.Type != "JobPosting" || schema.ValidThrough == "" {
+				continue
+			}
+
+			if validThrough = parseSchemaDate(schema.ValidThrough); validThrough != nil {
+				return false
+			}
+		}
+		return true
+	})
+
+	return validThrough
+}
+
+// jsonLDNodes flattens a JSON-LD document into its nodes, unwrapping
+// top-level arrays and @graph containers. Nodes that don't decode as a
+// schema, e.g. because @type is an array, are skipped.
+func jsonLDNodes(data []byte) []jobPostingSchema {
+	data = bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+
+		var nodes []jobPostingSchema
+		for _, item := range items {
+			nodes = append(nodes, jsonLDNodes(item)...)
+		}
+		return nodes
+	}
+
+	var container struct {
+		Graph []json.RawMessage `json:"@graph"`
+	}
+	if err := json.Unmarshal(data, &container); err != nil {
+		return nil
+	}
+	if len(container.Graph) > 0 {
+		var nodes []jobPostingSchema
+		for _, item := range container.Graph {
+			nodes = append(nodes, jsonLDNodes(item)...)
+		}
+		return nodes
+	}
+
+	var schema jobPostingSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	return []jobPostingSchema{schema}
+}
+
+func parseSchemaDate(value string) *time.Time {
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			// A bare date means the posting is valid through the end of that day
+			if layout == "2006-01-02" {
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return &t
+		}
+	}
+
+	return nil
+}
+
+func hasClosedMarker(doc *goquery.Document) bool {
+	text := strings.ToLower(strings.Join(strings.Fields(doc.Find("body").Text()), " "))
+	for _, marker := range closedPostingMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,203 @@
+package urlextractor
+
+import (
+	"context"
+	"ditto-backend/pkg/errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExpiryChecker(fetcher HTTPFetcher, now time.Time) *expiryChecker {
+	checker := newExpiryChecker(log.New(io.Discard, "", 0), fetcher)
+	checker.now = func() time.Time { return now }
+	return checker
+}
+
+func TestExpiryChecker_NotFound(t *testing.T) {
+	fetcher := &mockHTTPFetcher{err: errors.New(errors.ErrorNotFound, "Job posting not found")}
+	checker := newTestExpiryChecker(fetcher, time.Now())
+
+	status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+	require.NoError(t, err)
+	assert.True(t, status.Expired)
+	assert.Equal(t, ExpiryReasonNotFound, status.Reason)
+}
+
+func TestExpiryChecker_Gone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	checker := newTestExpiryChecker(newHTTPFetcher(log.New(io.Discard, "", 0)), time.Now())
+
+	status, err := checker.CheckExpiry(context.Background(), server.URL)
+
+	require.NoError(t, err)
+	assert.True(t, status.Expired)
+	assert.Equal(t, ExpiryReasonNotFound, status.Reason)
+}
+
+func TestExpiryChecker_NetworkErrorIsReturned(t *testing.T) {
+	fetcher := &mockHTTPFetcher{err: errors.New(errors.ErrorNetworkFailure, "HTTP 503: Server error")}
+	checker := newTestExpiryChecker(fetcher, time.Now())
+
+	status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+	assert.Error(t, err)
+	assert.Nil(t, status)
+}
+
+func TestExpiryChecker_ValidThrough(t *testing.T) {
+	html := `
+		<html>
+		<head>
+			<script type="application/ld+json">
+			{
+				"@context": "https://schema.org",
+				"@type": "JobPosting",
+				"title": "Backend Engineer",
+				"validThrough": "2025-03-01T00:00:00Z"
+			}
+			</script>
+		</head>
+		<body><p>Apply now</p></body>
+		</html>
+	`
+
+	t.Run("in the past", func(t *testing.T) {
+		now := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+		checker := newTestExpiryChecker(&mockHTTPFetcher{response: []byte(html)}, now)
+
+		status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+		require.NoError(t, err)
+		assert.True(t, status.Expired)
+		assert.Equal(t, ExpiryReasonValidThrough, status.Reason)
+		require.NotNil(t, status.ValidThrough)
+	})
+
+	t.Run("in the future", func(t *testing.T) {
+		now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		checker := newTestExpiryChecker(&mockHTTPFetcher{response: []byte(html)}, now)
+
+		status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+		require.NoError(t, err)
+		assert.False(t, status.Expired)
+		require.NotNil(t, status.ValidThrough)
+	})
+}
+
+func TestExpiryChecker_ValidThroughNested(t *testing.T) {
+	tests := []struct {
+		name   string
+		jsonLD string
+	}{
+		{
+			name: "graph",
+			jsonLD: `{
+				"@context": "https://schema.org",
+				"@graph": [
+					{"@type": "Organization", "name": "Acme"},
+					{"@type": "JobPosting", "title": "Backend Engineer", "validThrough": "2025-03-01"}
+				]
+			}`,
+		},
+		{
+			name: "array",
+			jsonLD: `[
+				{"@context": "https://schema.org", "@type": "BreadcrumbList"},
+				{"@context": "https://schema.org", "@type": "JobPosting", "title": "Backend Engineer", "validThrough": "2025-03-01"}
+			]`,
+		},
+		{
+			name: "array with graph",
+			jsonLD: `[
+				{"@type": ["WebPage", "ItemPage"], "name": "Careers"},
+				{"@graph": [{"@type": "JobPosting", "validThrough": "2025-03-01"}]}
+			]`,
+		},
+	}
+
+	now := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := `<html><head><script type="application/ld+json">` + tt.jsonLD + `</script></head><body><p>Apply now</p></body></html>`
+			checker := newTestExpiryChecker(&mockHTTPFetcher{response: []byte(html)}, now)
+
+			status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+			require.NoError(t, err)
+			assert.True(t, status.Expired)
+			assert.Equal(t, ExpiryReasonValidThrough, status.Reason)
+			require.NotNil(t, status.ValidThrough)
+			assert.Equal(t, time.Date(2025, 3, 1, 23, 59, 59, 0, time.UTC), *status.ValidThrough)
+		})
+	}
+}
+
+func TestExpiryChecker_ClosedMarker(t *testing.T) {
+	html := `
+		<html>
+		<body>
+			<h1>Backend Engineer</h1>
+			<div class="banner">This job is   No Longer Accepting
+				Applications</div>
+		</body>
+		</html>
+	`
+
+	checker := newTestExpiryChecker(&mockHTTPFetcher{response: []byte(html)}, time.Now())
+
+	status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+	require.NoError(t, err)
+	assert.True(t, status.Expired)
+	assert.Equal(t, ExpiryReasonClosedMarker, status.Reason)
+}
+
+func TestExpiryChecker_OpenPosting(t *testing.T) {
+	html := `<html><body><h1>Backend Engineer</h1><p>We are hiring!</p></body></html>`
+
+	checker := newTestExpiryChecker(&mockHTTPFetcher{response: []byte(html)}, time.Now())
+
+	status, err := checker.CheckExpiry(context.Background(), "https://example.com/job/1")
+
+	require.NoError(t, err)
+	assert.False(t, status.Expired)
+	assert.Empty(t, status.Reason)
+}
+
+func TestParseSchemaDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{"2025-03-01T12:00:00Z", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), true},
+		{"2025-03-01T12:00:00", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), true},
+		{"2025-03-01", time.Date(2025, 3, 1, 23, 59, 59, 0, time.UTC), true},
+		{"next tuesday", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := parseSchemaDate(tt.input)
+			if !tt.ok {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.True(t, tt.expected.Equal(*got), "expected %v, got %v", tt.expected, *got)
+		})
+	}
+}
@@ -116,6 +116,13 @@ func (e *extractor) Extract(ctx context.Context, urlStr string) (*ExtractedJobDa
 
 	data.Platform = platform
 
+	// Fall back to the description text when the parser found no structured salary
+	if data.MinSalary == nil && data.MaxSalary == nil {
+		if warning := applySalary(data, ParseSalary(data.Description)); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
 	if len(warnings) > 0 {
 		e.logger.Printf("Extraction completed with warnings: %v", warnings)
 	} else {
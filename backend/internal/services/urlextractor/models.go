@@ -15,4 +15,11 @@ type ExtractedJobData struct {
 	Description string `json:"description"`
 	JobType     string `json:"job_type,omitempty"` // "full-time" | "part-time" | "contract" | "internship"
 	Platform    string `json:"platform"`           // "linkedin" | "indeed" | "glassdoor" | "angellist"
+
+	// Salary figures are normalized to annual amounts; PayPeriod is the period
+	// the posting quoted them in ("hourly" | "daily" | "weekly" | "monthly" | "annual").
+	MinSalary *float64 `json:"min_salary,omitempty"`
+	MaxSalary *float64 `json:"max_salary,omitempty"`
+	Currency  string   `json:"currency,omitempty"`
+	PayPeriod string   `json:"pay_period,omitempty"`
 }
@@ -117,10 +117,14 @@ func fetchURLOnce(ctx context.Context, url string, headers map[string]string, lo
 
 	if res.StatusCode != http.StatusOK {
 		logger.Printf("HTTP request returned status %d", res.StatusCode)
-		if res.StatusCode == 404 {
+		if res.StatusCode == http.StatusNotFound {
 			return nil, errors.New(errors.ErrorNotFound, "Job posting not found")
 		}
 
+		if res.StatusCode == http.StatusGone {
+			return nil, errors.New(errors.ErrorNotFound, "Job posting no longer available")
+		}
+
 		if res.StatusCode >= 500 {
 			return nil, errors.New(errors.ErrorNetworkFailure, fmt.Sprintf("HTTP %d: Server error", res.StatusCode))
 		}
@@ -34,7 +34,9 @@ type jobPostingSchema struct {
 		Type string `json:"@type"`
 		Name string `json:"name"`
 	} `json:"hiringOrganization"`
-	JobLocation interface{} `json:"jobLocation"` // Can be object or array
+	JobLocation  interface{} `json:"jobLocation"` // Can be object or array
+	ValidThrough string      `json:"validThrough"`
+	BaseSalary   interface{} `json:"baseSalary"` // MonetaryAmount object or bare number
 }
 
 func (p *genericParser) FetchAndParse(ctx context.Context, url string) (*ExtractedJobData, []string, error) {
@@ -94,6 +96,10 @@ func (p *genericParser) extractFromJSONLD(doc *goquery.Document) (*ExtractedJobD
 			JobType:     normalizeJobType(schema.EmploymentType),
 			Platform:    "generic",
 		}
+
+		if warning := applySalary(jobData, parseBaseSalary(schema.BaseSalary)); warning != "" {
+			warnings = append(warnings, warning)
+		}
 	})
 
 	if jobData == nil {
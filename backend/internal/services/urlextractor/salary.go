@@ -0,0 +1,355 @@
+package urlextractor
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	PayPeriodHourly  = "hourly"
+	PayPeriodDaily   = "daily"
+	PayPeriodWeekly  = "weekly"
+	PayPeriodMonthly = "monthly"
+	PayPeriodAnnual  = "annual"
+)
+
+const (
+	SalaryConfidenceHigh   = "high"   // structured data (JSON-LD baseSalary)
+	SalaryConfidenceMedium = "medium" // free text with an explicit currency and pay period
+	SalaryConfidenceLow    = "low"    // free text where the pay period had to be guessed
+)
+
+// annualMultipliers converts a figure for a pay period into an annual figure,
+// assuming a 40-hour week and 52 working weeks.
+var annualMultipliers = map[string]float64{
+	PayPeriodHourly:  2080,
+	PayPeriodDaily:   260,
+	PayPeriodWeekly:  52,
+	PayPeriodMonthly: 12,
+	PayPeriodAnnual:  1,
+}
+
+// currencySymbols maps currency symbols to ISO 4217 codes. Anything else
+// matched by currencyPattern is already a code.
+var currencySymbols = map[string]string{
+	"US$": "USD",
+	"CA$": "CAD",
+	"AU$": "AUD",
+	"C$":  "CAD",
+	"A$":  "AUD",
+	"$":   "USD",
+	"£":   "GBP",
+	"€":   "EUR",
+	"¥":   "JPY",
+	"₹":   "INR",
+}
+
+// Longer symbols come before "$" so "C$" isn't read as "$". Amounts may be
+// grouped with commas ("60,000.50") or, as in much of Europe, with dots and a
+// decimal comma ("4.000,50").
+const (
+	currencyPattern = `(US\$|CA\$|AU\$|C\$|A\$|\$|£|€|¥|₹|\b(?:USD|GBP|EUR|CAD|AUD|CHF|INR|JPY|SGD|NZD)\b)`
+	amountPattern   = `(\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d{1,3}(?:\.\d{3})+(?:,\d+)?|\d+(?:\.\d+)?)\s*([kK])?`
+)
+
+// dotGroupedAmount matches amounts that use "." as the thousands separator
+var dotGroupedAmount = regexp.MustCompile(`^\d{1,3}(?:\.\d{3})+(?:,\d+)?$`)
+
+var (
+	salaryRangeRegex = regexp.MustCompile(
+		currencyPattern + `?\s?` + amountPattern + `\s*` + currencyPattern + `?` +
+			`\s*(?:-|–|—|to)\s*` +
+			currencyPattern + `?\s?` + amountPattern + `\s*` + currencyPattern + `?`)
+	salarySingleRegex = regexp.MustCompile(
+		currencyPattern + `\s?` + amountPattern + `|` + amountPattern + `\s*` + currencyPattern)
+
+	payPeriodPatterns = []struct {
+		period string
+		re     *regexp.Regexp
+	}{
+		{PayPeriodHourly, regexp.MustCompile(`(?i)^[\s,]*(?:(?:/\s*|per\s+|an?\s+)(?:hour|hr)\b|hourly\b)`)},
+		{PayPeriodDaily, regexp.MustCompile(`(?i)^[\s,]*(?:(?:/\s*|per\s+|a\s+)day\b|daily\b)`)},
+		{PayPeriodWeekly, regexp.MustCompile(`(?i)^[\s,]*(?:(?:/\s*|per\s+|a\s+)(?:week|wk)\b|weekly\b)`)},
+		{PayPeriodMonthly, regexp.MustCompile(`(?i)^[\s,]*(?:(?:/\s*|per\s+|a\s+)(?:month|mo)\b|monthly\b)`)},
+		{PayPeriodAnnual, regexp.MustCompile(`(?i)^[\s,]*(?:(?:/\s*|per\s+|an?\s+)(?:year|yr|annum)\b|p\.a\.|pa\b|annually\b|annual\b|yearly\b)`)},
+	}
+)
+
+// SalaryInfo is a salary normalized to annual figures. PayPeriod records the
+// period the salary was originally quoted in.
+type SalaryInfo struct {
+	MinSalary  *float64
+	MaxSalary  *float64
+	Currency   string
+	PayPeriod  string
+	Confidence string
+}
+
+// Warning describes how a salary was derived when it isn't from structured
+// data, so users know to double-check it. Returns "" for high confidence.
+func (s *SalaryInfo) Warning() string {
+	if s.Confidence == SalaryConfidenceHigh {
+		return ""
+	}
+	return fmt.Sprintf("Salary parsed from description text (%s confidence), please verify", s.Confidence)
+}
+
+// ParseSalary looks for the first salary range or single salary figure in free
+// text. Only amounts with an explicit currency are considered, so that things
+// like "3-5 years of experience" aren't mistaken for a salary.
+func ParseSalary(text string) *SalaryInfo {
+	text = html.UnescapeString(text)
+
+	for _, loc := range salaryRangeRegex.FindAllStringSubmatchIndex(text, -1) {
+		m := submatches(text, loc)
+		currency := firstNonEmpty(m[1], m[4], m[5], m[8])
+		if currency == "" {
+			continue
+		}
+		minAmount, minOK := parseAmount(m[2], m[3] != "")
+		maxAmount, maxOK := parseAmount(m[6], m[7] != "")
+		if !minOK || !maxOK {
+			continue
+		}
+		// "$150-180k": the suffix on the upper bound applies to both
+		if m[3] == "" && m[7] != "" && minAmount < 1000 {
+			minAmount *= 1000
+		}
+		if info := buildSalary(minAmount, maxAmount, currency, text[loc[1]:]); info != nil {
+			return info
+		}
+	}
+
+	// A lone figure is only trusted with a "k" suffix or an explicit pay
+	// period, otherwise "$10M Series B" would read as a salary
+	for _, loc := range salarySingleRegex.FindAllStringSubmatchIndex(text, -1) {
+		m := submatches(text, loc)
+		currency, amountStr, suffix := m[1], m[2], m[3]
+		if currency == "" {
+			amountStr, suffix, currency = m[4], m[5], m[6]
+		}
+		if suffix == "" && detectPayPeriod(text[loc[1]:]) == "" {
+			continue
+		}
+		amount, ok := parseAmount(amountStr, suffix != "")
+		if !ok {
+			continue
+		}
+		if info := buildSalary(amount, amount, currency, text[loc[1]:]); info != nil {
+			return info
+		}
+	}
+
+	return nil
+}
+
+func buildSalary(minAmount, maxAmount float64, currency, tail string) *SalaryInfo {
+	if minAmount > maxAmount {
+		minAmount, maxAmount = maxAmount, minAmount
+	}
+
+	confidence := SalaryConfidenceMedium
+	period := detectPayPeriod(tail)
+	if period == "" {
+		period = inferPayPeriod(maxAmount)
+		confidence = SalaryConfidenceLow
+	}
+
+	info := normalizeSalary(minAmount, maxAmount, normalizeCurrency(currency), period)
+	if info == nil {
+		return nil
+	}
+	info.Confidence = confidence
+	return info
+}
+
+func normalizeSalary(minAmount, maxAmount float64, currency, period string) *SalaryInfo {
+	multiplier, ok := annualMultipliers[period]
+	if !ok || maxAmount <= 0 {
+		return nil
+	}
+
+	minAnnual := math.Round(minAmount * multiplier)
+	maxAnnual := math.Round(maxAmount * multiplier)
+
+	return &SalaryInfo{
+		MinSalary: &minAnnual,
+		MaxSalary: &maxAnnual,
+		Currency:  currency,
+		PayPeriod: period,
+	}
+}
+
+func detectPayPeriod(tail string) string {
+	if len(tail) > 40 {
+		tail = tail[:40]
+	}
+	for _, p := range payPeriodPatterns {
+		if p.re.MatchString(tail) {
+			return p.period
+		}
+	}
+	return ""
+}
+
+// inferPayPeriod guesses the pay period from the size of the figure
+func inferPayPeriod(amount float64) string {
+	switch {
+	case amount < 500:
+		return PayPeriodHourly
+	case amount < 15000:
+		return PayPeriodMonthly
+	default:
+		return PayPeriodAnnual
+	}
+}
+
+func parseAmount(value string, thousands bool) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if dotGroupedAmount.MatchString(value) {
+		value = strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	if thousands {
+		amount *= 1000
+	}
+	return amount, true
+}
+
+func normalizeCurrency(currency string) string {
+	currency = strings.TrimSpace(currency)
+	if code, ok := currencySymbols[currency]; ok {
+		return code
+	}
+	return strings.ToUpper(currency)
+}
+
+// parseBaseSalary reads a schema.org baseSalary value, which is usually a
+// MonetaryAmount wrapping a QuantitativeValue but may also be a bare number.
+func parseBaseSalary(raw interface{}) *SalaryInfo {
+	var currency string
+	var value interface{}
+
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		currency, _ = v["currency"].(string)
+		value = v["value"]
+	default:
+		value = v
+	}
+
+	var minAmount, maxAmount float64
+	var unit string
+	var ok bool
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var maxOK bool
+		unit, _ = v["unitText"].(string)
+		minAmount, ok = toFloat(v["minValue"])
+		maxAmount, maxOK = toFloat(v["maxValue"])
+		switch {
+		case ok && maxOK:
+		case ok:
+			maxAmount = minAmount
+		case maxOK:
+			minAmount, ok = maxAmount, true
+		default:
+			minAmount, ok = toFloat(v["value"])
+			maxAmount = minAmount
+		}
+	default:
+		minAmount, ok = toFloat(v)
+		maxAmount = minAmount
+	}
+
+	if !ok {
+		return nil
+	}
+
+	period := unitToPayPeriod(unit)
+	confidence := SalaryConfidenceHigh
+	if period == "" {
+		period = inferPayPeriod(maxAmount)
+		confidence = SalaryConfidenceMedium
+	}
+
+	info := normalizeSalary(minAmount, maxAmount, normalizeCurrency(currency), period)
+	if info == nil {
+		return nil
+	}
+	info.Confidence = confidence
+	return info
+}
+
+func unitToPayPeriod(unit string) string {
+	switch strings.ToUpper(strings.TrimSpace(unit)) {
+	case "HOUR":
+		return PayPeriodHourly
+	case "DAY":
+		return PayPeriodDaily
+	case "WEEK":
+		return PayPeriodWeekly
+	case "MONTH":
+		return PayPeriodMonthly
+	case "YEAR":
+		return PayPeriodAnnual
+	default:
+		return ""
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(n), ",", ""), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// applySalary copies parsed salary data onto extracted job data and returns a
+// confidence warning if one should be shown.
+func applySalary(data *ExtractedJobData, salary *SalaryInfo) string {
+	if salary == nil {
+		return ""
+	}
+	data.MinSalary = salary.MinSalary
+	data.MaxSalary = salary.MaxSalary
+	data.Currency = salary.Currency
+	data.PayPeriod = salary.PayPeriod
+	return salary.Warning()
+}
+
+func submatches(text string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = text[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return m
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,255 @@
+package urlextractor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		min        float64
+		max        float64
+		currency   string
+		period     string
+		confidence string
+	}{
+		{
+			name:       "k suffix range with equity",
+			text:       "Compensation: $150k–$180k + equity",
+			min:        150000,
+			max:        180000,
+			currency:   "USD",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceLow,
+		},
+		{
+			name:       "shared k suffix",
+			text:       "Base salary $150-180k per year",
+			min:        150000,
+			max:        180000,
+			currency:   "USD",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "pounds per annum",
+			text:       "We offer £60,000 - £70,000 per annum plus benefits",
+			min:        60000,
+			max:        70000,
+			currency:   "GBP",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "euro thousands separators per month",
+			text:       "€4.000 - €5.000 per month",
+			min:        48000,
+			max:        60000,
+			currency:   "EUR",
+			period:     PayPeriodMonthly,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "euro thousands separators with trailing symbol",
+			text:       "Gehalt: 55.000 € - 65.000 € pro Jahr, brutto",
+			min:        55000,
+			max:        65000,
+			currency:   "EUR",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceLow,
+		},
+		{
+			name:       "euro decimal comma",
+			text:       "CHF 1.250,50 - 1.500,00 per week",
+			min:        65026,
+			max:        78000,
+			currency:   "CHF",
+			period:     PayPeriodWeekly,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "trailing currency code",
+			text:       "Salary range: 55,000 to 65,000 EUR annually",
+			min:        55000,
+			max:        65000,
+			currency:   "EUR",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "hourly range",
+			text:       "Pay: $25.50 - $30/hr depending on experience",
+			min:        53040,
+			max:        62400,
+			currency:   "USD",
+			period:     PayPeriodHourly,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "monthly range",
+			text:       "CA$5,000 - CA$6,000 per month",
+			min:        60000,
+			max:        72000,
+			currency:   "CAD",
+			period:     PayPeriodMonthly,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "single hourly figure",
+			text:       "Starting at $22 an hour",
+			min:        45760,
+			max:        45760,
+			currency:   "USD",
+			period:     PayPeriodHourly,
+			confidence: SalaryConfidenceMedium,
+		},
+		{
+			name:       "skips ranges without currency",
+			text:       "3-5 years of experience required. Salary: $90,000 - $110,000",
+			min:        90000,
+			max:        110000,
+			currency:   "USD",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceLow,
+		},
+		{
+			name:       "html entities",
+			text:       "&pound;40k - &pound;45k",
+			min:        40000,
+			max:        45000,
+			currency:   "GBP",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceLow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			salary := ParseSalary(tt.text)
+			require.NotNil(t, salary)
+			require.NotNil(t, salary.MinSalary)
+			require.NotNil(t, salary.MaxSalary)
+			assert.Equal(t, tt.min, *salary.MinSalary)
+			assert.Equal(t, tt.max, *salary.MaxSalary)
+			assert.Equal(t, tt.currency, salary.Currency)
+			assert.Equal(t, tt.period, salary.PayPeriod)
+			assert.Equal(t, tt.confidence, salary.Confidence)
+		})
+	}
+}
+
+func TestParseSalary_NoSalary(t *testing.T) {
+	texts := []string{
+		"",
+		"3-5 years of experience with Go",
+		"We raised a $10M Series B last year",
+		"Work 9 to 5, Monday to Friday",
+	}
+
+	for _, text := range texts {
+		assert.Nil(t, ParseSalary(text), "expected no salary in %q", text)
+	}
+}
+
+func TestParseBaseSalary(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		min        float64
+		max        float64
+		currency   string
+		period     string
+		confidence string
+	}{
+		{
+			name:       "monetary amount with range",
+			raw:        `{"@type":"MonetaryAmount","currency":"USD","value":{"@type":"QuantitativeValue","minValue":120000,"maxValue":150000,"unitText":"YEAR"}}`,
+			min:        120000,
+			max:        150000,
+			currency:   "USD",
+			period:     PayPeriodAnnual,
+			confidence: SalaryConfidenceHigh,
+		},
+		{
+			name:       "hourly single value as string",
+			raw:        `{"@type":"MonetaryAmount","currency":"gbp","value":{"@type":"QuantitativeValue","value":"20","unitText":"HOUR"}}`,
+			min:        41600,
+			max:        41600,
+			currency:   "GBP",
+			period:     PayPeriodHourly,
+			confidence: SalaryConfidenceHigh,
+		},
+		{
+			name:       "missing unit",
+			raw:        `{"@type":"MonetaryAmount","currency":"EUR","value":{"minValue":4000,"maxValue":5000}}`,
+			min:        48000,
+			max:        60000,
+			currency:   "EUR",
+			period:     PayPeriodMonthly,
+			confidence: SalaryConfidenceMedium,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.raw), &raw))
+
+			salary := parseBaseSalary(raw)
+			require.NotNil(t, salary)
+			assert.Equal(t, tt.min, *salary.MinSalary)
+			assert.Equal(t, tt.max, *salary.MaxSalary)
+			assert.Equal(t, tt.currency, salary.Currency)
+			assert.Equal(t, tt.period, salary.PayPeriod)
+			assert.Equal(t, tt.confidence, salary.Confidence)
+		})
+	}
+
+	assert.Nil(t, parseBaseSalary(nil))
+}
+
+func TestGenericParser_JSONLD_BaseSalary(t *testing.T) {
+	html := `
+		<html>
+		<head>
+			<script type="application/ld+json">
+			{
+				"@context": "https://schema.org",
+				"@type": "JobPosting",
+				"title": "Data Engineer",
+				"description": "<p>Build pipelines</p>",
+				"hiringOrganization": {"@type": "Organization", "name": "Data Co"},
+				"jobLocation": {"@type": "Place", "address": {"addressLocality": "Austin", "addressRegion": "TX"}},
+				"baseSalary": {
+					"@type": "MonetaryAmount",
+					"currency": "USD",
+					"value": {"@type": "QuantitativeValue", "minValue": 130000, "maxValue": 160000, "unitText": "YEAR"}
+				}
+			}
+			</script>
+		</head>
+		<body></body>
+		</html>
+	`
+
+	parser := newGenericParser(log.New(io.Discard, "", 0), &mockHTTPFetcher{response: []byte(html)})
+
+	data, warnings, err := parser.FetchAndParse(context.Background(), "https://example.com/job/1")
+
+	require.NoError(t, err)
+	require.NotNil(t, data.MinSalary)
+	require.NotNil(t, data.MaxSalary)
+	assert.Equal(t, 130000.0, *data.MinSalary)
+	assert.Equal(t, 160000.0, *data.MaxSalary)
+	assert.Equal(t, "USD", data.Currency)
+	assert.Equal(t, PayPeriodAnnual, data.PayPeriod)
+	assert.Empty(t, warnings)
+}
@@ -0,0 +1,113 @@
+// Package workflow defines which status changes are allowed for assessments,
+// interviews and applications. Each Machine is a transition table: a status
+// may only move to the statuses listed for it. Staying in the same status is
+// always allowed so that saving an unchanged form never fails.
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/errors"
+)
+
+// Application status names, as stored in the application_status table.
+const (
+	ApplicationDraft     = "Draft"
+	ApplicationSaved     = "Saved"
+	ApplicationApplied   = "Applied"
+	ApplicationInterview = "Interview"
+	ApplicationOffer     = "Offer"
+	ApplicationRejected  = "Rejected"
+)
+
+// Machine is the transition table for one kind of record.
+type Machine struct {
+	name        string
+	transitions map[string][]string
+}
+
+// Assessment moves forward through the work and back only to undo a step:
+// a verdict can be withdrawn to submitted, a submission to in_progress.
+var Assessment = &Machine{
+	name: "assessment",
+	transitions: map[string][]string{
+		models.AssessmentStatusNotStarted: {models.AssessmentStatusInProgress, models.AssessmentStatusSubmitted},
+		models.AssessmentStatusInProgress: {models.AssessmentStatusNotStarted, models.AssessmentStatusSubmitted},
+		models.AssessmentStatusSubmitted:  {models.AssessmentStatusInProgress, models.AssessmentStatusPassed, models.AssessmentStatusFailed},
+		models.AssessmentStatusPassed:     {models.AssessmentStatusSubmitted},
+		models.AssessmentStatusFailed:     {models.AssessmentStatusSubmitted},
+	},
+}
+
+// Interview ends once, as completed, cancelled or a no-show. Any of those can
+// be put back to scheduled if it was recorded by mistake or rebooked.
+var Interview = &Machine{
+	name: "interview",
+	transitions: map[string][]string{
+		models.InterviewStatusScheduled: {models.InterviewStatusCompleted, models.InterviewStatusCancelled, models.InterviewStatusNoShow},
+		models.InterviewStatusCompleted: {models.InterviewStatusScheduled},
+		models.InterviewStatusCancelled: {models.InterviewStatusScheduled},
+		models.InterviewStatusNoShow:    {models.InterviewStatusScheduled},
+	},
+}
+
+// Application follows the hiring pipeline. A draft can go anywhere a saved
+// application can. A rejection can be reopened when the company comes back,
+// and an offer can fall through.
+var Application = &Machine{
+	name: "application",
+	transitions: map[string][]string{
+		ApplicationDraft:     {ApplicationSaved, ApplicationApplied, ApplicationInterview, ApplicationRejected},
+		ApplicationSaved:     {ApplicationApplied, ApplicationInterview, ApplicationRejected},
+		ApplicationApplied:   {ApplicationSaved, ApplicationInterview, ApplicationOffer, ApplicationRejected},
+		ApplicationInterview: {ApplicationOffer, ApplicationRejected},
+		ApplicationOffer:     {ApplicationInterview, ApplicationRejected},
+		ApplicationRejected:  {ApplicationApplied, ApplicationInterview},
+	},
+}
+
+// Allowed lists the statuses a record in status from can move to. It is
+// empty, never nil, for unknown statuses.
+func (m *Machine) Allowed(from string) []string {
+	allowed := make([]string, len(m.transitions[from]))
+	copy(allowed, m.transitions[from])
+	return allowed
+}
+
+// CanTransition reports whether a record may move from one status to another.
+func (m *Machine) CanTransition(from, to string) bool {
+	if _, known := m.transitions[to]; !known {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	for _, status := range m.transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an INVALID_TRANSITION error naming the allowed statuses when
+// the move from one status to another is not allowed.
+func (m *Machine) Check(from, to string) error {
+	if m.CanTransition(from, to) {
+		return nil
+	}
+
+	if _, known := m.transitions[to]; !known {
+		return errors.New(errors.ErrorBadRequest, fmt.Sprintf("unknown %s status %q", m.name, to))
+	}
+
+	allowed := m.Allowed(from)
+	detail := "no status changes are allowed"
+	if len(allowed) > 0 {
+		detail = "allowed: " + strings.Join(allowed, ", ")
+	}
+	return errors.New(errors.ErrorInvalidTransition,
+		fmt.Sprintf("%s can't move from %s to %s", m.name, from, to), detail)
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"testing"
+
+	"ditto-backend/internal/models"
+	"ditto-backend/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineCheck(t *testing.T) {
+	t.Run("allowed transition", func(t *testing.T) {
+		assert.NoError(t, Assessment.Check(models.AssessmentStatusNotStarted, models.AssessmentStatusInProgress))
+		assert.NoError(t, Interview.Check(models.InterviewStatusScheduled, models.InterviewStatusNoShow))
+		assert.NoError(t, Application.Check(ApplicationApplied, ApplicationInterview))
+		assert.NoError(t, Application.Check(ApplicationDraft, ApplicationInterview))
+	})
+
+	t.Run("staying put is always allowed", func(t *testing.T) {
+		assert.NoError(t, Assessment.Check(models.AssessmentStatusPassed, models.AssessmentStatusPassed))
+	})
+
+	t.Run("skipping ahead is rejected with the allowed statuses", func(t *testing.T) {
+		err := Assessment.Check(models.AssessmentStatusNotStarted, models.AssessmentStatusPassed)
+		require.Error(t, err)
+
+		appErr := errors.ConvertError(err)
+		assert.Equal(t, errors.ErrorInvalidTransition, appErr.Code)
+		assert.Equal(t, 409, appErr.Status)
+		assert.Equal(t, "assessment can't move from not_started to passed", appErr.Message)
+		assert.Equal(t, []string{"allowed: in_progress, submitted"}, appErr.Details)
+	})
+
+	t.Run("a verdict can't be undone past submitted", func(t *testing.T) {
+		assert.Error(t, Assessment.Check(models.AssessmentStatusPassed, models.AssessmentStatusNotStarted))
+		assert.Error(t, Interview.Check(models.InterviewStatusCompleted, models.InterviewStatusCancelled))
+	})
+
+	t.Run("unknown target status", func(t *testing.T) {
+		err := Application.Check(ApplicationApplied, "Ghosted")
+		assert.Equal(t, errors.ErrorBadRequest, errors.ConvertError(err).Code)
+	})
+}
+
+func TestMachineAllowed(t *testing.T) {
+	assert.Equal(t, []string{models.InterviewStatusScheduled}, Interview.Allowed(models.InterviewStatusCancelled))
+	assert.Equal(t, []string{}, Application.Allowed("Ghosted"))
+	assert.Equal(t, []string{ApplicationSaved, ApplicationApplied, ApplicationInterview, ApplicationRejected}, Application.Allowed(ApplicationDraft))
+
+	// Callers can't change the table through the returned slice
+	allowed := Assessment.Allowed(models.AssessmentStatusPassed)
+	allowed[0] = models.AssessmentStatusNotStarted
+	assert.Equal(t, []string{models.AssessmentStatusSubmitted}, Assessment.Allowed(models.AssessmentStatusPassed))
+}
@@ -0,0 +1,194 @@
+package testutil
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// CalDAVServer is an in-memory calendar collection that speaks just enough
+// CalDAV for the sync client: PROPFIND for ETags, and GET, PUT and DELETE
+// honouring If-Match and If-None-Match. Tests edit resources behind the
+// client's back with SetResource and DeleteResource, as a calendar app would.
+type CalDAVServer struct {
+	*httptest.Server
+	Username   string
+	Password   string
+	Collection string
+
+	mu        sync.Mutex
+	resources map[string]*calDAVResource
+	version   int
+}
+
+type calDAVResource struct {
+	data []byte
+	etag string
+}
+
+// NewCalDAVServer starts a server with an empty collection at /calendars/test/.
+func NewCalDAVServer(t *testing.T) *CalDAVServer {
+	s := &CalDAVServer{
+		Username:   "test",
+		Password:   "secret",
+		Collection: "/calendars/test/",
+		resources:  make(map[string]*calDAVResource),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// CollectionURL is the full URL of the collection.
+func (s *CalDAVServer) CollectionURL() string {
+	return s.URL + s.Collection
+}
+
+// Resource returns the stored body at href.
+func (s *CalDAVServer) Resource(href string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.resources[href]
+	if !ok {
+		return nil, false
+	}
+	return res.data, true
+}
+
+// Hrefs lists every stored resource.
+func (s *CalDAVServer) Hrefs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hrefs := make([]string, 0, len(s.resources))
+	for href := range s.resources {
+		hrefs = append(hrefs, href)
+	}
+	return hrefs
+}
+
+// SetResource replaces a resource and gives it a new ETag.
+func (s *CalDAVServer) SetResource(href string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(href, data)
+}
+
+// DeleteResource removes a resource.
+func (s *CalDAVServer) DeleteResource(href string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resources, href)
+}
+
+func (s *CalDAVServer) store(href string, data []byte) string {
+	s.version++
+	etag := fmt.Sprintf(`"%d"`, s.version)
+	s.resources[href] = &calDAVResource{data: data, etag: etag}
+	return etag
+}
+
+func (s *CalDAVServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != s.Username || pass != s.Password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	href := r.URL.Path
+	res, exists := s.resources[href]
+
+	switch r.Method {
+	case "PROPFIND":
+		if href != s.Collection && href+"/" != s.Collection {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.writeMultistatus(w, r.Header.Get("Depth") == "1")
+
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", res.etag)
+		w.Header().Set("Content-Type", "text/calendar")
+		w.Write(res.data) //nolint:errcheck
+
+	case http.MethodPut:
+		if !strings.HasPrefix(href, s.Collection) || !preconditionsMet(r, res, exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("ETag", s.store(href, data))
+		if exists {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+	case http.MethodDelete:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !preconditionsMet(r, res, exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		delete(s.resources, href)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func preconditionsMet(r *http.Request, res *calDAVResource, exists bool) bool {
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		return false
+	}
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || match != res.etag) {
+		return false
+	}
+	return true
+}
+
+func (s *CalDAVServer) writeMultistatus(w http.ResponseWriter, members bool) {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+	fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop>`+
+		`<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`+
+		`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, escapeXML(s.Collection))
+
+	if members {
+		for href, res := range s.resources {
+			fmt.Fprintf(&b, `<d:response><d:href>%s</d:href><d:propstat><d:prop>`+
+				`<d:resourcetype/><d:getetag>%s</d:getetag>`+
+				`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, escapeXML(href), escapeXML(res.etag))
+		}
+	}
+	b.WriteString(`</d:multistatus>`)
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(w, b.String()) //nolint:errcheck
+}
+
+func escapeXML(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value)) //nolint:errcheck
+	return b.String()
+}
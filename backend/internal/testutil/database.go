@@ -84,12 +84,21 @@ func (td *TestDatabase) RunMigrations(t *testing.T) {
 func (td *TestDatabase) Truncate(t *testing.T) {
 	tables := []string{
 		"rate_limits",
+		"calendar_sync_items",
+		"calendar_sync_accounts",
+		"practice_reviews",
+		"practice_cards",
 		"user_notification_preferences",
 		"notifications",
+		"assessment_time_sessions",
 		"assessment_submissions",
 		"assessments",
+		"interview_events",
+		"interview_note_revisions",
 		"interview_notes",
 		"interview_questions",
+		"question_bank_entries",
+		"stories",
 		"interviewers",
 		"interviews",
 		"files",
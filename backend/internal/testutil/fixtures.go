@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"ditto-backend/internal/models"
+	"ditto-backend/internal/services/schedule"
 	"fmt"
 	"time"
 
@@ -132,15 +133,18 @@ func CreateTestInterview(userID, applicationID uuid.UUID, scheduledDate time.Tim
 	}
 }
 
-// CreateTestAssessment creates a test assessment
+// CreateTestAssessment creates a test assessment due at the end of dueDate
+// (YYYY-MM-DD) in UTC
 func CreateTestAssessment(userID, applicationID uuid.UUID, dueDate string, status string) *models.Assessment {
+	due, _ := schedule.ParseDeadline(dueDate, time.UTC)
 	return &models.Assessment{
 		ID:             uuid.New(),
 		UserID:         userID,
 		ApplicationID:  applicationID,
 		AssessmentType: models.AssessmentTypeTakeHomeProject,
 		Title:          "Take-Home Project",
-		DueDate:        dueDate,
+		DueDate:        due,
+		DueTimezone:    "UTC",
 		Status:         status,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
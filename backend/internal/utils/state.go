@@ -9,6 +9,7 @@ import (
 type AppState struct {
 	DB        *database.Database
 	Sanitizer *services.SanitizerService
+	Markdown  *services.MarkdownService
 }
 
 func NewAppState() (*AppState, error) {
@@ -22,9 +23,12 @@ func NewAppState() (*AppState, error) {
 		return nil, err
 	}
 
+	sanitizer := services.NewSanitizerService()
+
 	return &AppState{
 		DB:        db,
-		Sanitizer: services.NewSanitizerService(),
+		Sanitizer: sanitizer,
+		Markdown:  services.NewMarkdownService(sanitizer),
 	}, nil
 }
 
@@ -0,0 +1,3 @@
+-- Remove expiry check tracking from jobs table
+DROP INDEX IF EXISTS idx_jobs_expiry_check;
+ALTER TABLE jobs DROP COLUMN IF EXISTS expiry_checked_at;
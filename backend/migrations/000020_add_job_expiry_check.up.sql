@@ -0,0 +1,6 @@
+-- Track when a job posting's source URL was last re-checked for expiry
+ALTER TABLE jobs ADD COLUMN expiry_checked_at TIMESTAMP;
+
+-- Index for the expiry checker's "least recently checked first" scan
+CREATE INDEX idx_jobs_expiry_check ON jobs(expiry_checked_at NULLS FIRST)
+    WHERE deleted_at IS NULL AND is_expired = FALSE AND source_url IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Remove pay period from jobs table
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_pay_period_check;
+ALTER TABLE jobs DROP COLUMN IF EXISTS pay_period;
@@ -0,0 +1,5 @@
+-- Pay period the salary was originally quoted in; min_salary/max_salary hold annualized figures
+ALTER TABLE jobs ADD COLUMN pay_period VARCHAR(20);
+
+ALTER TABLE jobs ADD CONSTRAINT jobs_pay_period_check
+    CHECK (pay_period IS NULL OR pay_period IN ('hourly', 'daily', 'weekly', 'monthly', 'annual'));
@@ -0,0 +1,6 @@
+-- Remove exchange rates and preferred currency
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_currency;
+DROP FUNCTION IF EXISTS convert_currency(NUMERIC, TEXT, TEXT);
+DROP FUNCTION IF EXISTS exchange_rate_to_usd(TEXT, DATE);
+DROP TRIGGER IF EXISTS update_exchange_rates_timestamp ON exchange_rates;
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,47 @@
+-- Exchange rates used to compare salaries across currencies.
+-- rate_to_usd is the value of one unit of currency in USD on effective_date.
+CREATE TABLE exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    currency VARCHAR(3) NOT NULL,
+    rate_to_usd NUMERIC(20, 10) NOT NULL CHECK (rate_to_usd > 0),
+    effective_date DATE NOT NULL,
+    source VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (currency, effective_date)
+);
+
+CREATE INDEX idx_exchange_rates_lookup ON exchange_rates(currency, effective_date DESC);
+
+CREATE TRIGGER update_exchange_rates_timestamp
+    BEFORE UPDATE ON exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+
+-- USD is the base currency and always converts 1:1
+INSERT INTO exchange_rates (currency, rate_to_usd, effective_date, source)
+VALUES ('USD', 1, '1970-01-01', 'base');
+
+-- Latest known USD rate for a currency on or before a date
+CREATE OR REPLACE FUNCTION exchange_rate_to_usd(p_currency TEXT, p_on DATE)
+RETURNS NUMERIC AS $$
+    SELECT rate_to_usd
+    FROM exchange_rates
+    WHERE currency = UPPER(p_currency) AND effective_date <= p_on
+    ORDER BY effective_date DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- Convert an amount between currencies using current rates.
+-- A NULL source currency is assumed to already be in the target currency.
+-- Returns NULL when either rate is unknown.
+CREATE OR REPLACE FUNCTION convert_currency(p_amount NUMERIC, p_from TEXT, p_to TEXT)
+RETURNS NUMERIC AS $$
+    SELECT CASE
+        WHEN p_amount IS NULL THEN NULL
+        WHEN p_from IS NULL OR UPPER(p_from) = UPPER(p_to) THEN p_amount
+        ELSE ROUND(p_amount * exchange_rate_to_usd(p_from, CURRENT_DATE) / exchange_rate_to_usd(p_to, CURRENT_DATE), 2)
+    END
+$$ LANGUAGE sql STABLE;
+
+-- Currency salaries are normalized into for each user
+ALTER TABLE users ADD COLUMN preferred_currency VARCHAR(3) NOT NULL DEFAULT 'USD';
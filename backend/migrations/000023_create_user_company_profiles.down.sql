@@ -0,0 +1,3 @@
+-- Remove private per-user company profiles
+DROP TRIGGER IF EXISTS update_user_company_profiles_timestamp ON user_company_profiles;
+DROP TABLE IF EXISTS user_company_profiles;
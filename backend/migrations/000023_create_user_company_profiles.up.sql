@@ -0,0 +1,26 @@
+-- Private, per-user research on a company. companies rows are shared between
+-- all users, so anything personal lives here instead.
+CREATE TABLE user_company_profiles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    notes TEXT,
+    is_target BOOLEAN NOT NULL DEFAULT false,
+    priority INT CHECK (priority BETWEEN 1 AND 5),
+    culture_rating INT CHECK (culture_rating BETWEEN 1 AND 5),
+    tech_stack TEXT[] NOT NULL DEFAULT '{}',
+    headcount INT CHECK (headcount >= 0),
+    funding_stage VARCHAR(30) CHECK (funding_stage IN (
+        'bootstrapped', 'pre_seed', 'seed', 'series_a', 'series_b', 'series_c',
+        'series_d_plus', 'public', 'acquired'
+    )),
+    do_not_apply BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, company_id)
+);
+
+CREATE INDEX idx_user_company_profiles_company ON user_company_profiles(company_id);
+
+CREATE TRIGGER update_user_company_profiles_timestamp
+    BEFORE UPDATE ON user_company_profiles
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
@@ -0,0 +1,3 @@
+-- Remove company aliases
+DROP TRIGGER IF EXISTS update_company_aliases_timestamp ON company_aliases;
+DROP TABLE IF EXISTS company_aliases;
@@ -0,0 +1,18 @@
+-- Names of companies that were merged into another company. Lookups by name
+-- check here first so a merged duplicate is never recreated.
+-- normalized_name is computed in Go (companymatch.NormalizeName).
+CREATE TABLE company_aliases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    alias_name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL UNIQUE,
+    merged_company_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_company_aliases_company ON company_aliases(company_id);
+
+CREATE TRIGGER update_company_aliases_timestamp
+    BEFORE UPDATE ON company_aliases
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
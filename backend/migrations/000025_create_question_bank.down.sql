@@ -0,0 +1,5 @@
+-- Remove the interview question bank
+DROP INDEX IF EXISTS idx_interview_questions_bank_entry;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS bank_entry_id;
+DROP TRIGGER IF EXISTS update_question_bank_entries_timestamp ON question_bank_entries;
+DROP TABLE IF EXISTS question_bank_entries;
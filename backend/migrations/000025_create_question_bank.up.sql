@@ -0,0 +1,26 @@
+-- Per-user bank of canonical interview questions. interview_questions rows
+-- record what was asked in one interview; bank entries tie the same question
+-- asked across interviews together.
+CREATE TABLE question_bank_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question_text TEXT NOT NULL,
+    topics TEXT[] NOT NULL DEFAULT '{}',
+    difficulty VARCHAR(10) CHECK (difficulty IN ('easy', 'medium', 'hard')),
+    model_answer TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_question_bank_entries_user ON question_bank_entries(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_question_bank_entries_topics ON question_bank_entries USING GIN (topics);
+
+CREATE TRIGGER update_question_bank_entries_timestamp
+    BEFORE UPDATE ON question_bank_entries
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+
+ALTER TABLE interview_questions
+    ADD COLUMN bank_entry_id UUID REFERENCES question_bank_entries(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_interview_questions_bank_entry ON interview_questions(bank_entry_id) WHERE bank_entry_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Remove spaced-repetition practice
+DROP TABLE IF EXISTS practice_reviews;
+DROP TRIGGER IF EXISTS update_practice_cards_timestamp ON practice_cards;
+DROP TABLE IF EXISTS practice_cards;
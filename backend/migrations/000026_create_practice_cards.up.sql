@@ -0,0 +1,37 @@
+-- Spaced-repetition practice over logged interview questions and question
+-- bank entries. A card holds the SM-2 schedule for one question; every
+-- self-graded attempt is kept in practice_reviews.
+CREATE TABLE practice_cards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    interview_question_id UUID REFERENCES interview_questions(id) ON DELETE CASCADE,
+    bank_entry_id UUID REFERENCES question_bank_entries(id) ON DELETE CASCADE,
+    ease_factor NUMERIC(4,2) NOT NULL DEFAULT 2.5,
+    interval_days INT NOT NULL DEFAULT 0 CHECK (interval_days >= 0),
+    repetitions INT NOT NULL DEFAULT 0 CHECK (repetitions >= 0),
+    due_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((interview_question_id IS NULL) <> (bank_entry_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_practice_cards_interview_question ON practice_cards(interview_question_id) WHERE interview_question_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_practice_cards_bank_entry ON practice_cards(bank_entry_id) WHERE bank_entry_id IS NOT NULL;
+CREATE INDEX idx_practice_cards_user_due ON practice_cards(user_id, due_at);
+
+CREATE TRIGGER update_practice_cards_timestamp
+    BEFORE UPDATE ON practice_cards
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+
+CREATE TABLE practice_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    card_id UUID NOT NULL REFERENCES practice_cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grade INT NOT NULL CHECK (grade BETWEEN 0 AND 5),
+    ease_factor NUMERIC(4,2) NOT NULL,
+    interval_days INT NOT NULL,
+    reviewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_practice_reviews_card ON practice_reviews(card_id, reviewed_at DESC);
@@ -0,0 +1,5 @@
+-- Remove the STAR story library
+DROP INDEX IF EXISTS idx_interview_questions_story;
+ALTER TABLE interview_questions DROP COLUMN IF EXISTS story_id;
+DROP TRIGGER IF EXISTS update_stories_timestamp ON stories;
+DROP TABLE IF EXISTS stories;
@@ -0,0 +1,28 @@
+-- Library of behavioral (STAR) stories. Interview questions record which
+-- story was used to answer them, so repeats to the same company or
+-- interviewer can be spotted.
+CREATE TABLE stories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    situation TEXT,
+    task TEXT,
+    action TEXT,
+    result TEXT,
+    competencies TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_stories_user ON stories(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_stories_competencies ON stories USING GIN (competencies);
+
+CREATE TRIGGER update_stories_timestamp
+    BEFORE UPDATE ON stories
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+
+ALTER TABLE interview_questions
+    ADD COLUMN story_id UUID REFERENCES stories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_interview_questions_story ON interview_questions(story_id) WHERE story_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Remove interview note revision history
+DROP TABLE IF EXISTS interview_note_revisions;
@@ -0,0 +1,24 @@
+-- Revision history for interview notes. Every save of a note records the
+-- content it was saved with, so earlier versions can be viewed, compared and
+-- restored.
+CREATE TABLE interview_note_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    note_id UUID NOT NULL REFERENCES interview_notes(id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    content TEXT,
+    content_hash CHAR(64) NOT NULL,
+    content_size INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (note_id, revision_number)
+);
+
+-- Existing notes start their history with their current content
+INSERT INTO interview_note_revisions (note_id, revision_number, author_id, content, content_hash, content_size, created_at)
+SELECT n.id, 1, i.user_id, n.content,
+    encode(sha256(convert_to(COALESCE(n.content, ''), 'UTF8')), 'hex'),
+    octet_length(COALESCE(n.content, '')),
+    COALESCE(n.updated_at, n.created_at, NOW())
+FROM interview_notes n
+JOIN interviews i ON n.interview_id = i.id
+WHERE n.deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Remove note formats
+ALTER TABLE assessment_submissions DROP COLUMN IF EXISTS notes_format;
+ALTER TABLE interview_note_revisions DROP COLUMN IF EXISTS content_format;
+ALTER TABLE interview_notes DROP COLUMN IF EXISTS content_format;
+ALTER TABLE applications DROP COLUMN IF EXISTS notes_format;
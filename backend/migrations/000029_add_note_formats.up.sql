@@ -0,0 +1,17 @@
+-- Notes can be stored as Markdown as well as sanitized HTML. Existing notes
+-- are HTML.
+ALTER TABLE applications
+    ADD COLUMN notes_format VARCHAR(20) NOT NULL DEFAULT 'html'
+    CHECK (notes_format IN ('html', 'markdown'));
+
+ALTER TABLE interview_notes
+    ADD COLUMN content_format VARCHAR(20) NOT NULL DEFAULT 'html'
+    CHECK (content_format IN ('html', 'markdown'));
+
+ALTER TABLE interview_note_revisions
+    ADD COLUMN content_format VARCHAR(20) NOT NULL DEFAULT 'html'
+    CHECK (content_format IN ('html', 'markdown'));
+
+ALTER TABLE assessment_submissions
+    ADD COLUMN notes_format VARCHAR(20) NOT NULL DEFAULT 'html'
+    CHECK (notes_format IN ('html', 'markdown'));
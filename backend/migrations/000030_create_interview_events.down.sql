@@ -0,0 +1,8 @@
+-- Remove the interview event log and the no_show interview status
+DROP TABLE IF EXISTS interview_events;
+
+UPDATE interviews SET status = 'cancelled' WHERE status = 'no_show';
+
+ALTER TABLE interviews DROP CONSTRAINT IF EXISTS interviews_status_check;
+ALTER TABLE interviews ADD CONSTRAINT interviews_status_check
+    CHECK (status IN ('scheduled', 'completed', 'cancelled'));
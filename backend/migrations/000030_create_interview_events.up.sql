@@ -0,0 +1,23 @@
+-- Interview event log. Reschedules, cancellations and no-shows are recorded
+-- with who initiated them and why, instead of being overwritten in place.
+ALTER TABLE interviews DROP CONSTRAINT IF EXISTS interviews_status_check;
+ALTER TABLE interviews ADD CONSTRAINT interviews_status_check
+    CHECK (status IN ('scheduled', 'completed', 'cancelled', 'no_show'));
+
+CREATE TABLE interview_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    interview_id UUID NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL
+        CHECK (event_type IN ('rescheduled', 'cancelled', 'no_show')),
+    initiated_by VARCHAR(20) CHECK (initiated_by IN ('me', 'company')),
+    reason TEXT,
+    previous_date DATE,
+    previous_time TIME,
+    new_date DATE,
+    new_time TIME,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_interview_events_interview ON interview_events(interview_id, created_at);
+CREATE INDEX idx_interview_events_user ON interview_events(user_id);
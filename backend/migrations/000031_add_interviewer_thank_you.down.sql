@@ -0,0 +1,6 @@
+-- Remove thank-you note tracking from interviewers
+ALTER TABLE interviewers
+    DROP COLUMN IF EXISTS thank_you_template,
+    DROP COLUMN IF EXISTS thank_you_channel,
+    DROP COLUMN IF EXISTS thank_you_sent_at,
+    DROP COLUMN IF EXISTS thank_you_sent;
@@ -0,0 +1,8 @@
+-- Thank-you note tracking per interviewer: whether one was sent, when, how,
+-- and the text or template it was based on.
+ALTER TABLE interviewers
+    ADD COLUMN thank_you_sent BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN thank_you_sent_at TIMESTAMP,
+    ADD COLUMN thank_you_channel VARCHAR(20)
+        CHECK (thank_you_channel IN ('email', 'linkedin', 'handwritten', 'other')),
+    ADD COLUMN thank_you_template TEXT;
@@ -0,0 +1,4 @@
+-- Remove interview location and meeting link
+ALTER TABLE interviews
+    DROP COLUMN IF EXISTS meeting_link,
+    DROP COLUMN IF EXISTS location;
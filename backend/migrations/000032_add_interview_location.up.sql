@@ -0,0 +1,5 @@
+-- Where an interview takes place: a physical location and/or a video call
+-- link, e.g. as imported from a calendar invite.
+ALTER TABLE interviews
+    ADD COLUMN location VARCHAR(500),
+    ADD COLUMN meeting_link VARCHAR(2000);
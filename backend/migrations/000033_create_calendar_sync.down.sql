@@ -0,0 +1,3 @@
+-- Remove CalDAV sync tables
+DROP TABLE IF EXISTS calendar_sync_items;
+DROP TABLE IF EXISTS calendar_sync_accounts;
@@ -0,0 +1,38 @@
+-- CalDAV sync: one calendar collection per user, and what was last pushed to
+-- or pulled from it for each interview and assessment deadline.
+CREATE TABLE calendar_sync_accounts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    collection_url VARCHAR(2000) NOT NULL,
+    username VARCHAR(255) NOT NULL,
+    -- AES-GCM sealed with CALDAV_ENCRYPTION_KEY, never returned by the API
+    password_encrypted TEXT NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_synced_at TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_calendar_sync_accounts_timestamp
+    BEFORE UPDATE ON calendar_sync_accounts
+    FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+
+CREATE TABLE calendar_sync_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('interview', 'assessment')),
+    item_id UUID NOT NULL,
+    href VARCHAR(2000) NOT NULL,
+    etag VARCHAR(255),
+    -- hash of the fields last pushed, to tell whether Ditto's copy changed since
+    local_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'synced'
+        CHECK (status IN ('synced', 'conflict', 'remote_deleted')),
+    conflict_detail TEXT,
+    last_synced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, item_type, item_id)
+);
+
+CREATE INDEX idx_calendar_sync_items_status ON calendar_sync_items(user_id, status);
@@ -0,0 +1,2 @@
+-- Remove assessment time tracking
+DROP TABLE IF EXISTS assessment_time_sessions;
@@ -0,0 +1,19 @@
+-- Time spent on an assessment, logged by a start/stop timer or entered by
+-- hand. A session without ended_at is a timer that is still running.
+CREATE TABLE assessment_time_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    assessment_id UUID NOT NULL REFERENCES assessments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    is_manual BOOLEAN NOT NULL DEFAULT FALSE,
+    note VARCHAR(500),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX idx_assessment_time_sessions_assessment ON assessment_time_sessions(assessment_id, started_at);
+
+-- Only one timer can run per assessment
+CREATE UNIQUE INDEX idx_assessment_time_sessions_running
+    ON assessment_time_sessions(assessment_id) WHERE ended_at IS NULL;
@@ -0,0 +1,7 @@
+-- Remove GitHub snapshot from assessment submissions
+ALTER TABLE assessment_submissions
+    DROP COLUMN IF EXISTS archive_file_id,
+    DROP COLUMN IF EXISTS github_verified_at,
+    DROP COLUMN IF EXISTS github_private,
+    DROP COLUMN IF EXISTS github_ref,
+    DROP COLUMN IF EXISTS github_commit_sha;
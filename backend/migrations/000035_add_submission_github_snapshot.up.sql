@@ -0,0 +1,9 @@
+-- What GitHub reported about a submitted repository when it was submitted:
+-- the commit it was at, so later pushes show up, and an optional archive of
+-- that commit kept in file storage.
+ALTER TABLE assessment_submissions
+    ADD COLUMN github_commit_sha VARCHAR(40),
+    ADD COLUMN github_ref VARCHAR(255),
+    ADD COLUMN github_private BOOLEAN,
+    ADD COLUMN github_verified_at TIMESTAMP,
+    ADD COLUMN archive_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+-- Remove assessment due times and time zones
+ALTER TABLE assessments
+    ALTER COLUMN due_date TYPE DATE
+    USING (due_date AT TIME ZONE due_timezone)::date;
+
+ALTER TABLE assessments DROP COLUMN IF EXISTS due_timezone;
@@ -0,0 +1,8 @@
+-- Assessment deadlines become an exact instant plus the time zone they were
+-- set in, so "due 5pm PT" is reminded about at the right time. Existing
+-- date-only deadlines become the end of that day in UTC.
+ALTER TABLE assessments ADD COLUMN due_timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE assessments
+    ALTER COLUMN due_date TYPE TIMESTAMPTZ
+    USING (due_date + TIME '23:59') AT TIME ZONE 'UTC';
@@ -0,0 +1,7 @@
+-- Remove malware scan state from files
+DROP INDEX IF EXISTS idx_files_scan_pending;
+
+ALTER TABLE files
+    DROP COLUMN IF EXISTS scanned_at,
+    DROP COLUMN IF EXISTS scan_signature,
+    DROP COLUMN IF EXISTS scan_status;
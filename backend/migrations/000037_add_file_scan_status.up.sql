@@ -0,0 +1,13 @@
+-- Malware scan state of each uploaded file. Files uploaded before scanning
+-- existed were already being served, so they start out clean; new rows are
+-- pending until clamd has looked at them.
+ALTER TABLE files
+    ADD COLUMN scan_status VARCHAR(20) NOT NULL DEFAULT 'clean'
+        CHECK (scan_status IN ('pending', 'clean', 'infected')),
+    ADD COLUMN scan_signature VARCHAR(255),
+    ADD COLUMN scanned_at TIMESTAMP;
+
+ALTER TABLE files ALTER COLUMN scan_status SET DEFAULT 'pending';
+
+CREATE INDEX idx_files_scan_pending ON files(created_at)
+    WHERE scan_status = 'pending' AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- Remove expiry attempt tracking from jobs table
+DROP INDEX IF EXISTS idx_jobs_expiry_check;
+ALTER TABLE jobs DROP COLUMN IF EXISTS expiry_attempted_at;
+
+CREATE INDEX idx_jobs_expiry_check ON jobs(expiry_checked_at NULLS FIRST)
+    WHERE deleted_at IS NULL AND is_expired = FALSE AND source_url IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Track every expiry check attempt, including failed and rate-limited ones,
+-- so jobs that can't be checked don't stay at the head of the queue
+ALTER TABLE jobs ADD COLUMN expiry_attempted_at TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_jobs_expiry_check;
+CREATE INDEX idx_jobs_expiry_check ON jobs(expiry_attempted_at NULLS FIRST)
+    WHERE deleted_at IS NULL AND is_expired = FALSE AND source_url IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Remove the admin role and its grants
+DELETE FROM roles WHERE name = 'admin';
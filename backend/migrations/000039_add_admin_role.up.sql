@@ -0,0 +1,5 @@
+-- Admins maintain shared data such as the companies table. Grant the role with:
+-- INSERT INTO user_roles (user_id, role_id) SELECT '<user id>', id FROM roles WHERE name = 'admin';
+INSERT INTO roles (name, description)
+VALUES ('admin', 'Maintains shared data such as companies')
+ON CONFLICT (name) DO NOTHING;
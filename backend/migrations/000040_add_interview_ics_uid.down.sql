@@ -0,0 +1,4 @@
+-- Remove imported calendar event UIDs
+DROP INDEX IF EXISTS idx_interviews_user_ics_uid;
+
+ALTER TABLE interviews DROP COLUMN IF EXISTS ics_uid;
@@ -0,0 +1,7 @@
+-- The calendar event an interview was imported from, so the same invite
+-- can't be imported twice. Deleted interviews free their UID again.
+ALTER TABLE interviews ADD COLUMN ics_uid TEXT;
+
+CREATE UNIQUE INDEX idx_interviews_user_ics_uid
+    ON interviews(user_id, ics_uid)
+    WHERE ics_uid IS NOT NULL AND deleted_at IS NULL;
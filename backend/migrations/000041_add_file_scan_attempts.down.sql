@@ -0,0 +1,15 @@
+-- Remove scan attempt counting; failed files go back to pending
+DROP INDEX IF EXISTS idx_files_scan_pending;
+
+UPDATE files SET scan_status = 'pending' WHERE scan_status = 'failed';
+
+ALTER TABLE files
+    DROP COLUMN IF EXISTS scan_attempts,
+    DROP CONSTRAINT IF EXISTS files_scan_status_check;
+
+ALTER TABLE files
+    ADD CONSTRAINT files_scan_status_check
+        CHECK (scan_status IN ('pending', 'clean', 'infected'));
+
+CREATE INDEX idx_files_scan_pending ON files(created_at)
+    WHERE scan_status = 'pending' AND deleted_at IS NULL;
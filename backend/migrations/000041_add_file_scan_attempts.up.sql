@@ -0,0 +1,14 @@
+-- Files that can never be scanned, e.g. too large for clamd or missing from
+-- storage, end up failed instead of pending forever. Transient failures are
+-- counted, and the sweep retries the files with the fewest attempts first.
+ALTER TABLE files DROP CONSTRAINT IF EXISTS files_scan_status_check;
+
+ALTER TABLE files
+    ADD CONSTRAINT files_scan_status_check
+        CHECK (scan_status IN ('pending', 'clean', 'infected', 'failed')),
+    ADD COLUMN scan_attempts INT NOT NULL DEFAULT 0;
+
+DROP INDEX IF EXISTS idx_files_scan_pending;
+
+CREATE INDEX idx_files_scan_pending ON files(scan_attempts, created_at)
+    WHERE scan_status = 'pending' AND deleted_at IS NULL;
@@ -29,8 +29,9 @@ const (
 	ErrorUserNotFound ErrorCode = "USER_NOT_FOUND"
 	ErrorJobNotFound  ErrorCode = "JOB_NOT_FOUND"
 
-	ErrorForbidden ErrorCode = "FORBIDDEN"
-	ErrorConflict  ErrorCode = "CONFLICT"
+	ErrorForbidden         ErrorCode = "FORBIDDEN"
+	ErrorConflict          ErrorCode = "CONFLICT"
+	ErrorInvalidTransition ErrorCode = "INVALID_TRANSITION"
 
 	ErrorInternalServer ErrorCode = "INTERNAL_SERVER_ERROR"
 	ErrorDatabaseError  ErrorCode = "DATABASE_ERROR"
@@ -42,6 +43,7 @@ const (
 	ErrorUnsupportedPlatform ErrorCode = "UNSUPPORTED_PLATFORM"
 	ErrorQuotaExceeded       ErrorCode = "QUOTA_EXCEEDED"
 	ErrorExpired             ErrorCode = "EXPIRED"
+	ErrorPayloadTooLarge     ErrorCode = "PAYLOAD_TOO_LARGE"
 )
 
 func (e *AppError) Error() string {
@@ -66,7 +68,7 @@ func (code ErrorCode) HTTPStatus() int {
 	switch code {
 	case ErrorInvalidCredentials, ErrorUnauthorized:
 		return http.StatusUnauthorized
-	case ErrorEmailAlreadyExists, ErrorConflict:
+	case ErrorEmailAlreadyExists, ErrorConflict, ErrorInvalidTransition:
 		return http.StatusConflict
 	case ErrorNotFound, ErrorUserNotFound, ErrorJobNotFound, ErrorRoleNotFound:
 		return http.StatusNotFound
@@ -82,6 +84,8 @@ func (code ErrorCode) HTTPStatus() int {
 		return http.StatusBadGateway
 	case ErrorExpired:
 		return http.StatusGone
+	case ErrorPayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
 
 	default:
 		return http.StatusInternalServerError
@@ -92,7 +96,7 @@ func (code ErrorCode) Category() string {
 	switch code {
 	case ErrorInvalidCredentials, ErrorUnauthorized, ErrorEmailAlreadyExists, ErrorRoleNotFound, ErrorForbidden:
 		return "auth"
-	case ErrorValidationFailed, ErrorBadRequest:
+	case ErrorValidationFailed, ErrorBadRequest, ErrorInvalidTransition:
 		return "validation"
 	case ErrorNotFound, ErrorUserNotFound, ErrorJobNotFound:
 		return "not_found"
@@ -72,6 +72,14 @@ func SuccessWithWarnings(c *gin.Context, data interface{}, warnings []string) {
 	})
 }
 
+func CreatedWithWarnings(c *gin.Context, data interface{}, warnings []string) {
+	c.JSON(201, ApiResponse{
+		Success:  true,
+		Data:     data,
+		Warnings: warnings,
+	})
+}
+
 func NoContent(c *gin.Context) {
 	c.Status(204)
 }
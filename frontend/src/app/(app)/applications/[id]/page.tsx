@@ -47,6 +47,7 @@ import {
 import { AssessmentList } from '@/components/assessment-list';
 import { AssessmentFormModal } from '@/components/assessment-form';
 import { DocumentsSection } from '@/components/file-upload';
+import { NoteContent } from '@/components/note-content';
 
 const statusVariantMap = {
     'Saved': 'draft',
@@ -550,15 +551,17 @@ const ApplicationPage = () => {
                             {!app.notes ? (
                                 <p className="text-sm text-muted-foreground/60 italic">No notes provided</p>
                             ) : isNotesExpanded ? (
-                                <div
+                                <NoteContent
                                     className="prose prose-sm prose-invert max-w-none text-muted-foreground"
-                                    dangerouslySetInnerHTML={{ __html: sanitizeHtml(app.notes) }}
+                                    content={app.notes}
+                                    format={app.notes_format}
                                 />
                             ) : (
                                 <div className="relative max-h-[80px] overflow-hidden">
-                                    <div
+                                    <NoteContent
                                         className="prose prose-sm prose-invert max-w-none text-muted-foreground"
-                                        dangerouslySetInnerHTML={{ __html: sanitizeHtml(app.notes) }}
+                                        content={app.notes}
+                                        format={app.notes_format}
                                     />
                                     <div className="absolute bottom-0 left-0 right-0 h-8 bg-gradient-to-t from-card to-transparent" />
                                 </div>
@@ -5,7 +5,7 @@ import { FileText, MessageSquare, Lightbulb, BookOpen, StickyNote } from 'lucide
 import { CollapsibleSection } from './collapsible-section';
 import { InterviewNote } from '@/services/interview-service';
 import { Badge } from '@/components/ui/badge';
-import { sanitizeHtml } from '@/lib/sanitizer';
+import { NoteContent } from '@/components/note-content';
 
 interface FeedbackSectionProps {
     notes: InterviewNote[];
@@ -79,9 +79,10 @@ export const FeedbackSection = ({ notes }: FeedbackSectionProps) => {
                                 </Badge>
                             </div>
                             {note.content ? (
-                                <div
+                                <NoteContent
                                     className="prose prose-sm max-w-none text-muted-foreground"
-                                    dangerouslySetInnerHTML={{ __html: sanitizeHtml(note.content) }}
+                                    content={note.content}
+                                    format={note.content_format}
                                 />
                             ) : (
                                 <p className="text-muted-foreground italic">No content</p>
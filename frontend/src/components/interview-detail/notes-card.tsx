@@ -26,7 +26,7 @@ import {
     NoteType,
     createOrUpdateNote,
 } from '@/services/interview-service';
-import { sanitizeHtml } from '@/lib/sanitizer';
+import { NoteContent } from '@/components/note-content';
 
 type TabType = 'preparation' | 'during' | 'reflection';
 
@@ -184,11 +184,13 @@ export const NotesCard = ({
                             notes...
                         </div>
                     ) : (
-                        <div
+                        <NoteContent
                             className="prose prose-invert max-w-none text-sm cursor-pointer"
-                            dangerouslySetInnerHTML={{
-                                __html: sanitizeHtml(currentContent),
-                            }}
+                            content={currentContent}
+                            format={
+                                notes.find((n) => n.note_type === currentNoteType)
+                                    ?.content_format
+                            }
                             onClick={startEditing}
                         />
                     )}
@@ -20,7 +20,7 @@ import {
     NoteType,
     createOrUpdateNote,
 } from '@/services/interview-service';
-import { sanitizeHtml } from '@/lib/sanitizer';
+import { NoteContent } from '@/components/note-content';
 
 const NOTE_TYPE_LABELS: Record<NoteType, string> = {
     preparation: 'Preparation',
@@ -123,9 +123,10 @@ export const NoteSection = ({
                                 placeholder={`Add ${NOTE_TYPE_LABELS[type].toLowerCase()} notes...`}
                             />
                         ) : (
-                            <div
+                            <NoteContent
                                 className="prose prose-invert max-w-none text-sm cursor-pointer"
-                                dangerouslySetInnerHTML={{ __html: sanitizeHtml(content) }}
+                                content={content}
+                                format={notes.find((n) => n.note_type === type)?.content_format}
                                 onClick={() => startEditing(type)}
                             />
                         )}
@@ -0,0 +1,63 @@
+import React from 'react';
+import { render, screen, waitFor } from '@testing-library/react';
+import { NoteContent } from '../note-content';
+
+const mockRenderNote = jest.fn();
+
+jest.mock('@/services/note-service', () => ({
+    renderNote: (...args: unknown[]) => mockRenderNote(...args),
+}));
+
+jest.mock('react-markdown', () => ({
+    __esModule: true,
+    default: ({ children }: { children: string }) => <div data-testid="client-markdown">{children}</div>,
+}));
+
+jest.mock('remark-gfm', () => ({
+    __esModule: true,
+    default: () => undefined,
+}));
+
+describe('NoteContent', () => {
+    beforeEach(() => {
+        jest.clearAllMocks();
+    });
+
+    it('renders Markdown notes through the server so file references resolve', async () => {
+        mockRenderNote.mockResolvedValue(
+            '<p><img src="https://files.example.com/diagram.png" alt="system diagram"></p>'
+        );
+
+        render(<NoteContent content="![system diagram](file:3f0c)" format="markdown" />);
+
+        const image = await screen.findByAltText('system diagram');
+        expect(image).toHaveAttribute('src', 'https://files.example.com/diagram.png');
+        expect(mockRenderNote).toHaveBeenCalledWith('![system diagram](file:3f0c)', 'markdown');
+        expect(screen.queryByTestId('client-markdown')).not.toBeInTheDocument();
+    });
+
+    it('sanitizes the rendered HTML', async () => {
+        mockRenderNote.mockResolvedValue('<p>Prep</p><img src="x" onerror="alert(1)" alt="bad">');
+
+        render(<NoteContent content="Prep" format="markdown" />);
+
+        const image = await screen.findByAltText('bad');
+        expect(image).not.toHaveAttribute('onerror');
+    });
+
+    it('falls back to client-side Markdown when rendering fails', async () => {
+        mockRenderNote.mockRejectedValue(new Error('network'));
+
+        render(<NoteContent content="**Prep**" format="markdown" />);
+
+        await waitFor(() => expect(mockRenderNote).toHaveBeenCalled());
+        expect(screen.getByTestId('client-markdown')).toHaveTextContent('**Prep**');
+    });
+
+    it('injects HTML notes without a server round trip', () => {
+        render(<NoteContent content="<p>Hello <strong>there</strong></p>" format="html" />);
+
+        expect(screen.getByText('there').tagName).toBe('STRONG');
+        expect(mockRenderNote).not.toHaveBeenCalled();
+    });
+});
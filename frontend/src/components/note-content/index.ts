@@ -0,0 +1,2 @@
+export { NoteContent } from './note-content';
+export type { NoteContentProps } from './note-content';
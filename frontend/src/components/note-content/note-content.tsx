@@ -0,0 +1,74 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import ReactMarkdown from 'react-markdown';
+import remarkGfm from 'remark-gfm';
+
+import { sanitizeHtml, sanitizeRenderedNote } from '@/lib/sanitizer';
+import { renderNote } from '@/services/note-service';
+import type { NoteFormat } from '@/types/note';
+
+export interface NoteContentProps {
+    content: string;
+    format?: NoteFormat;
+    className?: string;
+    onClick?: () => void;
+}
+
+/**
+ * Displays a note in its stored format. Markdown is rendered by the server,
+ * which resolves `file:<id>` references to the user's files. Until that
+ * arrives, or if it fails, Markdown is rendered here without raw HTML
+ * support. HTML notes are sanitized before being injected.
+ */
+export function NoteContent({ content, format, className, onClick }: NoteContentProps) {
+    const [renderedHtml, setRenderedHtml] = useState<string | null>(null);
+
+    useEffect(() => {
+        setRenderedHtml(null);
+        if (format !== 'markdown' || !content) {
+            return;
+        }
+
+        let cancelled = false;
+        renderNote(content, format)
+            .then((html) => {
+                if (!cancelled) {
+                    setRenderedHtml(html);
+                }
+            })
+            .catch(() => {
+                // Keep the client-side rendering
+            });
+
+        return () => {
+            cancelled = true;
+        };
+    }, [content, format]);
+
+    if (format === 'markdown') {
+        if (renderedHtml !== null) {
+            return (
+                <div
+                    className={className}
+                    onClick={onClick}
+                    dangerouslySetInnerHTML={{ __html: sanitizeRenderedNote(renderedHtml) }}
+                />
+            );
+        }
+
+        return (
+            <div className={className} onClick={onClick}>
+                <ReactMarkdown remarkPlugins={[remarkGfm]}>{content}</ReactMarkdown>
+            </div>
+        );
+    }
+
+    return (
+        <div
+            className={className}
+            onClick={onClick}
+            dangerouslySetInnerHTML={{ __html: sanitizeHtml(content) }}
+        />
+    );
+}
@@ -136,7 +136,6 @@ export function useFileUpload(options: UseFileUploadOptions): UseFileUploadRetur
                 file.size,
                 applicationId,
                 interviewId,
-                submissionContext,
             );
 
             if (controller.signal.aborted) return;
@@ -174,9 +174,9 @@ export async function confirmUpload(
     fileType: string,
     fileSize: number,
     applicationId: string,
-    interviewId?: string,
-    submissionContext?: 'assessment'
+    interviewId?: string
 ): Promise<FileRecord> {
+    // The upload's limits follow from the key the presign step issued
     const response = await api.post('/api/files/confirm-upload', {
         s3_key: s3Key,
         file_name: fileName,
@@ -184,7 +184,6 @@ export async function confirmUpload(
         file_size: fileSize,
         application_id: applicationId,
         ...(interviewId && { interview_id: interviewId }),
-        ...(submissionContext && { submission_context: submissionContext }),
     }, { _suppressToast: true });
     return response.data.data;
 }
@@ -25,3 +25,28 @@ export function sanitizeHtml(dirty: string): string {
         FORCE_BODY: true,
     });
 }
+
+const NOTE_TAGS = [
+    ...ALLOWED_TAGS,
+    'img', 'hr', 'del',
+    'table', 'thead', 'tbody', 'tr', 'th', 'td',
+];
+
+const NOTE_ATTR = [...ALLOWED_ATTR, 'src', 'alt', 'title'];
+
+/**
+ * Sanitizes a note rendered by the server. Rendered notes can embed the
+ * user's uploaded images and GFM tables, which plain notes can't.
+ */
+export function sanitizeRenderedNote(dirty: string): string {
+    if (typeof window === 'undefined') {
+        return dirty;
+    }
+
+    return DOMPurify.sanitize(dirty, {
+        ALLOWED_TAGS: NOTE_TAGS,
+        ALLOWED_ATTR: NOTE_ATTR,
+        ADD_ATTR: ['rel'],
+        FORCE_BODY: true,
+    });
+}
@@ -1,4 +1,5 @@
 import api from '@/lib/axios';
+import type { NoteFormat } from '@/types/note';
 
 // Types matching backend models
 
@@ -35,6 +36,7 @@ export interface ApplicationWithDetails {
     offer_received: boolean;
     attempt_number: number;
     notes?: string;
+    notes_format?: NoteFormat;
     resume_file_id?: string;
     cover_letter_file_id?: string;
     created_at: string;
@@ -1,4 +1,5 @@
 import api from '@/lib/axios';
+import type { NoteFormat } from '@/types/note';
 
 export type InterviewType =
     | 'phone_screen'
@@ -124,6 +125,7 @@ export interface InterviewNote {
     interview_id: string;
     note_type: string;
     content?: string;
+    content_format?: NoteFormat;
     created_at: string;
     updated_at: string;
 }
@@ -0,0 +1,15 @@
+import api from '@/lib/axios';
+import type { NoteFormat } from '@/types/note';
+
+/**
+ * Renders a note to sanitized HTML on the server, which turns `file:<id>`
+ * links and images into download URLs for the user's own files.
+ */
+export async function renderNote(content: string, format: NoteFormat): Promise<string> {
+    const response = await api.post(
+        '/api/notes/render',
+        { content, format },
+        { _suppressToast: true }
+    );
+    return response.data.data.html;
+}
@@ -1,2 +1,3 @@
 export * from "./job-type";
 export * from "./search";
+export * from "./note";
@@ -0,0 +1,3 @@
+// Notes are stored either as HTML (sanitized by the backend on save) or as
+// Markdown source, which has to be rendered before display.
+export type NoteFormat = 'html' | 'markdown';